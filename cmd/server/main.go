@@ -2,56 +2,398 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/djurica-surla/backend-homework/internal/auth"
 	"github.com/djurica-surla/backend-homework/internal/config"
 	"github.com/djurica-surla/backend-homework/internal/database"
+	"github.com/djurica-surla/backend-homework/internal/health"
+	"github.com/djurica-surla/backend-homework/internal/job"
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/djurica-surla/backend-homework/internal/metrics"
+	"github.com/djurica-surla/backend-homework/internal/ratelimit"
+	"github.com/djurica-surla/backend-homework/internal/server"
 	"github.com/djurica-surla/backend-homework/internal/service"
 	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tracing"
 	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel"
 )
 
 func main() {
 	// Loads the app config from config.json
 	config.LoadAppConfig()
 
+	// Replace the default logger with the configured one, used by every package.
+	logger, err := logging.New(logging.Config{
+		Level:  config.AppConfig.LogLevel,
+		Format: config.AppConfig.LogFormat,
+	}, os.Stderr)
+	if err != nil {
+		fatal("error setting up logging", err)
+	}
+	slog.SetDefault(logger)
+
+	// Cancelled on SIGINT or SIGTERM, which stops the background jobs and shuts the server down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Install the global tracer provider before anything starts spans, including the database queries.
+	tracer, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName:  "backend-homework",
+		Exporter:     config.AppConfig.TraceExporter,
+		File:         config.AppConfig.TraceFile,
+		OTLPEndpoint: config.AppConfig.TraceOTLPEndpoint,
+		OTLPInsecure: config.AppConfig.TraceOTLPInsecure,
+		SampleRatio:  config.AppConfig.TraceSampleRatio,
+	})
+	if err != nil {
+		fatal("error setting up tracing", err)
+	}
+
 	// Attempt to establish a connection with the database.
 	connection, err := database.Connect(
-		context.Background(),
-		database.Config{DSN: config.AppConfig.DSN},
+		ctx,
+		database.Config{DSN: config.AppConfig.DSN, BusyTimeout: config.AppConfig.DatabaseBusyTimeout},
 	)
 	if err != nil {
-		log.Fatal(err)
+		fatal("error connecting to database", err)
 	}
 
 	// Run up migrations to create database schema.
-	err = database.Migrate(connection, "migrations")
+	migrator, err := database.NewMigrator(connection, "migrations")
+	if err != nil {
+		fatal("error reading migrations", err)
+	}
+
+	err = migrator.Up()
 	if err != nil {
-		log.Fatal(err)
+		fatal("error migrating database", err)
 	}
 
-	// Instantiate question storage.
-	questionStorage := storage.NewQuestionStore(connection)
+	// Instantiate prometheus metrics, including the stats of the database connection pool.
+	appMetrics := metrics.New(connection)
+
+	// Instantiate transactor, which lets services commit the writes of several stores together.
+	transactor := storage.NewTransactor(connection)
+
+	// Instantiate question storage, instrumented to record query durations and question operations.
+	questionStorage := storage.NewInstrumentedQuestionStore(storage.NewQuestionStore(connection), appMetrics)
+
+	// Instantiate question option storage, instrumented to record query durations.
+	questionOptionStorage := storage.NewInstrumentedQuestionOptionStore(
+		storage.NewQuestionOptionStore(connection), appMetrics)
+
+	// Instantiate attachment metadata storage.
+	attachmentStorage := storage.NewAttachmentStore(connection)
+
+	// Instantiate local filesystem storage for attachment contents.
+	blobStorage, err := storage.NewLocalBlobStore(config.AppConfig.AttachmentDir)
+	if err != nil {
+		fatal("error creating attachment storage", err)
+	}
+
+	// Instantiate question hint storage.
+	questionHintStorage := storage.NewQuestionHintStore(connection)
+
+	// Instantiate review storage.
+	reviewStorage := storage.NewReviewStore(connection)
+
+	// Instantiate question service, traced so each method gets a span.
+	// Writes run within a transaction, deleting options or questions also deletes their attachments
+	// and deleting questions their review schedules.
+	questionService := service.NewTracedQuestionService(service.NewQuestionService(questionStorage,
+		questionOptionStorage, attachmentStorage, blobStorage, questionHintStorage, reviewStorage, transactor,
+		service.NewRolePolicy()), otel.GetTracerProvider())
+
+	// Instantiate attachment service, attachments are managed by callers who may modify their question.
+	attachmentService := service.NewAttachmentService(attachmentStorage, blobStorage,
+		questionStorage, questionOptionStorage, service.AttachmentPolicy{
+			MaxSize:             config.AppConfig.AttachmentMaxSize,
+			AllowedContentTypes: config.AppConfig.AttachmentContentTypes,
+		}, service.NewRolePolicy())
+
+	// Instantiate question response storage.
+	questionResponseStorage := storage.NewQuestionResponseStore(connection)
+
+	// Instantiate response service.
+	responseService := service.NewResponseService(questionResponseStorage, questionStorage,
+		questionOptionStorage, questionStorage)
 
-	// Instantiate question option storage.
-	questionOptionStorage := storage.NewQuestionOptionStore(connection)
+	// Background jobs run until shutdown, the server waits for them before closing the database.
+	jobs := job.NewGroup(ctx)
 
-	// Instantiate question service.
-	questionService := service.NewQuestionService(questionStorage, questionOptionStorage)
+	// Periodically recalculate empirical difficulty of questions from recorded responses.
+	jobs.Go("difficulty recalculation",
+		config.AppConfig.DifficultyRecalculationInterval, responseService.RecalculateDifficulty)
+
+	// Instantiate statistics storage and service.
+	statisticsStorage := storage.NewStatisticsStore(connection)
+	statisticsService := service.NewStatisticsService(statisticsStorage, questionStorage,
+		service.NewRolePolicy())
+
+	// Instantiate review service.
+	reviewService := service.NewReviewService(reviewStorage, questionService, time.Now)
+
+	// Instantiate practice storage and service.
+	practiceStorage := storage.NewPracticeStore(connection)
+	practiceService := service.NewPracticeService(practiceStorage, questionStorage,
+		questionService, questionOptionStorage, time.Now)
+
+	// Instantiate run storage and leaderboard service.
+	runStorage := storage.NewRunStore(connection)
+	leaderboardService := service.NewLeaderboardService(runStorage, questionOptionStorage, time.Now)
+
+	// Instantiate user storage and service.
+	userStorage := storage.NewUserStore(connection)
+	userService := service.NewUserService(userStorage, config.AppConfig.SessionTTL, time.Now)
+
+	// Periodically delete expired sessions.
+	jobs.Go("session cleanup",
+		config.AppConfig.TokenCleanupInterval, userService.DeleteExpiredSessions)
+
+	// Instantiate jwt manager which signs and verifies access tokens.
+	jwtManager, err := newJWTManager(config.AppConfig)
+	if err != nil {
+		fatal("error creating jwt manager", err)
+	}
+
+	// Instantiate token storage and service.
+	tokenStorage := storage.NewTokenStore(connection)
+	tokenService := service.NewTokenService(tokenStorage, jwtManager, userService, service.TokenPolicy{
+		AccessTTL:  config.AppConfig.JWTAccessTTL,
+		RefreshTTL: config.AppConfig.JWTRefreshTTL,
+	}, time.Now)
+
+	// Periodically delete expired refresh tokens and revocation list entries.
+	jobs.Go("token cleanup",
+		config.AppConfig.TokenCleanupInterval, tokenService.DeleteExpiredTokens)
+
+	// Instantiate api key storage and service.
+	apiKeyStorage := storage.NewAPIKeyStore(connection)
+	apiKeyService := service.NewAPIKeyService(apiKeyStorage, time.Now)
+
+	// Instantiate qti service, importing every package within a single transaction.
+	qtiService := service.NewQTIService(questionService, transactor)
 
 	// Instantiate mux router.
 	router := mux.NewRouter().StrictSlash(true)
 
-	// Instantiate question handler.
-	handler := transporthttp.NewQuestionHandler(questionService)
+	// Respond to unknown routes and methods with json errors like every other error response.
+	router.NotFoundHandler = transporthttp.NewNotFoundHandler()
+	router.MethodNotAllowedHandler = transporthttp.NewMethodNotAllowedHandler()
+
+	// Start a span for every routed request, continuing the trace of the W3C traceparent header.
+	router.Use(otelmux.Middleware("backend-homework"))
+
+	// Assign or propagate the request id and log every routed request.
+	router.Use(transporthttp.NewRequestLoggingMiddleware(logger))
+
+	// Respond with a json 500 instead of dropping the connection when a handler panics.
+	router.Use(transporthttp.NewRecoveryMiddleware())
+
+	// Record the count and duration of every routed request.
+	router.Use(transporthttp.NewMetricsMiddleware(appMetrics))
+
+	// Liveness has no checks of its own, the process answering is enough. Readiness requires the database.
+	liveness := health.NewRegistry(config.AppConfig.HealthCheckTimeout)
+	readiness := health.NewRegistry(config.AppConfig.HealthCheckTimeout)
+	readiness.Register("database", (*sql.DB)(connection).PingContext)
+	readiness.Register("migrations", migrator.CheckVersion)
+
+	// Question listings can be written as json (the default), csv, yaml or MessagePack.
+	questionEncoders := transporthttp.NewEncoderRegistry(
+		transporthttp.JSONEncoder{},
+		transporthttp.CSVEncoder{},
+		transporthttp.YAMLEncoder{},
+		transporthttp.MessagePackEncoder{},
+	)
+
+	// Token buckets of the rate limits are kept in memory of this instance.
+	rateLimitStore := ratelimit.NewMemoryStore(time.Now)
+
+	// Periodically delete the buckets of clients which stopped sending requests.
+	jobs.Go("rate limit pruning",
+		config.AppConfig.RateLimitPruneInterval, rateLimitStore.Prune)
 
-	// Register routes for question handler.
-	handler.RegisterRoutes(router)
+	// Instantiate handlers and register their routes. Metrics, probes and the OpenAPI document with its
+	// Swagger UI docs are served ahead of the api middlewares, every other route passes through them.
+	transporthttp.RegisterRoutes(router, transporthttp.Handlers{
+		Metrics: appMetrics.Handler(),
+		Health:  transporthttp.NewHealthHandler(liveness, readiness),
+		OpenAPI: transporthttp.NewOpenAPIHandler(transporthttp.NewOpenAPIDocument("1.0.0", questionEncoders)),
+
+		Question:    transporthttp.NewQuestionHandler(questionService, config.AppConfig.MaxBodySize, questionEncoders),
+		QTI:         transporthttp.NewQTIHandler(qtiService),
+		Attachment:  transporthttp.NewAttachmentHandler(attachmentService, config.AppConfig.AttachmentMaxSize),
+		Response:    transporthttp.NewResponseHandler(responseService, config.AppConfig.MaxBodySize),
+		Statistics:  transporthttp.NewStatisticsHandler(statisticsService),
+		Review:      transporthttp.NewReviewHandler(reviewService, config.AppConfig.MaxBodySize),
+		Practice:    transporthttp.NewPracticeHandler(practiceService, config.AppConfig.MaxBodySize),
+		Leaderboard: transporthttp.NewLeaderboardHandler(leaderboardService, config.AppConfig.MaxBodySize),
+		User:        transporthttp.NewUserHandler(userService, config.AppConfig.MaxBodySize),
+		Token:       transporthttp.NewTokenHandler(tokenService, config.AppConfig.MaxBodySize),
+		APIKey: transporthttp.NewAPIKeyHandler(apiKeyService, config.AppConfig.AdminToken,
+			config.AppConfig.MaxBodySize),
+	},
+		// Limit the requests of each client ip ahead of authentication, so guessing credentials is limited too.
+		transporthttp.NewClientIPRateLimitMiddleware(rateLimitStore, rateLimitPolicy(config.AppConfig)),
+
+		// Resolve the claims and user of the jwt or the user of the session token into the request context.
+		transporthttp.NewJWTMiddleware(tokenService),
+		transporthttp.NewAuthMiddleware(userService),
+
+		// Resolve the api key of machine clients into the request context.
+		transporthttp.NewAPIKeyMiddleware(apiKeyService),
+
+		// Resolve the tenant whose question bank the request works with into the request context, the tenant of
+		// the authenticated user or api key, the one named by the tenant header for admins and the trusted proxy,
+		// or else the default tenant.
+		transporthttp.NewTenantMiddleware(tenantPolicy(config.AppConfig)),
+
+		// Resolve the caller and its role, from trusted proxy headers when configured or else from the user or api key.
+		transporthttp.NewIdentityMiddleware(identityResolvers(config.AppConfig)...),
+
+		// Limit the requests of each caller or client ip.
+		transporthttp.NewRateLimitMiddleware(rateLimitStore, rateLimitPolicy(config.AppConfig)),
+	)
+
+	// Compress responses with the content coding the client prefers.
+	compression, err := transporthttp.NewCompressionMiddleware(config.AppConfig.CompressionMinSize,
+		config.AppConfig.CompressionEncodings...)
+	if err != nil {
+		fatal("error creating compression middleware", err)
+	}
+
+	// Let the allowed browser frontends call the api.
+	cors, err := transporthttp.NewCORSMiddleware(transporthttp.CORSPolicy{
+		AllowedOrigins:   config.AppConfig.CORSAllowedOrigins,
+		AllowedMethods:   config.AppConfig.CORSAllowedMethods,
+		AllowedHeaders:   config.AppConfig.CORSAllowedHeaders,
+		ExposedHeaders:   config.AppConfig.CORSExposedHeaders,
+		AllowCredentials: config.AppConfig.CORSAllowCredentials,
+		MaxAge:           config.AppConfig.CORSMaxAge,
+	})
+	if err != nil {
+		fatal("error creating cors middleware", err)
+	}
+
+	// Add security headers to every response and apply cors. Both wrap the router,
+	// so they also apply to preflight requests and unrouted responses.
+	rootHandler := transporthttp.NewSecurityHeadersMiddleware(transporthttp.SecurityHeaders{
+		ContentSecurityPolicy:   config.AppConfig.ContentSecurityPolicy,
+		FrameOptions:            config.AppConfig.FrameOptions,
+		ReferrerPolicy:          config.AppConfig.ReferrerPolicy,
+		StrictTransportSecurity: config.AppConfig.StrictTransportSecurity,
+	})(cors(compression(router)))
+
+	// Start the server, once ctx is cancelled it drains in-flight requests, waits for the background jobs
+	// and then closes the database.
+	srv := server.New(server.Config{
+		Addr:              fmt.Sprintf(":%v", config.AppConfig.Port),
+		ReadTimeout:       config.AppConfig.ReadTimeout,
+		ReadHeaderTimeout: config.AppConfig.ReadHeaderTimeout,
+		WriteTimeout:      config.AppConfig.WriteTimeout,
+		IdleTimeout:       config.AppConfig.IdleTimeout,
+		ShutdownTimeout:   config.AppConfig.ShutdownTimeout,
+	}, rootHandler, jobs, (*sql.DB)(connection), tracer)
+
+	err = srv.Run(ctx)
+	if err != nil {
+		fatal("error running server", err)
+	}
+}
+
+// fatal logs the error and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// identityResolvers returns the caller identity resolvers enabled by the app config, in order of precedence.
+func identityResolvers(appConfig *config.Config) []transporthttp.IdentityResolver {
+	resolvers := []transporthttp.IdentityResolver{}
+
+	if appConfig.TrustedIdentityHeader != "" && appConfig.TrustedRoleHeader != "" {
+		resolvers = append(resolvers, transporthttp.NewTrustedHeaderResolver(
+			appConfig.TrustedIdentityHeader, appConfig.TrustedRoleHeader))
+	}
+
+	return append(resolvers, transporthttp.NewAuthenticatedResolver())
+}
+
+// tenantPolicy returns the tenant resolution of the app config, the proxy is only trusted when it sets both
+// trusted headers.
+func tenantPolicy(appConfig *config.Config) transporthttp.TenantPolicy {
+	policy := transporthttp.TenantPolicy{
+		Header:        appConfig.TenantHeader,
+		DefaultTenant: appConfig.DefaultTenant,
+		AdminToken:    appConfig.AdminToken,
+	}
+
+	if appConfig.TrustedIdentityHeader != "" && appConfig.TrustedRoleHeader != "" {
+		policy.TrustedIdentityHeader = appConfig.TrustedIdentityHeader
+	}
+
+	return policy
+}
+
+// rateLimitPolicy returns the rate limits of the app config.
+func rateLimitPolicy(appConfig *config.Config) transporthttp.RateLimitPolicy {
+	policy := transporthttp.RateLimitPolicy{
+		Default: ratelimit.Limit{
+			Requests: appConfig.RateLimitDefault.Requests,
+			Period:   appConfig.RateLimitDefault.Period,
+		},
+		ClientIP: ratelimit.Limit{
+			Requests: appConfig.RateLimitClientIP.Requests,
+			Period:   appConfig.RateLimitClientIP.Period,
+		},
+		ClientIPHeader: appConfig.RateLimitClientIPHeader,
+	}
+
+	for _, route := range appConfig.RateLimitRoutes {
+		policy.Routes = append(policy.Routes, transporthttp.RouteLimit{
+			Method: route.Method,
+			Path:   route.Path,
+			Limit:  ratelimit.Limit{Requests: route.Requests, Period: route.Period},
+		})
+	}
+
+	return policy
+}
+
+// newJWTManager creates the jwt manager from the app config, reading RS256 keys from their files.
+func newJWTManager(appConfig *config.Config) (*auth.JWTManager, error) {
+	jwtConfig := auth.JWTConfig{
+		Algorithm: appConfig.JWTAlgorithm,
+		Secret:    []byte(appConfig.JWTSecret),
+		Issuer:    appConfig.JWTIssuer,
+	}
+
+	var err error
+
+	if appConfig.JWTPrivateKeyFile != "" {
+		jwtConfig.PrivateKeyPEM, err = os.ReadFile(appConfig.JWTPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt private key: %w", err)
+		}
+	}
+
+	if appConfig.JWTPublicKeyFile != "" {
+		jwtConfig.PublicKeyPEM, err = os.ReadFile(appConfig.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt public key: %w", err)
+		}
+	}
 
-	// Start the server
-	log.Printf("starting server on port %s", config.AppConfig.Port)
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%v", config.AppConfig.Port), router))
+	return auth.NewJWTManager(jwtConfig)
 }
@@ -7,3 +7,20 @@ import (
 
 //go:generate mockgen -destination=internal/mock/questionStorerMock/questionStorerMock.go -package=questionStorerMock github.com/djurica-surla/backend-homework/internal/service QuestionStorer
 //go:generate mockgen -destination=internal/mock/questionOptionStorerMock/questionOptionStorerMock.go -package=questionOptionStorerMock github.com/djurica-surla/backend-homework/internal/service QuestionOptionStorer
+//go:generate mockgen -destination=internal/mock/questionManagerMock/questionManagerMock.go -package=questionManagerMock github.com/djurica-surla/backend-homework/internal/service QuestionManager
+//go:generate mockgen -destination=internal/mock/attachmentStorerMock/attachmentStorerMock.go -package=attachmentStorerMock github.com/djurica-surla/backend-homework/internal/service AttachmentStorer
+//go:generate mockgen -destination=internal/mock/blobStorerMock/blobStorerMock.go -package=blobStorerMock github.com/djurica-surla/backend-homework/internal/service BlobStorer
+//go:generate mockgen -destination=internal/mock/questionHintStorerMock/questionHintStorerMock.go -package=questionHintStorerMock github.com/djurica-surla/backend-homework/internal/service QuestionHintStorer
+//go:generate mockgen -destination=internal/mock/questionResponseStorerMock/questionResponseStorerMock.go -package=questionResponseStorerMock github.com/djurica-surla/backend-homework/internal/service QuestionResponseStorer
+//go:generate mockgen -destination=internal/mock/difficultyCalculatorMock/difficultyCalculatorMock.go -package=difficultyCalculatorMock github.com/djurica-surla/backend-homework/internal/service DifficultyCalculator
+//go:generate mockgen -destination=internal/mock/statisticsStorerMock/statisticsStorerMock.go -package=statisticsStorerMock github.com/djurica-surla/backend-homework/internal/service StatisticsStorer
+//go:generate mockgen -destination=internal/mock/reviewStorerMock/reviewStorerMock.go -package=reviewStorerMock github.com/djurica-surla/backend-homework/internal/service ReviewStorer
+//go:generate mockgen -destination=internal/mock/reviewScheduleDeleterMock/reviewScheduleDeleterMock.go -package=reviewScheduleDeleterMock github.com/djurica-surla/backend-homework/internal/service ReviewScheduleDeleter
+//go:generate mockgen -destination=internal/mock/questionGetterMock/questionGetterMock.go -package=questionGetterMock github.com/djurica-surla/backend-homework/internal/service QuestionGetter
+//go:generate mockgen -destination=internal/mock/practiceStorerMock/practiceStorerMock.go -package=practiceStorerMock github.com/djurica-surla/backend-homework/internal/service PracticeStorer
+//go:generate mockgen -destination=internal/mock/practiceQuestionPickerMock/practiceQuestionPickerMock.go -package=practiceQuestionPickerMock github.com/djurica-surla/backend-homework/internal/service PracticeQuestionPicker
+//go:generate mockgen -destination=internal/mock/runStorerMock/runStorerMock.go -package=runStorerMock github.com/djurica-surla/backend-homework/internal/service RunStorer
+//go:generate mockgen -destination=internal/mock/userStorerMock/userStorerMock.go -package=userStorerMock github.com/djurica-surla/backend-homework/internal/service UserStorer
+//go:generate mockgen -destination=internal/mock/tokenStorerMock/tokenStorerMock.go -package=tokenStorerMock github.com/djurica-surla/backend-homework/internal/service TokenStorer
+//go:generate mockgen -destination=internal/mock/credentialCheckerMock/credentialCheckerMock.go -package=credentialCheckerMock github.com/djurica-surla/backend-homework/internal/service CredentialChecker
+//go:generate mockgen -destination=internal/mock/apiKeyStorerMock/apiKeyStorerMock.go -package=apiKeyStorerMock github.com/djurica-surla/backend-homework/internal/service APIKeyStorer
@@ -0,0 +1,40 @@
+package auth
+
+import "context"
+
+// Scopes which can be granted to an api key.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// APIKey represents the api key a machine client authenticated with, within the tenant it belongs to.
+type APIKey struct {
+	ID       int
+	Name     string
+	Scopes   []string
+	TenantID string
+}
+
+type apiKeyContextKey struct{}
+
+// HasScope reports whether the api key was granted the scope.
+func (k APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAPIKey returns a copy of the context which carries the authenticated api key.
+func WithAPIKey(ctx context.Context, key APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext returns the authenticated api key carried by the context, if any.
+func APIKeyFromContext(ctx context.Context) (APIKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(APIKey)
+	return key, ok
+}
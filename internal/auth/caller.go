@@ -0,0 +1,47 @@
+package auth
+
+import "context"
+
+// Role represents what a caller is allowed to do with questions.
+type Role string
+
+// Roles a caller can have.
+const (
+	// Manages every question.
+	RoleAdmin Role = "admin"
+	// Creates questions and manages only their own.
+	RoleAuthor Role = "author"
+	// Reads every question including option correctness, but can't write.
+	RoleReviewer Role = "reviewer"
+	// Reads questions without option correctness.
+	RoleTaker Role = "taker"
+)
+
+// ParseRole returns the role with the provided name, reporting false for unknown roles.
+func ParseRole(name string) (Role, bool) {
+	switch role := Role(name); role {
+	case RoleAdmin, RoleAuthor, RoleReviewer, RoleTaker:
+		return role, true
+	default:
+		return "", false
+	}
+}
+
+// Caller represents the identity and role of whoever made the request.
+type Caller struct {
+	ID   string
+	Role Role
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of the context which carries the caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller carried by the context, if any.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+	return caller, ok
+}
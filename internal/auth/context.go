@@ -0,0 +1,37 @@
+package auth
+
+import "context"
+
+// User represents the authenticated caller of a request, within the tenant it belongs to.
+type User struct {
+	ID       int
+	Username string
+	TenantID string
+}
+
+type (
+	userContextKey   struct{}
+	claimsContextKey struct{}
+)
+
+// WithUser returns a copy of the context which carries the authenticated user.
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user carried by the context, if any.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
+
+// WithClaims returns a copy of the context which carries the claims of the access token.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the access token claims carried by the context, if any.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(Claims)
+	return claims, ok
+}
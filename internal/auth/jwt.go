@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Returned when a token can't be parsed, has an invalid signature or is expired.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims represents the claims carried by an access token.
+type Claims struct {
+	jwt.RegisteredClaims
+	Username string `json:"username"`
+	Tenant   string `json:"tenant,omitempty"`
+}
+
+// JWTConfig holds the signing settings of access tokens.
+// Secret is used by HS256, PEM encoded keys are used by RS256.
+type JWTConfig struct {
+	Algorithm     string
+	Secret        []byte
+	PrivateKeyPEM []byte
+	PublicKeyPEM  []byte
+	Issuer        string
+}
+
+// JWTManager signs and verifies access tokens with a single algorithm.
+type JWTManager struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	issuer    string
+}
+
+// NewJWTManager creates a new instance of jwt manager for the configured algorithm.
+func NewJWTManager(config JWTConfig) (*JWTManager, error) {
+	manager := &JWTManager{issuer: config.Issuer}
+
+	switch config.Algorithm {
+	case "", jwt.SigningMethodHS256.Alg():
+		if len(config.Secret) < 32 {
+			return nil, errors.New("HS256 secret must be at least 32 bytes")
+		}
+		manager.method = jwt.SigningMethodHS256
+		manager.signKey = config.Secret
+		manager.verifyKey = config.Secret
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(config.PrivateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing RS256 private key: %w", err)
+		}
+		manager.method = jwt.SigningMethodRS256
+		manager.signKey = privateKey
+		manager.verifyKey = &privateKey.PublicKey
+
+		// Public key is optional, when present it has to match the private key.
+		if len(config.PublicKeyPEM) > 0 {
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(config.PublicKeyPEM)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing RS256 public key: %w", err)
+			}
+			if !publicKey.Equal(&privateKey.PublicKey) {
+				return nil, errors.New("RS256 public key doesn't match the private key")
+			}
+			manager.verifyKey = publicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %s", config.Algorithm)
+	}
+
+	return manager, nil
+}
+
+// Sign returns the signed token of the claims, issuer is set by the manager.
+func (m *JWTManager) Sign(claims Claims) (string, error) {
+	claims.Issuer = m.issuer
+
+	return jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+}
+
+// Parse verifies the token signature and its issuer and expiry at the provided time.
+func (m *JWTManager) Parse(token string, now time.Time) (Claims, error) {
+	claims := Claims{}
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return m.verifyKey, nil
+	}, jwt.WithValidMethods([]string{m.method.Alg()}), jwt.WithoutClaimsValidation())
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	if !claims.VerifyIssuer(m.issuer, true) {
+		return Claims{}, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return Claims{}, fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return Claims{}, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// User returns the user identified by the subject of the claims, within the tenant of the claims.
+func (c Claims) User() (User, error) {
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil {
+		return User{}, fmt.Errorf("%w: subject is not a user id", ErrInvalidToken)
+	}
+
+	// Tokens signed before tenants were bound to users carry no tenant, their users belong to the default tenant.
+	tenantID := c.Tenant
+	if tenantID == "" {
+		tenantID = tenant.Default
+	}
+
+	return User{ID: id, Username: c.Username, TenantID: tenantID}, nil
+}
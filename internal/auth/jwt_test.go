@@ -0,0 +1,129 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSecret = []byte("test-secret-which-is-long-enough-for-hs256")
+	testNow    = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+)
+
+func newTestClaims() auth.Claims {
+	return auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "token-id",
+			Subject:   "7",
+			IssuedAt:  jwt.NewNumericDate(testNow),
+			ExpiresAt: jwt.NewNumericDate(testNow.Add(time.Minute)),
+		},
+		Username: "student",
+		Tenant:   "school-a",
+	}
+}
+
+func TestJWTManager_HS256(t *testing.T) {
+	manager, err := auth.NewJWTManager(auth.JWTConfig{Algorithm: "HS256", Secret: testSecret, Issuer: "test"})
+	assert.Nil(t, err)
+
+	token, err := manager.Sign(newTestClaims())
+	assert.Nil(t, err)
+
+	t.Run("Should parse valid token", func(t *testing.T) {
+		claims, err := manager.Parse(token, testNow)
+		assert.Nil(t, err)
+		assert.Equal(t, "test", claims.Issuer)
+
+		user, err := claims.User()
+		assert.Nil(t, err)
+		assert.Equal(t, auth.User{ID: 7, Username: "student", TenantID: "school-a"}, user)
+	})
+
+	t.Run("Should put user of token without tenant in the default tenant", func(t *testing.T) {
+		claims := newTestClaims()
+		claims.Tenant = ""
+
+		user, err := claims.User()
+		assert.Nil(t, err)
+		assert.Equal(t, tenant.Default, user.TenantID)
+	})
+
+	t.Run("Should reject expired token", func(t *testing.T) {
+		_, err := manager.Parse(token, testNow.Add(time.Minute))
+		assert.True(t, errors.Is(err, auth.ErrInvalidToken))
+	})
+
+	t.Run("Should reject token signed with another secret", func(t *testing.T) {
+		other, err := auth.NewJWTManager(auth.JWTConfig{Secret: []byte("another-secret-which-is-long-enough-too"), Issuer: "test"})
+		assert.Nil(t, err)
+
+		_, err = other.Parse(token, testNow)
+		assert.True(t, errors.Is(err, auth.ErrInvalidToken))
+	})
+
+	t.Run("Should reject token from another issuer", func(t *testing.T) {
+		other, err := auth.NewJWTManager(auth.JWTConfig{Secret: testSecret, Issuer: "other"})
+		assert.Nil(t, err)
+
+		_, err = other.Parse(token, testNow)
+		assert.True(t, errors.Is(err, auth.ErrInvalidToken))
+	})
+
+	t.Run("Should reject short secret", func(t *testing.T) {
+		_, err := auth.NewJWTManager(auth.JWTConfig{Algorithm: "HS256", Secret: []byte("short")})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestJWTManager_RS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.Nil(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	manager, err := auth.NewJWTManager(auth.JWTConfig{
+		Algorithm:     "RS256",
+		PrivateKeyPEM: privatePEM,
+		PublicKeyPEM:  publicPEM,
+		Issuer:        "test",
+	})
+	assert.Nil(t, err)
+
+	token, err := manager.Sign(newTestClaims())
+	assert.Nil(t, err)
+
+	t.Run("Should parse valid token", func(t *testing.T) {
+		claims, err := manager.Parse(token, testNow)
+		assert.Nil(t, err)
+		assert.Equal(t, "token-id", claims.ID)
+	})
+
+	t.Run("Should reject HS256 token signed with the public key", func(t *testing.T) {
+		claims := newTestClaims()
+		claims.Issuer = "test"
+
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(publicPEM)
+		assert.Nil(t, err)
+
+		_, err = manager.Parse(forged, testNow)
+		assert.True(t, errors.Is(err, auth.ErrInvalidToken))
+	})
+}
@@ -1,31 +1,225 @@
 package config
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // A struct which holds the app configuration.
 type Config struct {
-	Port string `mapstructure:"port"`
-	DSN  string `mapstructure:"dsn"`
+	Port                   string   `mapstructure:"port"`
+	DSN                    string   `mapstructure:"dsn"`
+	AttachmentDir          string   `mapstructure:"attachment_dir"`
+	AttachmentMaxSize      int64    `mapstructure:"attachment_max_size"`
+	AttachmentContentTypes []string `mapstructure:"attachment_content_types"`
+	// How long a database write waits for the write of another request to finish before failing, e.g. "5s".
+	DatabaseBusyTimeout time.Duration `mapstructure:"database_busy_timeout"`
+	// Largest json request body in bytes, larger bodies are rejected with 413.
+	MaxBodySize int64 `mapstructure:"max_body_size"`
+	// Minimum level of logged records (debug, info, warn or error) and their format (json or text).
+	LogLevel  string `mapstructure:"log_level"`
+	LogFormat string `mapstructure:"log_format"`
+	// Timeouts of the http server and how long in-flight requests may take to drain on shutdown, e.g. "30s".
+	ReadTimeout       time.Duration `mapstructure:"read_timeout"`
+	ReadHeaderTimeout time.Duration `mapstructure:"read_header_timeout"`
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`
+	ShutdownTimeout   time.Duration `mapstructure:"shutdown_timeout"`
+	// How long each check of /healthz and /readyz may take before it fails, e.g. "2s".
+	HealthCheckTimeout time.Duration `mapstructure:"health_check_timeout"`
+	// Where spans are exported: none (the default), stdout, file (trace_file, never rotated)
+	// or otlp (an OTLP/HTTP collector at trace_otlp_endpoint).
+	TraceExporter     string  `mapstructure:"trace_exporter"`
+	TraceFile         string  `mapstructure:"trace_file"`
+	TraceOTLPEndpoint string  `mapstructure:"trace_otlp_endpoint"`
+	TraceOTLPInsecure bool    `mapstructure:"trace_otlp_insecure"`
+	TraceSampleRatio  float64 `mapstructure:"trace_sample_ratio"`
+	// How often empirical difficulty of questions is recalculated, e.g. "5m".
+	DifficultyRecalculationInterval time.Duration `mapstructure:"difficulty_recalculation_interval"`
+	// How long a login session stays valid, e.g. "24h".
+	SessionTTL time.Duration `mapstructure:"session_ttl"`
+	// Signing algorithm of access tokens, HS256 uses the secret and RS256 the PEM key files.
+	// The secret has no default, the server refuses to start with HS256 until one is configured.
+	JWTAlgorithm      string `mapstructure:"jwt_algorithm"`
+	JWTSecret         string `mapstructure:"jwt_secret"`
+	JWTPrivateKeyFile string `mapstructure:"jwt_private_key_file"`
+	JWTPublicKeyFile  string `mapstructure:"jwt_public_key_file"`
+	JWTIssuer         string `mapstructure:"jwt_issuer"`
+	// Lifetimes of access and refresh tokens, e.g. "15m" and "720h".
+	JWTAccessTTL  time.Duration `mapstructure:"jwt_access_ttl"`
+	JWTRefreshTTL time.Duration `mapstructure:"jwt_refresh_ttl"`
+	// How often expired sessions, refresh tokens and revocation list entries are deleted, e.g. "1h".
+	TokenCleanupInterval time.Duration `mapstructure:"token_cleanup_interval"`
+	// Token expected in the X-Admin-Token header of admin routes, admin routes are disabled when empty.
+	AdminToken string `mapstructure:"admin_token"`
+	// Headers a trusted proxy sets to the caller id and role, trusted headers are ignored when either is empty.
+	TrustedIdentityHeader string `mapstructure:"trusted_identity_header"`
+	TrustedRoleHeader     string `mapstructure:"trusted_role_header"`
+	// Header naming the tenant of logins, admin requests and requests of the trusted proxy, other unauthenticated
+	// requests belong to default_tenant or are rejected when it is empty. Authenticated requests belong to the
+	// tenant of their credentials.
+	TenantHeader  string `mapstructure:"tenant_header"`
+	DefaultTenant string `mapstructure:"default_tenant"`
+	// Token bucket limits of each client, e.g. {"requests": 60, "period": "1m"}, a zero limit doesn't limit.
+	// Route limits override the default for a method and mux path template, for every method when method is empty.
+	RateLimitDefault RateLimit        `mapstructure:"rate_limit_default"`
+	RateLimitRoutes  []RouteRateLimit `mapstructure:"rate_limit_routes"`
+	// Limit of each client ip over every route, taken before authentication so failed credentials count too.
+	RateLimitClientIP RateLimit `mapstructure:"rate_limit_client_ip"`
+	// Header a trusted proxy appends the client ip to, the last address of the header is used.
+	// Clients are limited by their remote address when empty.
+	RateLimitClientIPHeader string `mapstructure:"rate_limit_client_ip_header"`
+	// How often buckets of clients which stopped sending requests are deleted, e.g. "1m".
+	RateLimitPruneInterval time.Duration `mapstructure:"rate_limit_prune_interval"`
+	// Origins of browser frontends allowed to call the api ("*" for any), cross origin requests are refused when empty.
+	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"`
+	// Methods and headers frontends may send, headers they may read and whether they may send credentials,
+	// which requires the origins to be listed by name.
+	CORSAllowedMethods   []string `mapstructure:"cors_allowed_methods"`
+	CORSAllowedHeaders   []string `mapstructure:"cors_allowed_headers"`
+	CORSExposedHeaders   []string `mapstructure:"cors_exposed_headers"`
+	CORSAllowCredentials bool     `mapstructure:"cors_allow_credentials"`
+	// How long browsers cache preflight results, e.g. "10m".
+	CORSMaxAge time.Duration `mapstructure:"cors_max_age"`
+	// Security headers of every response, a header is left out when its setting is empty.
+	ContentSecurityPolicy   string `mapstructure:"content_security_policy"`
+	FrameOptions            string `mapstructure:"frame_options"`
+	ReferrerPolicy          string `mapstructure:"referrer_policy"`
+	StrictTransportSecurity string `mapstructure:"strict_transport_security"`
+	// Content codings responses are compressed with (zstd, gzip or deflate), in order of preference.
+	// Responses smaller than compression_min_size bytes aren't compressed.
+	CompressionEncodings []string `mapstructure:"compression_encodings"`
+	CompressionMinSize   int      `mapstructure:"compression_min_size"`
+}
+
+// RateLimit allows bursts of up to requests, refilled evenly over the period.
+type RateLimit struct {
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
+}
+
+// RouteRateLimit overrides the default rate limit for a route.
+type RouteRateLimit struct {
+	Method   string        `mapstructure:"method"`
+	Path     string        `mapstructure:"path"`
+	Requests int           `mapstructure:"requests"`
+	Period   time.Duration `mapstructure:"period"`
 }
 
 var AppConfig *Config
 
+// Placeholder jwt_secret which config.json shipped with, refused so it is never used to sign tokens.
+const placeholderJWTSecret = "development-only-secret-change-me-in-production"
+
 // Function which reads configuration from config.json.
 func LoadAppConfig() {
-	log.Println("loading server configuration...")
+	slog.Info("loading server configuration")
 	viper.AddConfigPath(".")
 	viper.SetConfigName("config")
 	viper.SetConfigType("json")
+	setDefaults()
 	err := viper.ReadInConfig()
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("error reading server configuration", "error", err)
+		os.Exit(1)
 	}
 	err = viper.Unmarshal(&AppConfig)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("error decoding server configuration", "error", err)
+		os.Exit(1)
 	}
+	err = AppConfig.validate()
+	if err != nil {
+		slog.Error("invalid server configuration", "error", err)
+		os.Exit(1)
+	}
+}
+
+// Checks settings which can't be used as they are.
+func (c *Config) validate() error {
+	// Intervals of the background jobs, a ticker can't tick every zero or negative duration.
+	intervals := []struct {
+		key      string
+		interval time.Duration
+	}{
+		{"difficulty_recalculation_interval", c.DifficultyRecalculationInterval},
+		{"token_cleanup_interval", c.TokenCleanupInterval},
+		{"rate_limit_prune_interval", c.RateLimitPruneInterval},
+	}
+
+	for _, setting := range intervals {
+		if setting.interval <= 0 {
+			return fmt.Errorf("%s must be a positive duration, got %s", setting.key, setting.interval)
+		}
+	}
+
+	// Anyone knowing the secret can sign access tokens of any user.
+	if c.JWTAlgorithm == "HS256" {
+		if c.JWTSecret == "" {
+			return fmt.Errorf("jwt_secret must be set when jwt_algorithm is HS256")
+		}
+		if c.JWTSecret == placeholderJWTSecret {
+			return fmt.Errorf("jwt_secret must be changed from the development placeholder")
+		}
+	}
+
+	return nil
+}
+
+// Sets default values for settings which are missing from config.json.
+func setDefaults() {
+	viper.SetDefault("log_level", "info")
+	viper.SetDefault("log_format", "json")
+	viper.SetDefault("read_timeout", "15s")
+	viper.SetDefault("read_header_timeout", "5s")
+	viper.SetDefault("write_timeout", "30s")
+	viper.SetDefault("idle_timeout", "120s")
+	viper.SetDefault("shutdown_timeout", "30s")
+	viper.SetDefault("health_check_timeout", "2s")
+	viper.SetDefault("trace_exporter", "none")
+	viper.SetDefault("trace_file", "traces.json")
+	viper.SetDefault("trace_otlp_endpoint", "localhost:4318")
+	viper.SetDefault("trace_sample_ratio", 1.0)
+	viper.SetDefault("database_busy_timeout", "5s")
+	viper.SetDefault("attachment_dir", "attachments")
+	viper.SetDefault("difficulty_recalculation_interval", "5m")
+	viper.SetDefault("session_ttl", "24h")
+	viper.SetDefault("jwt_algorithm", "HS256")
+	viper.SetDefault("jwt_issuer", "backend-homework")
+	viper.SetDefault("jwt_access_ttl", "15m")
+	viper.SetDefault("jwt_refresh_ttl", "720h")
+	viper.SetDefault("token_cleanup_interval", "1h")
+	viper.SetDefault("tenant_header", "X-Tenant-ID")
+	viper.SetDefault("default_tenant", "default")
+	viper.SetDefault("rate_limit_prune_interval", "1m")
+	viper.SetDefault("cors_allowed_methods", []string{"GET", "POST", "PUT", "DELETE"})
+	viper.SetDefault("cors_allowed_headers", []string{"Authorization", "Content-Type", "X-Tenant-ID", "X-Request-ID"})
+	viper.SetDefault("cors_exposed_headers", []string{
+		"X-Request-ID",
+		"RateLimit-Limit",
+		"RateLimit-Remaining",
+		"RateLimit-Reset",
+		"RateLimit-Policy",
+		"Retry-After",
+	})
+	viper.SetDefault("cors_max_age", "10m")
+	viper.SetDefault("content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	viper.SetDefault("frame_options", "DENY")
+	viper.SetDefault("referrer_policy", "no-referrer")
+	viper.SetDefault("compression_encodings", []string{"zstd", "gzip", "deflate"})
+	viper.SetDefault("compression_min_size", 1024)
+	viper.SetDefault("attachment_max_size", 5<<20)
+	viper.SetDefault("max_body_size", 1<<20)
+	viper.SetDefault("attachment_content_types", []string{
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"image/webp",
+		"audio/mpeg",
+		"video/mp4",
+	})
 }
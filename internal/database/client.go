@@ -3,14 +3,16 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/XSAM/otelsql"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "modernc.org/sqlite"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"modernc.org/sqlite"
 )
 
 const (
@@ -30,55 +32,64 @@ var (
 type Connection *sql.DB
 
 // Configuration for creating a new sqlite instance.
+// BusyTimeout is how long a write waits for the lock held by another connection before failing.
 type Config struct {
-	DSN string
+	DSN         string
+	BusyTimeout time.Duration
 }
 
 // Connect connects to the database using the provided DSN.
+// Connections use the write-ahead log, so reads don't wait for writes, and writes wait up to
+// the busy timeout for each other instead of failing at once.
+// Every query is traced with a span of the global tracer provider, carrying the sql statement.
 func Connect(
 	ctx context.Context,
 	cfg Config,
 ) (Connection, error) {
-	instance, err := sql.Open(sqliteDriver, cfg.DSN)
-	if err != nil {
-		return nil, ErrFailedConnection
-	}
+	instance := otelsql.OpenDB(connector{dsn: cfg.DSN, busyTimeout: cfg.BusyTimeout, driver: &sqlite.Driver{}},
+		otelsql.WithAttributes(semconv.DBSystemSqlite),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}))
 
-	err = instance.Ping()
+	err := instance.Ping()
 	if err != nil {
 		return nil, ErrFailedConnection
 	}
 
-	log.Println("database connection successful")
+	slog.Info("database connection successful")
 	return instance, nil
 }
 
-// Migrate makes sure database migrations are up to date.
-func Migrate(connection Connection, path string) error {
-	driver, err := sqlite.WithInstance(connection, &sqlite.Config{
-		MigrationsTable: fmt.Sprintf("%s_%s", sqliteMigrationsTable, sqlite.DefaultMigrationsTable),
-	})
+// connector opens sqlite connections and sets their pragmas, which apply to a single connection.
+type connector struct {
+	dsn         string
+	busyTimeout time.Duration
+	driver      driver.Driver
+}
+
+// Connect opens a new connection to the database.
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
 	if err != nil {
-		return fmt.Errorf("%s: %w", ErrDriver, err)
+		return nil, err
 	}
 
-	// Read migration files.
-	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", path), sqliteDriver, driver)
-	if err != nil {
-		return fmt.Errorf("%s: %w", ErrReadMigration, err)
+	pragmas := []string{
+		fmt.Sprintf("PRAGMA busy_timeout = %d", c.busyTimeout.Milliseconds()),
+		"PRAGMA journal_mode = WAL",
 	}
 
-	// Perform database migration.
-	err = m.Up()
-	if err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("%s: %w", ErrMigration, err)
-	} else if err == migrate.ErrNoChange {
-		v, _, _ := m.Version()
-		log.Printf("postgres migrations up to date, version: %d", v)
-	} else if err == nil {
-		v, _, _ := m.Version()
-		log.Printf("postgres database updated, version: %d", v)
+	for _, pragma := range pragmas {
+		_, err := conn.(driver.ExecerContext).ExecContext(ctx, pragma, nil)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("error setting %s %w", pragma, err)
+		}
 	}
 
-	return nil
+	return conn, nil
+}
+
+// Driver returns the sqlite driver.
+func (c connector) Driver() driver.Driver {
+	return c.driver
 }
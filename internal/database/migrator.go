@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// ErrMigrationVersion is returned when the database isn't at the version of the latest migration.
+var ErrMigrationVersion = errors.New("database migration version mismatch")
+
+// Migrator applies the migrations from the migrations directory and reports the version of the database.
+type Migrator struct {
+	migrate         *migrate.Migrate
+	expectedVersion uint
+}
+
+// NewMigrator creates a migrator for the migrations in the directory at path.
+func NewMigrator(connection Connection, path string) (*Migrator, error) {
+	driver, err := sqlite.WithInstance(connection, &sqlite.Config{
+		MigrationsTable: fmt.Sprintf("%s_%s", sqliteMigrationsTable, sqlite.DefaultMigrationsTable),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrDriver, err)
+	}
+
+	// Read migration files.
+	sourceDriver, err := source.Open(fmt.Sprintf("file://%s", path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadMigration, err)
+	}
+
+	expectedVersion, err := latestVersion(sourceDriver)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadMigration, err)
+	}
+
+	m, err := migrate.NewWithInstance("file", sourceDriver, sqliteDriver, driver)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrReadMigration, err)
+	}
+
+	return &Migrator{migrate: m, expectedVersion: expectedVersion}, nil
+}
+
+// Migrate makes sure database migrations are up to date.
+func Migrate(connection Connection, path string) error {
+	migrator, err := NewMigrator(connection, path)
+	if err != nil {
+		return err
+	}
+
+	return migrator.Up()
+}
+
+// Up runs the migrations which haven't been applied yet.
+func (m *Migrator) Up() error {
+	err := m.migrate.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("%s: %w", ErrMigration, err)
+	} else if err == migrate.ErrNoChange {
+		v, _, _ := m.migrate.Version()
+		slog.Info("database migrations up to date", "version", v)
+	} else if err == nil {
+		v, _, _ := m.migrate.Version()
+		slog.Info("database migrated", "version", v)
+	}
+
+	return nil
+}
+
+// CheckVersion fails unless the database is cleanly migrated to the latest migration.
+func (m *Migrator) CheckVersion(ctx context.Context) error {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("%w: no migrations applied, expected %d", ErrMigrationVersion, m.expectedVersion)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrMigrationVersion, err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%w: version %d is dirty", ErrMigrationVersion, version)
+	}
+
+	if version != m.expectedVersion {
+		return fmt.Errorf("%w: version %d, expected %d", ErrMigrationVersion, version, m.expectedVersion)
+	}
+
+	return nil
+}
+
+// latestVersion returns the version of the last migration available to the source.
+func latestVersion(sourceDriver source.Driver) (uint, error) {
+	version, err := sourceDriver.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := sourceDriver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		version = next
+	}
+}
@@ -0,0 +1,34 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_CheckVersion(t *testing.T) {
+	t.Run("Should pass once migrated to the latest migration", func(t *testing.T) {
+		ctx := context.Background()
+		connection, err := database.Connect(ctx, database.Config{DSN: filepath.Join(t.TempDir(), "homework.sqlite")})
+		require.NoError(t, err)
+		defer (*sql.DB)(connection).Close()
+
+		migrator, err := database.NewMigrator(connection, "../../migrations")
+		require.NoError(t, err)
+
+		err = migrator.CheckVersion(ctx)
+		assert.True(t, errors.Is(err, database.ErrMigrationVersion))
+
+		err = migrator.Up()
+		require.NoError(t, err)
+
+		err = migrator.CheckVersion(ctx)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Represents an api key of a machine client, identified by its prefix.
+type APIKey struct {
+	ID         int
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	TenantID   string
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Represents metadata of a file attached to a question or to a question option.
+// QuestionOptionID is only set for option attachments, they are stored without QuestionID
+// and read with the id of the question of their option.
+type Attachment struct {
+	ID               int
+	QuestionID       int
+	QuestionOptionID int
+	Filename         string
+	ContentType      string
+	Size             int64
+	StorageKey       string
+	CreatedAt        time.Time
+}
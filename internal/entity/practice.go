@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// Represents an adaptive practice session of a user.
+type PracticeSession struct {
+	ID        int
+	UserID    string
+	CreatedAt time.Time
+}
+
+// Represents an answer submitted within a practice session together with ratings after it.
+type PracticeAnswer struct {
+	ID             int
+	SessionID      int
+	QuestionID     int
+	Correct        bool
+	UserRating     float64
+	QuestionRating float64
+	AnsweredAt     time.Time
+}
@@ -1,15 +1,44 @@
 package entity
 
+import "time"
+
 // Represents question.
+// Difficulty is zero when not assigned, EmpiricalDifficulty is nil until responses are recorded.
+// Owner is empty for questions created before ownership was tracked.
 type Question struct {
-	ID   int
-	Body string
+	ID                  int
+	Body                string
+	Explanation         string
+	Difficulty          int
+	EmpiricalDifficulty *float64
+	ResponseCount       int
+	Owner               string
 }
 
 // Represents options for question.
 type QuestionOption struct {
+	ID          int
+	Body        string
+	Correct     bool
+	QuestionID  int
+	Explanation string
+}
+
+// Represents a hint for question, hints are ordered by position starting from 1.
+type QuestionHint struct {
 	ID         int
+	QuestionID int
+	Position   int
 	Body       string
-	Correct    bool
+}
+
+// Represents a recorded response to question with the selected options.
+// Duration is the time spent answering, zero when unknown.
+type QuestionResponse struct {
+	ID         int
 	QuestionID int
+	OptionIDs  []int
+	Correct    bool
+	AnsweredAt time.Time
+	Duration   time.Duration
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Represents SM-2 scheduling state of a question for a user.
+type ReviewSchedule struct {
+	UserID         string
+	QuestionID     int
+	EaseFactor     float64
+	IntervalDays   int
+	Repetitions    int
+	DueAt          time.Time
+	LastReviewedAt time.Time
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Represents a scored run over the question bank submitted by a player.
+type Run struct {
+	ID          int
+	PlayerName  string
+	Score       int
+	Total       int
+	Duration    time.Duration
+	SubmittedAt time.Time
+}
+
+// Represents the best run of a player ranked within a leaderboard time window.
+type LeaderboardEntry struct {
+	Window      string
+	Rank        int
+	PlayerName  string
+	RunID       int
+	Score       int
+	Total       int
+	Duration    time.Duration
+	SubmittedAt time.Time
+}
@@ -0,0 +1,19 @@
+package entity
+
+// Represents aggregated responses to question.
+// AverageDurationMs is nil when no response has a recorded duration.
+type QuestionResponseSummary struct {
+	QuestionID        int
+	Attempts          int
+	CorrectCount      int
+	AverageDurationMs *float64
+}
+
+// Represents how many times an option was selected in responses.
+type OptionSelection struct {
+	QuestionID int
+	OptionID   int
+	Body       string
+	Correct    bool
+	Selections int
+}
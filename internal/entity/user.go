@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Represents a registered user account.
+type User struct {
+	ID           int
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+	TenantID     string
+}
+
+// Represents a login session of a user, identified by the hash of its token.
+type UserSession struct {
+	TokenHash string
+	UserID    int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	TenantID  string
+}
+
+// Represents a refresh token of a user, identified by the hash of the token.
+type RefreshToken struct {
+	TokenHash string
+	UserID    int
+	FamilyID  string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	TenantID  string
+}
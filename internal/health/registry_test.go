@@ -0,0 +1,52 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	t.Run("Should report ok without checks", func(t *testing.T) {
+		registry := health.NewRegistry(time.Second)
+
+		report := registry.Run(context.Background())
+		assert.Equal(t, health.Report{Status: health.StatusOK, Checks: []health.CheckResult{}}, report)
+	})
+
+	t.Run("Should report every check in registration order and fail when one fails", func(t *testing.T) {
+		registry := health.NewRegistry(time.Second)
+		registry.Register("first", func(ctx context.Context) error { return nil })
+		registry.Register("second", func(ctx context.Context) error { return errors.New("some-error") })
+
+		report := registry.Run(context.Background())
+		assert.Equal(t, health.StatusFail, report.Status)
+		assert.Len(t, report.Checks, 2)
+		assert.Equal(t, "first", report.Checks[0].Name)
+		assert.Equal(t, health.StatusOK, report.Checks[0].Status)
+		assert.Empty(t, report.Checks[0].Error)
+		assert.Equal(t, "second", report.Checks[1].Name)
+		assert.Equal(t, health.StatusFail, report.Checks[1].Status)
+		assert.Equal(t, "some-error", report.Checks[1].Error)
+	})
+
+	t.Run("Should fail check which outlives the timeout", func(t *testing.T) {
+		registry := health.NewRegistry(20 * time.Millisecond)
+		release := make(chan struct{})
+		defer close(release)
+
+		registry.Register("stuck", func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		report := registry.Run(context.Background())
+		assert.Equal(t, health.StatusFail, report.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+		assert.GreaterOrEqual(t, report.Checks[0].LatencyMS, float64(20))
+	})
+}
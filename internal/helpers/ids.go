@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxIDs is the most ids a single list may hold, each id fans out into queries.
+const MaxIDs = 100
+
+// ParseIDs parses a comma separated list of at most MaxIDs ids, e.g. "1,2,3".
+func ParseIDs(value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, errors.New("no ids provided")
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) > MaxIDs {
+		return nil, fmt.Errorf("%d ids provided, at most %d are allowed", len(parts), MaxIDs)
+	}
+
+	ids := []int{}
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting id %q to number: %w", part, err)
+		}
+		ids = append(ids, int(id))
+	}
+
+	return ids, nil
+}
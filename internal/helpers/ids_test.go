@@ -0,0 +1,35 @@
+package helpers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIDs(t *testing.T) {
+	t.Run("Should parse comma separated ids", func(t *testing.T) {
+		ids, err := helpers.ParseIDs("1, 2,3")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ids)
+	})
+
+	t.Run("Should allow at most MaxIDs ids", func(t *testing.T) {
+		ids, err := helpers.ParseIDs(strings.Repeat("1,", helpers.MaxIDs-1) + "1")
+		assert.NoError(t, err)
+		assert.Len(t, ids, helpers.MaxIDs)
+
+		ids, err = helpers.ParseIDs(strings.Repeat("1,", helpers.MaxIDs) + "1")
+		assert.Nil(t, ids)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should fail without ids or with ids which aren't numbers", func(t *testing.T) {
+		for _, value := range []string{"", " ", "1,a", "1,,2", "-1"} {
+			ids, err := helpers.ParseIDs(value)
+			assert.Nil(t, ids, value)
+			assert.Error(t, err, value)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Markdown renderer, raw html in the source is omitted since unsafe rendering is not enabled.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.Table,
+		extension.Strikethrough,
+		extension.Linkify,
+	),
+)
+
+// RenderMarkdown converts markdown source into sanitised html.
+func RenderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+
+	err := markdown.Convert([]byte(source), &buf)
+	if err != nil {
+		return "", fmt.Errorf("error rendering markdown: %w", err)
+	}
+
+	return SanitizeHTML(buf.String()), nil
+}
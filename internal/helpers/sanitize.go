@@ -0,0 +1,171 @@
+package helpers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Elements which are kept together with the listed attributes, everything else is stripped.
+var allowedElements = map[string][]string{
+	"a":          {"href", "title"},
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"del":        nil,
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"img":        {"src", "alt", "title"},
+	"li":         nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align"},
+	"th":         {"align"},
+	"thead":      nil,
+	"tr":         nil,
+	"ul":         nil,
+}
+
+// Elements which are removed together with their content.
+var droppedElements = map[string]bool{
+	"embed":    true,
+	"iframe":   true,
+	"math":     true,
+	"noscript": true,
+	"object":   true,
+	"script":   true,
+	"select":   true,
+	"style":    true,
+	"svg":      true,
+	"template": true,
+	"textarea": true,
+}
+
+// Elements which never have content or closing tag.
+var voidElements = map[string]bool{
+	"br":  true,
+	"hr":  true,
+	"img": true,
+}
+
+// Attributes which contain urls and have to use one of the safe schemes.
+var urlAttributes = map[string]bool{
+	"href": true,
+	"src":  true,
+}
+
+var (
+	safeURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+	codeClass      = regexp.MustCompile(`^language-[\w-]+$`)
+	alignValue     = regexp.MustCompile(`^(left|right|center)$`)
+	numberValue    = regexp.MustCompile(`^\d+$`)
+)
+
+// SanitizeHTML removes every element and attribute which is not explicitly allowed,
+// including scripts, event handlers and urls with unsafe schemes.
+func SanitizeHTML(input string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+
+	var b strings.Builder
+	// Depth of nesting inside elements which are dropped with their content.
+	dropped := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return b.String()
+		}
+
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.TextToken:
+			if dropped == 0 {
+				b.WriteString(html.EscapeString(token.Data))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedElements[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+
+			attributes, ok := allowedElements[token.Data]
+			if !ok || dropped > 0 {
+				continue
+			}
+
+			b.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if attr.Namespace == "" && contains(attributes, attr.Key) && isSafeAttribute(attr.Key, attr.Val) {
+					b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+				}
+			}
+			if token.Data == "a" {
+				b.WriteString(` rel="nofollow noopener"`)
+			}
+			b.WriteString(">")
+		case html.EndTagToken:
+			if droppedElements[token.Data] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+
+			if _, ok := allowedElements[token.Data]; ok && dropped == 0 && !voidElements[token.Data] {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+// isSafeAttribute validates the value of an allowed attribute.
+func isSafeAttribute(key, value string) bool {
+	switch {
+	case urlAttributes[key]:
+		return isSafeURL(value)
+	case key == "class":
+		return codeClass.MatchString(value)
+	case key == "align":
+		return alignValue.MatchString(value)
+	case key == "start":
+		return numberValue.MatchString(value)
+	default:
+		return true
+	}
+}
+
+// isSafeURL allows relative urls and absolute urls with one of the safe schemes.
+func isSafeURL(value string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "" || safeURLSchemes[strings.ToLower(u.Scheme)]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package helpers_test
+
+import (
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Should keep allowed markup",
+			input:    `<p><strong>bold</strong> <code class="language-go">x := 1</code></p>`,
+			expected: `<p><strong>bold</strong> <code class="language-go">x := 1</code></p>`,
+		},
+		{
+			name:     "Should remove scripts with their content",
+			input:    `<p>a<script>alert(1)</script>b</p>`,
+			expected: `<p>ab</p>`,
+		},
+		{
+			name:     "Should remove event handlers",
+			input:    `<img src="image.png" onerror="alert(1)">`,
+			expected: `<img src="image.png">`,
+		},
+		{
+			name:     "Should remove urls with unsafe schemes",
+			input:    `<a href="javascript:alert(1)">x</a><a href=" JaVaScRiPt&#58;alert(1)">y</a>`,
+			expected: `<a rel="nofollow noopener">x</a><a rel="nofollow noopener">y</a>`,
+		},
+		{
+			name:     "Should keep content of unknown elements but not the elements",
+			input:    `<div onclick="alert(1)"><span style="color:red">text</span></div>`,
+			expected: `text`,
+		},
+		{
+			name:     "Should escape text",
+			input:    `&lt;script&gt;alert(1)&lt;/script&gt;`,
+			expected: `&lt;script&gt;alert(1)&lt;/script&gt;`,
+		},
+		{
+			name:     "Should remove style elements and svg",
+			input:    `<style>*{}</style><svg><script>alert(1)</script></svg>ok`,
+			expected: `ok`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, helpers.SanitizeHTML(tt.input))
+		})
+	}
+}
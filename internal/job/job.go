@@ -0,0 +1,67 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/logging"
+)
+
+// RunPeriodically runs the task immediately and then once every interval,
+// until the context is cancelled. Failures are logged and don't stop the job.
+// A job without a positive interval is logged and never runs.
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, task func(ctx context.Context) error) {
+	if interval <= 0 {
+		logging.FromContext(ctx).Error("job not started, interval must be positive", "job", name, "interval", interval)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := task(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Error("job failed", "job", name, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Group runs jobs in the background and stops them together, so the resources they use can be released after.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup creates a new group whose jobs run until the context is cancelled or the group is closed.
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go runs the task periodically in the background, as RunPeriodically does.
+func (g *Group) Go(name string, interval time.Duration, task func(ctx context.Context) error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+		RunPeriodically(g.ctx, name, interval, task)
+	}()
+}
+
+// Close stops the jobs of the group and waits for their running tasks to return.
+func (g *Group) Close() error {
+	g.cancel()
+	g.wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	t.Run("Should run the task until the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		runs := 0
+
+		job.RunPeriodically(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			runs++
+			if runs == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		assert.Equal(t, 3, runs)
+	})
+
+	t.Run("Should not run the task when the interval isn't positive", func(t *testing.T) {
+		runs := 0
+
+		for _, interval := range []time.Duration{0, -time.Second} {
+			job.RunPeriodically(context.Background(), "test", interval, func(ctx context.Context) error {
+				runs++
+				return nil
+			})
+		}
+
+		assert.Zero(t, runs)
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("Should wait for the running task when closed", func(t *testing.T) {
+		jobs := job.NewGroup(context.Background())
+		started := make(chan struct{})
+		finished := false
+
+		jobs.Go("test", time.Hour, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished = true
+			return nil
+		})
+
+		<-started
+		err := jobs.Close()
+		assert.NoError(t, err)
+		assert.True(t, finished)
+	})
+}
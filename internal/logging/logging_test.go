@@ -0,0 +1,70 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Should write json records at or above the level", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		logger, err := logging.New(logging.Config{Level: "warn", Format: logging.FormatJSON}, out)
+		require.NoError(t, err)
+
+		logger.Info("first-message")
+		logger.Warn("second-message", "question_id", 1)
+
+		record := map[string]interface{}{}
+		err = json.Unmarshal(out.Bytes(), &record)
+		require.NoError(t, err)
+		assert.Equal(t, "WARN", record["level"])
+		assert.Equal(t, "second-message", record["msg"])
+		assert.Equal(t, float64(1), record["question_id"])
+	})
+
+	t.Run("Should write text records", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		logger, err := logging.New(logging.Config{Level: "debug", Format: logging.FormatText}, out)
+		require.NoError(t, err)
+
+		logger.Debug("first-message")
+		assert.Contains(t, out.String(), "level=DEBUG msg=first-message")
+	})
+
+	t.Run("Should fail for unknown level or format", func(t *testing.T) {
+		_, err := logging.New(logging.Config{Level: "verbose", Format: logging.FormatJSON}, &bytes.Buffer{})
+		assert.True(t, errors.Is(err, logging.ErrInvalidConfig))
+
+		_, err = logging.New(logging.Config{Level: "info", Format: "xml"}, &bytes.Buffer{})
+		assert.True(t, errors.Is(err, logging.ErrInvalidConfig))
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("Should return the logger carried by the context or the default one", func(t *testing.T) {
+		logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+		ctx := logging.WithLogger(context.Background(), logger)
+
+		assert.Same(t, logger, logging.FromContext(ctx))
+		assert.Same(t, slog.Default(), logging.FromContext(context.Background()))
+	})
+
+	t.Run("Should return the request id carried by the context", func(t *testing.T) {
+		ctx := logging.WithRequestID(context.Background(), "request-1")
+
+		requestID, ok := logging.RequestIDFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "request-1", requestID)
+
+		_, ok = logging.RequestIDFromContext(context.Background())
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "homework"
+
+// Buckets of database query durations in seconds, sqlite queries mostly take well under a millisecond.
+var queryDurationBuckets = []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// Metrics holds the prometheus collectors of the service and the registry they're exposed from.
+type Metrics struct {
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	queryDuration      *prometheus.HistogramVec
+	questionOperations *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them along with go runtime, process and database pool collectors.
+func New(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of http requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of http requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of database queries by store, method and result.",
+			Buckets:   queryDurationBuckets,
+		}, []string{"store", "method", "result"}),
+		questionOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "question_operations_total",
+			Help:      "Number of questions created, updated and deleted.",
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "homework"),
+		m.requests,
+		m.requestDuration,
+		m.queryDuration,
+		m.questionOperations,
+	)
+
+	return m
+}
+
+// Handler returns the handler which exposes the metrics in the prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a served http request, route is the template of the matched mux route.
+func (m *Metrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(route, method, code).Inc()
+	m.requestDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+// ObserveQuery records a database query made by the method of the store.
+func (m *Metrics) ObserveQuery(store, method string, duration time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+
+	m.queryDuration.WithLabelValues(store, method, result).Observe(duration.Seconds())
+}
+
+// CountQuestionOperation counts a question created, updated or deleted.
+func (m *Metrics) CountQuestionOperation(operation string) {
+	m.questionOperations.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+// scrape returns the metrics exposed by the handler.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	t.Run("Should expose requests, queries, question operations and pool stats", func(t *testing.T) {
+		m := metrics.New(db)
+
+		m.ObserveRequest("/questions/{id}", "GET", 200, 10*time.Millisecond)
+		m.ObserveQuery("QuestionStore", "GetQuestionByID", time.Millisecond, nil)
+		m.ObserveQuery("QuestionStore", "GetQuestionByID", time.Millisecond, errors.New("some-error"))
+		m.CountQuestionOperation("created")
+
+		body := scrape(t, m)
+		assert.Contains(t, body, `homework_http_requests_total{method="GET",route="/questions/{id}",status="200"} 1`)
+		assert.Contains(t, body,
+			`homework_http_request_duration_seconds_count{method="GET",route="/questions/{id}",status="200"} 1`)
+		assert.Contains(t, body,
+			`homework_db_query_duration_seconds_count{method="GetQuestionByID",result="ok",store="QuestionStore"} 1`)
+		assert.Contains(t, body,
+			`homework_db_query_duration_seconds_count{method="GetQuestionByID",result="error",store="QuestionStore"} 1`)
+		assert.Contains(t, body, `homework_question_operations_total{operation="created"} 1`)
+		assert.Contains(t, body, `go_sql_open_connections{db_name="homework"}`)
+	})
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: APIKeyStorer)
+
+// Package apiKeyStorerMock is a generated GoMock package.
+package apiKeyStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyStorer is a mock of APIKeyStorer interface.
+type MockAPIKeyStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyStorerMockRecorder
+}
+
+// MockAPIKeyStorerMockRecorder is the mock recorder for MockAPIKeyStorer.
+type MockAPIKeyStorerMockRecorder struct {
+	mock *MockAPIKeyStorer
+}
+
+// NewMockAPIKeyStorer creates a new mock instance.
+func NewMockAPIKeyStorer(ctrl *gomock.Controller) *MockAPIKeyStorer {
+	mock := &MockAPIKeyStorer{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyStorer) EXPECT() *MockAPIKeyStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyStorer) CreateAPIKey(arg0 context.Context, arg1 entity.APIKey) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyStorerMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyStorer)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockAPIKeyStorer) DeleteAPIKey(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockAPIKeyStorerMockRecorder) DeleteAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockAPIKeyStorer)(nil).DeleteAPIKey), arg0, arg1)
+}
+
+// GetAPIKeyByPrefix mocks base method.
+func (m *MockAPIKeyStorer) GetAPIKeyByPrefix(arg0 context.Context, arg1 string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByPrefix", arg0, arg1)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByPrefix indicates an expected call of GetAPIKeyByPrefix.
+func (mr *MockAPIKeyStorerMockRecorder) GetAPIKeyByPrefix(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByPrefix", reflect.TypeOf((*MockAPIKeyStorer)(nil).GetAPIKeyByPrefix), arg0, arg1)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeyStorer) GetAPIKeys(arg0 context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", arg0)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyStorerMockRecorder) GetAPIKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeyStorer)(nil).GetAPIKeys), arg0)
+}
+
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockAPIKeyStorer) UpdateAPIKeyLastUsed(arg0 context.Context, arg1 int, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockAPIKeyStorerMockRecorder) UpdateAPIKeyLastUsed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockAPIKeyStorer)(nil).UpdateAPIKeyLastUsed), arg0, arg1, arg2)
+}
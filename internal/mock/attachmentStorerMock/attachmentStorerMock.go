@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: AttachmentStorer)
+
+// Package attachmentStorerMock is a generated GoMock package.
+package attachmentStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentStorer is a mock of AttachmentStorer interface.
+type MockAttachmentStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentStorerMockRecorder
+}
+
+// MockAttachmentStorerMockRecorder is the mock recorder for MockAttachmentStorer.
+type MockAttachmentStorerMockRecorder struct {
+	mock *MockAttachmentStorer
+}
+
+// NewMockAttachmentStorer creates a new mock instance.
+func NewMockAttachmentStorer(ctrl *gomock.Controller) *MockAttachmentStorer {
+	mock := &MockAttachmentStorer{ctrl: ctrl}
+	mock.recorder = &MockAttachmentStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentStorer) EXPECT() *MockAttachmentStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentStorer) CreateAttachment(arg0 context.Context, arg1 entity.Attachment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentStorerMockRecorder) CreateAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentStorer)(nil).CreateAttachment), arg0, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentStorer) DeleteAttachment(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentStorerMockRecorder) DeleteAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentStorer)(nil).DeleteAttachment), arg0, arg1)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachmentStorer) GetAttachmentByID(arg0 context.Context, arg1 int) (entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", arg0, arg1)
+	ret0, _ := ret[0].(entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentStorerMockRecorder) GetAttachmentByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachmentStorer)(nil).GetAttachmentByID), arg0, arg1)
+}
+
+// GetAttachmentsByQuestionID mocks base method.
+func (m *MockAttachmentStorer) GetAttachmentsByQuestionID(arg0 context.Context, arg1 int) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByQuestionID", arg0, arg1)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByQuestionID indicates an expected call of GetAttachmentsByQuestionID.
+func (mr *MockAttachmentStorerMockRecorder) GetAttachmentsByQuestionID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByQuestionID", reflect.TypeOf((*MockAttachmentStorer)(nil).GetAttachmentsByQuestionID), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: CredentialChecker)
+
+// Package credentialCheckerMock is a generated GoMock package.
+package credentialCheckerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/djurica-surla/backend-homework/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCredentialChecker is a mock of CredentialChecker interface.
+type MockCredentialChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialCheckerMockRecorder
+}
+
+// MockCredentialCheckerMockRecorder is the mock recorder for MockCredentialChecker.
+type MockCredentialCheckerMockRecorder struct {
+	mock *MockCredentialChecker
+}
+
+// NewMockCredentialChecker creates a new mock instance.
+func NewMockCredentialChecker(ctrl *gomock.Controller) *MockCredentialChecker {
+	mock := &MockCredentialChecker{ctrl: ctrl}
+	mock.recorder = &MockCredentialCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialChecker) EXPECT() *MockCredentialCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckCredentials mocks base method.
+func (m *MockCredentialChecker) CheckCredentials(arg0 context.Context, arg1 service.UserLoginDTO) (service.UserDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCredentials", arg0, arg1)
+	ret0, _ := ret[0].(service.UserDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckCredentials indicates an expected call of CheckCredentials.
+func (mr *MockCredentialCheckerMockRecorder) CheckCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCredentials", reflect.TypeOf((*MockCredentialChecker)(nil).CheckCredentials), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockCredentialChecker) GetUserByID(arg0 context.Context, arg1 int) (service.UserDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(service.UserDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockCredentialCheckerMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockCredentialChecker)(nil).GetUserByID), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: DifficultyCalculator)
+
+// Package difficultyCalculatorMock is a generated GoMock package.
+package difficultyCalculatorMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDifficultyCalculator is a mock of DifficultyCalculator interface.
+type MockDifficultyCalculator struct {
+	ctrl     *gomock.Controller
+	recorder *MockDifficultyCalculatorMockRecorder
+}
+
+// MockDifficultyCalculatorMockRecorder is the mock recorder for MockDifficultyCalculator.
+type MockDifficultyCalculatorMockRecorder struct {
+	mock *MockDifficultyCalculator
+}
+
+// NewMockDifficultyCalculator creates a new mock instance.
+func NewMockDifficultyCalculator(ctrl *gomock.Controller) *MockDifficultyCalculator {
+	mock := &MockDifficultyCalculator{ctrl: ctrl}
+	mock.recorder = &MockDifficultyCalculatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDifficultyCalculator) EXPECT() *MockDifficultyCalculatorMockRecorder {
+	return m.recorder
+}
+
+// RecalculateEmpiricalDifficulty mocks base method.
+func (m *MockDifficultyCalculator) RecalculateEmpiricalDifficulty(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateEmpiricalDifficulty", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecalculateEmpiricalDifficulty indicates an expected call of RecalculateEmpiricalDifficulty.
+func (mr *MockDifficultyCalculatorMockRecorder) RecalculateEmpiricalDifficulty(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateEmpiricalDifficulty", reflect.TypeOf((*MockDifficultyCalculator)(nil).RecalculateEmpiricalDifficulty), arg0)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: PracticeQuestionPicker)
+
+// Package practiceQuestionPickerMock is a generated GoMock package.
+package practiceQuestionPickerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPracticeQuestionPicker is a mock of PracticeQuestionPicker interface.
+type MockPracticeQuestionPicker struct {
+	ctrl     *gomock.Controller
+	recorder *MockPracticeQuestionPickerMockRecorder
+}
+
+// MockPracticeQuestionPickerMockRecorder is the mock recorder for MockPracticeQuestionPicker.
+type MockPracticeQuestionPickerMockRecorder struct {
+	mock *MockPracticeQuestionPicker
+}
+
+// NewMockPracticeQuestionPicker creates a new mock instance.
+func NewMockPracticeQuestionPicker(ctrl *gomock.Controller) *MockPracticeQuestionPicker {
+	mock := &MockPracticeQuestionPicker{ctrl: ctrl}
+	mock.recorder = &MockPracticeQuestionPickerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPracticeQuestionPicker) EXPECT() *MockPracticeQuestionPickerMockRecorder {
+	return m.recorder
+}
+
+// GetNextPracticeQuestionID mocks base method.
+func (m *MockPracticeQuestionPicker) GetNextPracticeQuestionID(arg0 context.Context, arg1 int, arg2, arg3 float64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextPracticeQuestionID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextPracticeQuestionID indicates an expected call of GetNextPracticeQuestionID.
+func (mr *MockPracticeQuestionPickerMockRecorder) GetNextPracticeQuestionID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextPracticeQuestionID", reflect.TypeOf((*MockPracticeQuestionPicker)(nil).GetNextPracticeQuestionID), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: PracticeStorer)
+
+// Package practiceStorerMock is a generated GoMock package.
+package practiceStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	service "github.com/djurica-surla/backend-homework/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPracticeStorer is a mock of PracticeStorer interface.
+type MockPracticeStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPracticeStorerMockRecorder
+}
+
+// MockPracticeStorerMockRecorder is the mock recorder for MockPracticeStorer.
+type MockPracticeStorerMockRecorder struct {
+	mock *MockPracticeStorer
+}
+
+// NewMockPracticeStorer creates a new mock instance.
+func NewMockPracticeStorer(ctrl *gomock.Controller) *MockPracticeStorer {
+	mock := &MockPracticeStorer{ctrl: ctrl}
+	mock.recorder = &MockPracticeStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPracticeStorer) EXPECT() *MockPracticeStorerMockRecorder {
+	return m.recorder
+}
+
+// CreatePracticeAnswer mocks base method.
+func (m *MockPracticeStorer) CreatePracticeAnswer(arg0 context.Context, arg1 string, arg2 entity.PracticeAnswer, arg3 float64, arg4 service.RatingUpdate) (entity.PracticeAnswer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePracticeAnswer", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(entity.PracticeAnswer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePracticeAnswer indicates an expected call of CreatePracticeAnswer.
+func (mr *MockPracticeStorerMockRecorder) CreatePracticeAnswer(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePracticeAnswer", reflect.TypeOf((*MockPracticeStorer)(nil).CreatePracticeAnswer), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreatePracticeSession mocks base method.
+func (m *MockPracticeStorer) CreatePracticeSession(arg0 context.Context, arg1 entity.PracticeSession) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePracticeSession", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePracticeSession indicates an expected call of CreatePracticeSession.
+func (mr *MockPracticeStorerMockRecorder) CreatePracticeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePracticeSession", reflect.TypeOf((*MockPracticeStorer)(nil).CreatePracticeSession), arg0, arg1)
+}
+
+// GetPracticeSession mocks base method.
+func (m *MockPracticeStorer) GetPracticeSession(arg0 context.Context, arg1 int) (entity.PracticeSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPracticeSession", arg0, arg1)
+	ret0, _ := ret[0].(entity.PracticeSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPracticeSession indicates an expected call of GetPracticeSession.
+func (mr *MockPracticeStorerMockRecorder) GetPracticeSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPracticeSession", reflect.TypeOf((*MockPracticeStorer)(nil).GetPracticeSession), arg0, arg1)
+}
+
+// GetQuestionRating mocks base method.
+func (m *MockPracticeStorer) GetQuestionRating(arg0 context.Context, arg1 int) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionRating", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionRating indicates an expected call of GetQuestionRating.
+func (mr *MockPracticeStorerMockRecorder) GetQuestionRating(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionRating", reflect.TypeOf((*MockPracticeStorer)(nil).GetQuestionRating), arg0, arg1)
+}
+
+// GetUserRating mocks base method.
+func (m *MockPracticeStorer) GetUserRating(arg0 context.Context, arg1 string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRating", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRating indicates an expected call of GetUserRating.
+func (mr *MockPracticeStorerMockRecorder) GetUserRating(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRating", reflect.TypeOf((*MockPracticeStorer)(nil).GetUserRating), arg0, arg1)
+}
+
+// HasPracticeAnswer mocks base method.
+func (m *MockPracticeStorer) HasPracticeAnswer(arg0 context.Context, arg1, arg2 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPracticeAnswer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPracticeAnswer indicates an expected call of HasPracticeAnswer.
+func (mr *MockPracticeStorerMockRecorder) HasPracticeAnswer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPracticeAnswer", reflect.TypeOf((*MockPracticeStorer)(nil).HasPracticeAnswer), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: QuestionGetter)
+
+// Package questionGetterMock is a generated GoMock package.
+package questionGetterMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/djurica-surla/backend-homework/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuestionGetter is a mock of QuestionGetter interface.
+type MockQuestionGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionGetterMockRecorder
+}
+
+// MockQuestionGetterMockRecorder is the mock recorder for MockQuestionGetter.
+type MockQuestionGetterMockRecorder struct {
+	mock *MockQuestionGetter
+}
+
+// NewMockQuestionGetter creates a new mock instance.
+func NewMockQuestionGetter(ctrl *gomock.Controller) *MockQuestionGetter {
+	mock := &MockQuestionGetter{ctrl: ctrl}
+	mock.recorder = &MockQuestionGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionGetter) EXPECT() *MockQuestionGetterMockRecorder {
+	return m.recorder
+}
+
+// GetQuestionByID mocks base method.
+func (m *MockQuestionGetter) GetQuestionByID(arg0 context.Context, arg1 int) (service.QuestionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionByID", arg0, arg1)
+	ret0, _ := ret[0].(service.QuestionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionByID indicates an expected call of GetQuestionByID.
+func (mr *MockQuestionGetterMockRecorder) GetQuestionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionByID", reflect.TypeOf((*MockQuestionGetter)(nil).GetQuestionByID), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: QuestionHintStorer)
+
+// Package questionHintStorerMock is a generated GoMock package.
+package questionHintStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuestionHintStorer is a mock of QuestionHintStorer interface.
+type MockQuestionHintStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionHintStorerMockRecorder
+}
+
+// MockQuestionHintStorerMockRecorder is the mock recorder for MockQuestionHintStorer.
+type MockQuestionHintStorerMockRecorder struct {
+	mock *MockQuestionHintStorer
+}
+
+// NewMockQuestionHintStorer creates a new mock instance.
+func NewMockQuestionHintStorer(ctrl *gomock.Controller) *MockQuestionHintStorer {
+	mock := &MockQuestionHintStorer{ctrl: ctrl}
+	mock.recorder = &MockQuestionHintStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionHintStorer) EXPECT() *MockQuestionHintStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateQuestionHint mocks base method.
+func (m *MockQuestionHintStorer) CreateQuestionHint(arg0 context.Context, arg1, arg2 int, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestionHint", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQuestionHint indicates an expected call of CreateQuestionHint.
+func (mr *MockQuestionHintStorerMockRecorder) CreateQuestionHint(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestionHint", reflect.TypeOf((*MockQuestionHintStorer)(nil).CreateQuestionHint), arg0, arg1, arg2, arg3)
+}
+
+// DeleteQuestionHints mocks base method.
+func (m *MockQuestionHintStorer) DeleteQuestionHints(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuestionHints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuestionHints indicates an expected call of DeleteQuestionHints.
+func (mr *MockQuestionHintStorerMockRecorder) DeleteQuestionHints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuestionHints", reflect.TypeOf((*MockQuestionHintStorer)(nil).DeleteQuestionHints), arg0, arg1)
+}
+
+// GetQuestionHints mocks base method.
+func (m *MockQuestionHintStorer) GetQuestionHints(arg0 context.Context, arg1 int) ([]entity.QuestionHint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionHints", arg0, arg1)
+	ret0, _ := ret[0].([]entity.QuestionHint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionHints indicates an expected call of GetQuestionHints.
+func (mr *MockQuestionHintStorerMockRecorder) GetQuestionHints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionHints", reflect.TypeOf((*MockQuestionHintStorer)(nil).GetQuestionHints), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: QuestionManager)
+
+// Package questionManagerMock is a generated GoMock package.
+package questionManagerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/djurica-surla/backend-homework/internal/service"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuestionManager is a mock of QuestionManager interface.
+type MockQuestionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionManagerMockRecorder
+}
+
+// MockQuestionManagerMockRecorder is the mock recorder for MockQuestionManager.
+type MockQuestionManagerMockRecorder struct {
+	mock *MockQuestionManager
+}
+
+// NewMockQuestionManager creates a new mock instance.
+func NewMockQuestionManager(ctrl *gomock.Controller) *MockQuestionManager {
+	mock := &MockQuestionManager{ctrl: ctrl}
+	mock.recorder = &MockQuestionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionManager) EXPECT() *MockQuestionManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateQuestion mocks base method.
+func (m *MockQuestionManager) CreateQuestion(arg0 context.Context, arg1 service.QuestionCreationDTO) (service.QuestionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestion", arg0, arg1)
+	ret0, _ := ret[0].(service.QuestionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuestion indicates an expected call of CreateQuestion.
+func (mr *MockQuestionManagerMockRecorder) CreateQuestion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQuestionManager)(nil).CreateQuestion), arg0, arg1)
+}
+
+// GetQuestionByID mocks base method.
+func (m *MockQuestionManager) GetQuestionByID(arg0 context.Context, arg1 int) (service.QuestionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionByID", arg0, arg1)
+	ret0, _ := ret[0].(service.QuestionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionByID indicates an expected call of GetQuestionByID.
+func (mr *MockQuestionManagerMockRecorder) GetQuestionByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionByID", reflect.TypeOf((*MockQuestionManager)(nil).GetQuestionByID), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: QuestionResponseStorer)
+
+// Package questionResponseStorerMock is a generated GoMock package.
+package questionResponseStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockQuestionResponseStorer is a mock of QuestionResponseStorer interface.
+type MockQuestionResponseStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuestionResponseStorerMockRecorder
+}
+
+// MockQuestionResponseStorerMockRecorder is the mock recorder for MockQuestionResponseStorer.
+type MockQuestionResponseStorerMockRecorder struct {
+	mock *MockQuestionResponseStorer
+}
+
+// NewMockQuestionResponseStorer creates a new mock instance.
+func NewMockQuestionResponseStorer(ctrl *gomock.Controller) *MockQuestionResponseStorer {
+	mock := &MockQuestionResponseStorer{ctrl: ctrl}
+	mock.recorder = &MockQuestionResponseStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuestionResponseStorer) EXPECT() *MockQuestionResponseStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateQuestionResponse mocks base method.
+func (m *MockQuestionResponseStorer) CreateQuestionResponse(arg0 context.Context, arg1 entity.QuestionResponse) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuestionResponse", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuestionResponse indicates an expected call of CreateQuestionResponse.
+func (mr *MockQuestionResponseStorerMockRecorder) CreateQuestionResponse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestionResponse", reflect.TypeOf((*MockQuestionResponseStorer)(nil).CreateQuestionResponse), arg0, arg1)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	service "github.com/djurica-surla/backend-homework/internal/service"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,18 +37,18 @@ func (m *MockQuestionStorer) EXPECT() *MockQuestionStorerMockRecorder {
 }
 
 // CreateQuestion mocks base method.
-func (m *MockQuestionStorer) CreateQuestion(arg0 context.Context, arg1 string) (int, error) {
+func (m *MockQuestionStorer) CreateQuestion(arg0 context.Context, arg1 service.QuestionCreationDTO, arg2 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateQuestion", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateQuestion", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateQuestion indicates an expected call of CreateQuestion.
-func (mr *MockQuestionStorerMockRecorder) CreateQuestion(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockQuestionStorerMockRecorder) CreateQuestion(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQuestionStorer)(nil).CreateQuestion), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQuestionStorer)(nil).CreateQuestion), arg0, arg1, arg2)
 }
 
 // DeleteQuestion mocks base method.
@@ -80,22 +81,22 @@ func (mr *MockQuestionStorerMockRecorder) GetQuestionByID(arg0, arg1 interface{}
 }
 
 // GetQuestions mocks base method.
-func (m *MockQuestionStorer) GetQuestions(arg0 context.Context, arg1, arg2 int) ([]entity.Question, error) {
+func (m *MockQuestionStorer) GetQuestions(arg0 context.Context, arg1 service.QuestionFilter, arg2, arg3 int) ([]entity.Question, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuestions", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetQuestions", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]entity.Question)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetQuestions indicates an expected call of GetQuestions.
-func (mr *MockQuestionStorerMockRecorder) GetQuestions(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockQuestionStorerMockRecorder) GetQuestions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockQuestionStorer)(nil).GetQuestions), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockQuestionStorer)(nil).GetQuestions), arg0, arg1, arg2, arg3)
 }
 
 // UpdateQuestion mocks base method.
-func (m *MockQuestionStorer) UpdateQuestion(arg0 context.Context, arg1 int, arg2 string) (int, error) {
+func (m *MockQuestionStorer) UpdateQuestion(arg0 context.Context, arg1 int, arg2 service.QuestionCreationDTO) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateQuestion", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: ReviewScheduleDeleter)
+
+// Package reviewScheduleDeleterMock is a generated GoMock package.
+package reviewScheduleDeleterMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewScheduleDeleter is a mock of ReviewScheduleDeleter interface.
+type MockReviewScheduleDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewScheduleDeleterMockRecorder
+}
+
+// MockReviewScheduleDeleterMockRecorder is the mock recorder for MockReviewScheduleDeleter.
+type MockReviewScheduleDeleterMockRecorder struct {
+	mock *MockReviewScheduleDeleter
+}
+
+// NewMockReviewScheduleDeleter creates a new mock instance.
+func NewMockReviewScheduleDeleter(ctrl *gomock.Controller) *MockReviewScheduleDeleter {
+	mock := &MockReviewScheduleDeleter{ctrl: ctrl}
+	mock.recorder = &MockReviewScheduleDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewScheduleDeleter) EXPECT() *MockReviewScheduleDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteQuestionReviewSchedules mocks base method.
+func (m *MockReviewScheduleDeleter) DeleteQuestionReviewSchedules(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuestionReviewSchedules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuestionReviewSchedules indicates an expected call of DeleteQuestionReviewSchedules.
+func (mr *MockReviewScheduleDeleterMockRecorder) DeleteQuestionReviewSchedules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuestionReviewSchedules", reflect.TypeOf((*MockReviewScheduleDeleter)(nil).DeleteQuestionReviewSchedules), arg0, arg1)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: ReviewStorer)
+
+// Package reviewStorerMock is a generated GoMock package.
+package reviewStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewStorer is a mock of ReviewStorer interface.
+type MockReviewStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewStorerMockRecorder
+}
+
+// MockReviewStorerMockRecorder is the mock recorder for MockReviewStorer.
+type MockReviewStorerMockRecorder struct {
+	mock *MockReviewStorer
+}
+
+// NewMockReviewStorer creates a new mock instance.
+func NewMockReviewStorer(ctrl *gomock.Controller) *MockReviewStorer {
+	mock := &MockReviewStorer{ctrl: ctrl}
+	mock.recorder = &MockReviewStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewStorer) EXPECT() *MockReviewStorerMockRecorder {
+	return m.recorder
+}
+
+// GetDueReviewSchedules mocks base method.
+func (m *MockReviewStorer) GetDueReviewSchedules(arg0 context.Context, arg1 string, arg2 time.Time, arg3, arg4 int) ([]entity.ReviewSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueReviewSchedules", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]entity.ReviewSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueReviewSchedules indicates an expected call of GetDueReviewSchedules.
+func (mr *MockReviewStorerMockRecorder) GetDueReviewSchedules(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueReviewSchedules", reflect.TypeOf((*MockReviewStorer)(nil).GetDueReviewSchedules), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetReviewSchedule mocks base method.
+func (m *MockReviewStorer) GetReviewSchedule(arg0 context.Context, arg1 string, arg2 int) (entity.ReviewSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewSchedule", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.ReviewSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewSchedule indicates an expected call of GetReviewSchedule.
+func (mr *MockReviewStorerMockRecorder) GetReviewSchedule(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewSchedule", reflect.TypeOf((*MockReviewStorer)(nil).GetReviewSchedule), arg0, arg1, arg2)
+}
+
+// SaveReviewSchedule mocks base method.
+func (m *MockReviewStorer) SaveReviewSchedule(arg0 context.Context, arg1 entity.ReviewSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReviewSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReviewSchedule indicates an expected call of SaveReviewSchedule.
+func (mr *MockReviewStorerMockRecorder) SaveReviewSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReviewSchedule", reflect.TypeOf((*MockReviewStorer)(nil).SaveReviewSchedule), arg0, arg1)
+}
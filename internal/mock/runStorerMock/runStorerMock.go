@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: RunStorer)
+
+// Package runStorerMock is a generated GoMock package.
+package runStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRunStorer is a mock of RunStorer interface.
+type MockRunStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunStorerMockRecorder
+}
+
+// MockRunStorerMockRecorder is the mock recorder for MockRunStorer.
+type MockRunStorerMockRecorder struct {
+	mock *MockRunStorer
+}
+
+// NewMockRunStorer creates a new mock instance.
+func NewMockRunStorer(ctrl *gomock.Controller) *MockRunStorer {
+	mock := &MockRunStorer{ctrl: ctrl}
+	mock.recorder = &MockRunStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunStorer) EXPECT() *MockRunStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateRun mocks base method.
+func (m *MockRunStorer) CreateRun(arg0 context.Context, arg1 entity.Run, arg2 map[string]time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRunStorerMockRecorder) CreateRun(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRunStorer)(nil).CreateRun), arg0, arg1, arg2)
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockRunStorer) GetLeaderboard(arg0 context.Context, arg1 string, arg2, arg3 int) ([]entity.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entity.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockRunStorerMockRecorder) GetLeaderboard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockRunStorer)(nil).GetLeaderboard), arg0, arg1, arg2, arg3)
+}
+
+// GetLeaderboardEntry mocks base method.
+func (m *MockRunStorer) GetLeaderboardEntry(arg0 context.Context, arg1, arg2 string) (entity.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboardEntry", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entity.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboardEntry indicates an expected call of GetLeaderboardEntry.
+func (mr *MockRunStorerMockRecorder) GetLeaderboardEntry(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboardEntry", reflect.TypeOf((*MockRunStorer)(nil).GetLeaderboardEntry), arg0, arg1, arg2)
+}
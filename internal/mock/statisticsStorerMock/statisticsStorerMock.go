@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: StatisticsStorer)
+
+// Package statisticsStorerMock is a generated GoMock package.
+package statisticsStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatisticsStorer is a mock of StatisticsStorer interface.
+type MockStatisticsStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsStorerMockRecorder
+}
+
+// MockStatisticsStorerMockRecorder is the mock recorder for MockStatisticsStorer.
+type MockStatisticsStorerMockRecorder struct {
+	mock *MockStatisticsStorer
+}
+
+// NewMockStatisticsStorer creates a new mock instance.
+func NewMockStatisticsStorer(ctrl *gomock.Controller) *MockStatisticsStorer {
+	mock := &MockStatisticsStorer{ctrl: ctrl}
+	mock.recorder = &MockStatisticsStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsStorer) EXPECT() *MockStatisticsStorerMockRecorder {
+	return m.recorder
+}
+
+// GetOptionSelections mocks base method.
+func (m *MockStatisticsStorer) GetOptionSelections(arg0 context.Context, arg1 []int) ([]entity.OptionSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptionSelections", arg0, arg1)
+	ret0, _ := ret[0].([]entity.OptionSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptionSelections indicates an expected call of GetOptionSelections.
+func (mr *MockStatisticsStorerMockRecorder) GetOptionSelections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptionSelections", reflect.TypeOf((*MockStatisticsStorer)(nil).GetOptionSelections), arg0, arg1)
+}
+
+// GetResponseSummaries mocks base method.
+func (m *MockStatisticsStorer) GetResponseSummaries(arg0 context.Context, arg1 []int) ([]entity.QuestionResponseSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResponseSummaries", arg0, arg1)
+	ret0, _ := ret[0].([]entity.QuestionResponseSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResponseSummaries indicates an expected call of GetResponseSummaries.
+func (mr *MockStatisticsStorerMockRecorder) GetResponseSummaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponseSummaries", reflect.TypeOf((*MockStatisticsStorer)(nil).GetResponseSummaries), arg0, arg1)
+}
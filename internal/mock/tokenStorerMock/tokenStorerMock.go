@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/djurica-surla/backend-homework/internal/service (interfaces: TokenStorer)
+
+// Package tokenStorerMock is a generated GoMock package.
+package tokenStorerMock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/djurica-surla/backend-homework/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenStorer is a mock of TokenStorer interface.
+type MockTokenStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenStorerMockRecorder
+}
+
+// MockTokenStorerMockRecorder is the mock recorder for MockTokenStorer.
+type MockTokenStorerMockRecorder struct {
+	mock *MockTokenStorer
+}
+
+// NewMockTokenStorer creates a new mock instance.
+func NewMockTokenStorer(ctrl *gomock.Controller) *MockTokenStorer {
+	mock := &MockTokenStorer{ctrl: ctrl}
+	mock.recorder = &MockTokenStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenStorer) EXPECT() *MockTokenStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockTokenStorer) CreateRefreshToken(arg0 context.Context, arg1 entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockTokenStorerMockRecorder) CreateRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockTokenStorer)(nil).CreateRefreshToken), arg0, arg1)
+}
+
+// DeleteExpiredTokens mocks base method.
+func (m *MockTokenStorer) DeleteExpiredTokens(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockTokenStorerMockRecorder) DeleteExpiredTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*MockTokenStorer)(nil).DeleteExpiredTokens), arg0, arg1)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockTokenStorer) GetRefreshToken(arg0 context.Context, arg1 string) (entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockTokenStorerMockRecorder) GetRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockTokenStorer)(nil).GetRefreshToken), arg0, arg1)
+}
+
+// IsAccessTokenRevoked mocks base method.
+func (m *MockTokenStorer) IsAccessTokenRevoked(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessTokenRevoked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccessTokenRevoked indicates an expected call of IsAccessTokenRevoked.
+func (mr *MockTokenStorerMockRecorder) IsAccessTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenRevoked", reflect.TypeOf((*MockTokenStorer)(nil).IsAccessTokenRevoked), arg0, arg1)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockTokenStorer) RevokeAccessToken(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockTokenStorerMockRecorder) RevokeAccessToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockTokenStorer)(nil).RevokeAccessToken), arg0, arg1, arg2)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockTokenStorer) RevokeRefreshTokenFamily(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockTokenStorerMockRecorder) RevokeRefreshTokenFamily(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockTokenStorer)(nil).RevokeRefreshTokenFamily), arg0, arg1, arg2)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockTokenStorer) RotateRefreshToken(arg0 context.Context, arg1 string, arg2 entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockTokenStorerMockRecorder) RotateRefreshToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockTokenStorer)(nil).RotateRefreshToken), arg0, arg1, arg2)
+}
@@ -0,0 +1,106 @@
+package openapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/openapi"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestDocument_SchemaOf(t *testing.T) {
+	t.Run("Should describe request dto with the constraints of its validate tags", func(t *testing.T) {
+		doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"})
+
+		schema := doc.SchemaOf(service.QuestionCreationDTO{}, openapi.RequestUsage)
+		assert.Equal(t, &openapi.Schema{Ref: "#/components/schemas/QuestionCreationDTO"}, schema)
+
+		assert.Equal(t, &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"body": {Type: "string", MinLength: intPtr(1), MaxLength: intPtr(10000)},
+				"options": {Type: "array", Items: &openapi.Schema{
+					Ref: "#/components/schemas/QuestionOptionCreationDTO",
+				}},
+				"explanation": {Type: "string"},
+				"hints":       {Type: "array", Items: &openapi.Schema{Type: "string", MinLength: intPtr(1)}},
+				"difficulty":  {Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(5)},
+			},
+			Required: []string{"body"},
+		}, doc.Components.Schemas["QuestionCreationDTO"])
+
+		assert.Equal(t, &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"body":        {Type: "string", MinLength: intPtr(1)},
+				"correct":     {Type: "boolean"},
+				"explanation": {Type: "string"},
+			},
+			Required: []string{"body"},
+		}, doc.Components.Schemas["QuestionOptionCreationDTO"])
+	})
+
+	t.Run("Should require fields of response dto which aren't omitted when empty", func(t *testing.T) {
+		doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"})
+
+		doc.SchemaOf([]service.QuestionDTO{}, openapi.ResponseUsage)
+
+		schema := doc.Components.Schemas["QuestionDTO"]
+		assert.Equal(t, []string{"id", "body", "body_html", "empirical_difficulty", "response_count", "options"},
+			schema.Required)
+		assert.Equal(t, &openapi.Schema{Type: "number", Format: "double", Nullable: true},
+			schema.Properties["empirical_difficulty"])
+		assert.Equal(t, &openapi.Schema{Type: "boolean", Nullable: true},
+			doc.Components.Schemas["QuestionOptionDTO"].Properties["correct"])
+	})
+
+	t.Run("Should flatten embedded structs and describe times and enums", func(t *testing.T) {
+		doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"})
+
+		doc.SchemaOf(service.CreatedAPIKeyDTO{}, openapi.ResponseUsage)
+		doc.SchemaOf(service.APIKeyCreationDTO{}, openapi.RequestUsage)
+
+		created := doc.Components.Schemas["CreatedAPIKeyDTO"]
+		assert.Contains(t, created.Properties, "key")
+		assert.Contains(t, created.Properties, "prefix")
+		assert.Contains(t, created.Required, "prefix")
+		assert.Equal(t, &openapi.Schema{Type: "string", Format: "date-time"}, created.Properties["created_at"])
+
+		assert.Equal(t, &openapi.Schema{
+			Type:     "array",
+			MinItems: intPtr(1),
+			Items:    &openapi.Schema{Type: "string", Enum: []string{"read", "write"}},
+		}, doc.Components.Schemas["APIKeyCreationDTO"].Properties["scopes"])
+		assert.Equal(t, &openapi.Schema{Type: "string", Format: "date-time", Nullable: true},
+			doc.Components.Schemas["APIKeyCreationDTO"].Properties["expires_at"])
+	})
+
+	t.Run("Should describe anonymous structs inline", func(t *testing.T) {
+		doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"})
+
+		schema := doc.SchemaOf(struct {
+			At      time.Time         `json:"at"`
+			Labels  map[string]string `json:"labels,omitempty"`
+			Ignored string            `json:"-"`
+		}{}, openapi.ResponseUsage)
+
+		assert.Equal(t, &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"at":     {Type: "string", Format: "date-time"},
+				"labels": {Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}},
+			},
+			Required: []string{"at"},
+		}, schema)
+		assert.Empty(t, doc.Components.Schemas)
+	})
+}
@@ -0,0 +1,260 @@
+package qti
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Maximum number of bytes read from a single file of the package.
+const maxFileSize = 1 << 20
+
+var (
+	ErrInvalidPackage  = errors.New("invalid qti package")
+	ErrMissingManifest = errors.New("qti package has no imsmanifest.xml")
+	ErrFileTooLarge    = errors.New("qti package file too large")
+	// Returned for items which can't be represented as a question.
+	ErrUnsupportedItem = errors.New("unsupported qti item")
+)
+
+// Represents an item which was read from the package, or the reason it couldn't be.
+type ReadResult struct {
+	Identifier string
+	Item       Item
+	Err        error
+}
+
+// WritePackage writes a QTI 2.1 content package with one assessmentItem per item.
+func WritePackage(w io.Writer, items []Item) error {
+	archive := zip.NewWriter(w)
+
+	m := manifest{
+		Xmlns:      manifestNamespace,
+		Identifier: "MANIFEST-QUESTIONS",
+		Metadata: manifestMetadata{
+			Schema:        "IMS Content",
+			SchemaVersion: "1.1",
+		},
+	}
+
+	for _, item := range items {
+		href := path.Join("items", item.Identifier+".xml")
+
+		err := writeXML(archive, href, newAssessmentItem(item))
+		if err != nil {
+			return err
+		}
+
+		m.Resources = append(m.Resources, resource{
+			Identifier: item.Identifier,
+			Type:       itemResourceType,
+			Href:       href,
+			Files:      []resourceFile{{Href: href}},
+		})
+	}
+
+	err := writeXML(archive, manifestFile, m)
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// ReadPackage reads every item resource listed in the package manifest.
+// Items which can't be converted are returned with a non nil Err.
+func ReadPackage(data []byte) ([]ReadResult, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, file := range archive.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	manifestZip, ok := files[manifestFile]
+	if !ok {
+		return nil, ErrMissingManifest
+	}
+
+	m := manifest{}
+	err = readXML(manifestZip, &m)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+	}
+
+	results := []ReadResult{}
+
+	for _, res := range m.Resources {
+		if !strings.HasPrefix(res.Type, "imsqti_item_xmlv2p") {
+			continue
+		}
+
+		result := ReadResult{Identifier: res.Identifier}
+
+		file, ok := files[path.Clean(res.Href)]
+		if !ok {
+			result.Err = fmt.Errorf("%w: file %s not found in package", ErrInvalidPackage, res.Href)
+			results = append(results, result)
+			continue
+		}
+
+		doc := assessmentItem{}
+		err := readXML(file, &doc)
+		if err != nil {
+			result.Err = fmt.Errorf("%w: %w", ErrInvalidPackage, err)
+			results = append(results, result)
+			continue
+		}
+
+		result.Item, result.Err = doc.toItem()
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// newAssessmentItem converts item into a QTI assessmentItem document.
+func newAssessmentItem(item Item) assessmentItem {
+	prompt := newRichText(item.Prompt)
+	interaction := choiceInteraction{
+		ResponseIdentifier: responseIdentifier,
+		Prompt:             &prompt,
+	}
+
+	correct := []string{}
+	for _, choice := range item.Choices {
+		interaction.SimpleChoices = append(interaction.SimpleChoices, simpleChoice{
+			Identifier: choice.Identifier,
+			Content:    newRichText(choice.Text).Content,
+		})
+		if choice.Correct {
+			correct = append(correct, choice.Identifier)
+		}
+	}
+
+	cardinality := "single"
+	interaction.MaxChoices = 1
+	if len(correct) > 1 {
+		cardinality = "multiple"
+		interaction.MaxChoices = 0
+	}
+
+	return assessmentItem{
+		Xmlns:      itemNamespace,
+		Identifier: item.Identifier,
+		Title:      item.Title,
+		ResponseDeclarations: []responseDeclaration{{
+			Identifier:      responseIdentifier,
+			Cardinality:     cardinality,
+			BaseType:        "identifier",
+			CorrectResponse: &correctResponse{Values: correct},
+		}},
+		OutcomeDeclarations: []outcomeDeclaration{{
+			Identifier:  "SCORE",
+			Cardinality: "single",
+			BaseType:    "float",
+		}},
+		ItemBody: itemBody{
+			ChoiceInteractions: []choiceInteraction{interaction},
+		},
+		ResponseProcessing: &responseProcessing{Template: matchCorrectTemplate},
+	}
+}
+
+// toItem converts the first choice interaction of the document into an item.
+func (doc assessmentItem) toItem() (Item, error) {
+	if len(doc.ItemBody.ChoiceInteractions) == 0 {
+		return Item{}, fmt.Errorf("%w: item has no choiceInteraction", ErrUnsupportedItem)
+	}
+	interaction := doc.ItemBody.ChoiceInteractions[0]
+	if len(interaction.SimpleChoices) == 0 {
+		return Item{}, fmt.Errorf("%w: choiceInteraction has no simpleChoice", ErrUnsupportedItem)
+	}
+
+	correct := map[string]bool{}
+	for _, declaration := range doc.ResponseDeclarations {
+		if declaration.Identifier != interaction.ResponseIdentifier || declaration.CorrectResponse == nil {
+			continue
+		}
+		for _, value := range declaration.CorrectResponse.Values {
+			correct[strings.TrimSpace(value)] = true
+		}
+	}
+
+	item := Item{
+		Identifier: doc.Identifier,
+		Title:      doc.Title,
+	}
+
+	if interaction.Prompt != nil {
+		item.Prompt = interaction.Prompt.Text()
+	}
+	if item.Prompt == "" {
+		item.Prompt = doc.Title
+	}
+
+	for _, choice := range interaction.SimpleChoices {
+		item.Choices = append(item.Choices, Choice{
+			Identifier: choice.Identifier,
+			Text:       richText{Content: choice.Content}.Text(),
+			Correct:    correct[choice.Identifier],
+		})
+	}
+
+	return item, nil
+}
+
+// writeXML marshals v into a new file of the archive.
+func writeXML(archive *zip.Writer, name string, v interface{}) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating %s in qti package %w", name, err)
+	}
+
+	_, err = io.WriteString(file, xml.Header)
+	if err != nil {
+		return fmt.Errorf("error writing %s in qti package %w", name, err)
+	}
+
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(v)
+	if err != nil {
+		return fmt.Errorf("error writing %s in qti package %w", name, err)
+	}
+
+	return nil
+}
+
+// readXML unmarshals the archive file into v. Files larger than maxFileSize are rejected
+// instead of being decoded partially.
+func readXML(file *zip.File, v interface{}) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxFileSize {
+		return fmt.Errorf("%w: %s is larger than %d bytes", ErrFileTooLarge, file.Name, maxFileSize)
+	}
+
+	return xml.Unmarshal(data, v)
+}
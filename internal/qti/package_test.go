@@ -0,0 +1,123 @@
+package qti_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/qti"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// itemManifest lists a single QTI 2.1 item stored in items/item-1.xml.
+const itemManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest xmlns="http://www.imsglobal.org/xsd/imscp_v1p1" identifier="MANIFEST">
+  <resources>
+    <resource identifier="item-1" type="imsqti_item_xmlv2p1" href="items/item-1.xml">
+      <file href="items/item-1.xml"/>
+    </resource>
+  </resources>
+</manifest>`
+
+// newPackage creates a zip archive with the given files.
+func newPackage(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for name, content := range files {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	return buf.Bytes()
+}
+
+func TestWriteAndReadPackage(t *testing.T) {
+	t.Run("Should read the written items with their correct choices", func(t *testing.T) {
+		items := []qti.Item{
+			{
+				Identifier: "question-1",
+				Title:      "Question 1",
+				Prompt:     "Is 2 < 3 & 3 > 2?",
+				Choices: []qti.Choice{
+					{Identifier: "option-1", Text: "yes", Correct: true},
+					{Identifier: "option-2", Text: "no"},
+				},
+			},
+			{
+				Identifier: "question-2",
+				Title:      "Question 2",
+				Prompt:     "Which are even?",
+				Choices: []qti.Choice{
+					{Identifier: "option-3", Text: "2", Correct: true},
+					{Identifier: "option-4", Text: "3"},
+					{Identifier: "option-5", Text: "4", Correct: true},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, qti.WritePackage(&buf, items))
+
+		results, err := qti.ReadPackage(buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, []qti.ReadResult{
+			{Identifier: "question-1", Item: items[0]},
+			{Identifier: "question-2", Item: items[1]},
+		}, results)
+	})
+}
+
+func TestReadPackage(t *testing.T) {
+	t.Run("Should reject data which is not a zip archive", func(t *testing.T) {
+		_, err := qti.ReadPackage([]byte("not a zip"))
+		assert.True(t, errors.Is(err, qti.ErrInvalidPackage))
+	})
+
+	t.Run("Should reject package without manifest", func(t *testing.T) {
+		_, err := qti.ReadPackage(newPackage(t, map[string]string{"items/item-1.xml": "<assessmentItem/>"}))
+		assert.True(t, errors.Is(err, qti.ErrMissingManifest))
+	})
+
+	t.Run("Should reject malformed manifest", func(t *testing.T) {
+		_, err := qti.ReadPackage(newPackage(t, map[string]string{"imsmanifest.xml": "<manifest><resources>"}))
+		assert.True(t, errors.Is(err, qti.ErrInvalidPackage))
+	})
+
+	t.Run("Should report item missing from package", func(t *testing.T) {
+		results, err := qti.ReadPackage(newPackage(t, map[string]string{"imsmanifest.xml": itemManifest}))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, errors.Is(results[0].Err, qti.ErrInvalidPackage))
+	})
+
+	t.Run("Should report item without choice interaction as unsupported", func(t *testing.T) {
+		results, err := qti.ReadPackage(newPackage(t, map[string]string{
+			"imsmanifest.xml": itemManifest,
+			"items/item-1.xml": `<assessmentItem identifier="item-1" title="Essay">
+  <itemBody><extendedTextInteraction responseIdentifier="RESPONSE"/></itemBody>
+</assessmentItem>`,
+		}))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "item-1", results[0].Identifier)
+		assert.True(t, errors.Is(results[0].Err, qti.ErrUnsupportedItem))
+	})
+
+	t.Run("Should report item larger than the file size limit", func(t *testing.T) {
+		results, err := qti.ReadPackage(newPackage(t, map[string]string{
+			"imsmanifest.xml": itemManifest,
+			"items/item-1.xml": `<assessmentItem identifier="item-1"><!--` + strings.Repeat("x", 1<<20) +
+				`--></assessmentItem>`,
+		}))
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, errors.Is(results[0].Err, qti.ErrFileTooLarge))
+	})
+}
@@ -0,0 +1,156 @@
+package qti
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+const (
+	// Namespace of QTI 2.1 assessment items.
+	itemNamespace = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	// Namespace of IMS content packaging manifest.
+	manifestNamespace = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	// Resource type used for QTI 2.1 items in the manifest.
+	itemResourceType = "imsqti_item_xmlv2p1"
+	// Response processing template for items with a single correct response.
+	matchCorrectTemplate = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"
+	// Identifier of the response variable bound to the choice interaction.
+	responseIdentifier = "RESPONSE"
+	// Name of the manifest file inside the package.
+	manifestFile = "imsmanifest.xml"
+)
+
+// Represents a choice question in a format independent of the QTI xml.
+type Item struct {
+	Identifier string
+	Title      string
+	Prompt     string
+	Choices    []Choice
+}
+
+// Represents a single choice of an item.
+type Choice struct {
+	Identifier string
+	Text       string
+	Correct    bool
+}
+
+// Represents the imsmanifest.xml document.
+type manifest struct {
+	XMLName       xml.Name         `xml:"manifest"`
+	Xmlns         string           `xml:"xmlns,attr,omitempty"`
+	Identifier    string           `xml:"identifier,attr"`
+	Metadata      manifestMetadata `xml:"metadata"`
+	Organizations struct{}         `xml:"organizations"`
+	Resources     []resource       `xml:"resources>resource"`
+}
+
+// Represents the metadata section of the manifest.
+type manifestMetadata struct {
+	Schema        string `xml:"schema"`
+	SchemaVersion string `xml:"schemaversion"`
+}
+
+// Represents a resource entry in the manifest.
+type resource struct {
+	Identifier string         `xml:"identifier,attr"`
+	Type       string         `xml:"type,attr"`
+	Href       string         `xml:"href,attr"`
+	Files      []resourceFile `xml:"file"`
+}
+
+// Represents a file which belongs to a manifest resource.
+type resourceFile struct {
+	Href string `xml:"href,attr"`
+}
+
+// Represents a QTI 2.1 assessmentItem document.
+type assessmentItem struct {
+	XMLName              xml.Name              `xml:"assessmentItem"`
+	Xmlns                string                `xml:"xmlns,attr,omitempty"`
+	Identifier           string                `xml:"identifier,attr"`
+	Title                string                `xml:"title,attr"`
+	Adaptive             bool                  `xml:"adaptive,attr"`
+	TimeDependent        bool                  `xml:"timeDependent,attr"`
+	ResponseDeclarations []responseDeclaration `xml:"responseDeclaration"`
+	OutcomeDeclarations  []outcomeDeclaration  `xml:"outcomeDeclaration"`
+	ItemBody             itemBody              `xml:"itemBody"`
+	ResponseProcessing   *responseProcessing   `xml:"responseProcessing,omitempty"`
+}
+
+// Represents declaration of a response variable and its correct values.
+type responseDeclaration struct {
+	Identifier      string           `xml:"identifier,attr"`
+	Cardinality     string           `xml:"cardinality,attr"`
+	BaseType        string           `xml:"baseType,attr"`
+	CorrectResponse *correctResponse `xml:"correctResponse,omitempty"`
+}
+
+// Represents the list of correct values of a response variable.
+type correctResponse struct {
+	Values []string `xml:"value"`
+}
+
+// Represents declaration of an outcome variable.
+type outcomeDeclaration struct {
+	Identifier  string `xml:"identifier,attr"`
+	Cardinality string `xml:"cardinality,attr"`
+	BaseType    string `xml:"baseType,attr"`
+}
+
+// Represents body of the item, only choice interactions are supported.
+type itemBody struct {
+	ChoiceInteractions []choiceInteraction `xml:"choiceInteraction"`
+}
+
+// Represents a choiceInteraction element.
+type choiceInteraction struct {
+	ResponseIdentifier string         `xml:"responseIdentifier,attr"`
+	Shuffle            bool           `xml:"shuffle,attr"`
+	MaxChoices         int            `xml:"maxChoices,attr"`
+	Prompt             *richText      `xml:"prompt,omitempty"`
+	SimpleChoices      []simpleChoice `xml:"simpleChoice"`
+}
+
+// Represents a simpleChoice element.
+type simpleChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Content    string `xml:",innerxml"`
+}
+
+// Represents responseProcessing element referencing a standard template.
+type responseProcessing struct {
+	Template string `xml:"template,attr"`
+}
+
+// Represents element content which may contain inline xhtml markup.
+type richText struct {
+	Content string `xml:",innerxml"`
+}
+
+// newRichText creates element content from plain text, escaping it.
+func newRichText(text string) richText {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+	return richText{Content: b.String()}
+}
+
+// Text returns the character data of the content with all markup stripped.
+func (t richText) Text() string {
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + t.Content + "</root>"))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var b strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if data, ok := token.(xml.CharData); ok {
+			b.Write(data)
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
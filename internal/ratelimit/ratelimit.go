@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit allows bursts of up to Requests requests, refilled evenly over Period.
+// A limit without requests or period doesn't limit anything.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Unlimited reports whether the limit doesn't limit anything.
+func (l Limit) Unlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// rate returns how many tokens are refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result represents the outcome of taking a token from a bucket.
+type Result struct {
+	// Whether a token was taken and the request may be served.
+	Allowed bool
+	// Capacity of the bucket and the whole tokens left in it.
+	Limit     int
+	Remaining int
+	// How long until the bucket is full again.
+	Reset time.Duration
+	// How long until the next token is available, zero when the request is allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets, implementations backed by a shared store let several instances share limits.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// When the bucket is full again, after which it's the same as a new bucket and can be pruned.
+	full time.Time
+}
+
+// MemoryStore keeps the token buckets in memory of a single instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewMemoryStore creates a new memory store without buckets.
+func NewMemoryStore(now func() time.Time) *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		now:     now,
+	}
+}
+
+// Take takes a token from the bucket of the key, refilling it for the time passed since it was last used.
+func (m *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := m.now()
+	capacity := float64(limit.Requests)
+	rate := limit.rate()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		m.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	result := Result{Limit: limit.Requests}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / rate)
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// Prune deletes the buckets which have been refilled since they were last used.
+func (m *MemoryStore) Prune(ctx context.Context) error {
+	now := m.now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+
+	return nil
+}
+
+// seconds converts fractional seconds to a duration, rounded up to the nanosecond.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
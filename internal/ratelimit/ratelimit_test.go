@@ -0,0 +1,95 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock returns a clock which can be advanced by the test.
+func clock() (func() time.Time, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 2, Period: 10 * time.Second}
+
+	t.Run("Should allow a burst up to the limit and then deny until a token is refilled", func(t *testing.T) {
+		now, advance := clock()
+		store := ratelimit.NewMemoryStore(now)
+		ctx := context.Background()
+
+		result, err := store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 5 * time.Second}, result)
+
+		result, err = store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 10 * time.Second}, result)
+
+		advance(2 * time.Second)
+		result, err = store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.Equal(t, ratelimit.Result{
+			Allowed:    false,
+			Limit:      2,
+			Remaining:  0,
+			Reset:      8 * time.Second,
+			RetryAfter: 3 * time.Second,
+		}, result)
+
+		advance(3 * time.Second)
+		result, err = store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+	})
+
+	t.Run("Should keep a separate bucket per key", func(t *testing.T) {
+		now, _ := clock()
+		store := ratelimit.NewMemoryStore(now)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			_, err := store.Take(ctx, "first", limit)
+			require.NoError(t, err)
+		}
+
+		result, err := store.Take(ctx, "second", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+	})
+}
+
+func TestMemoryStore_Prune(t *testing.T) {
+	t.Run("Should only delete buckets which have been refilled", func(t *testing.T) {
+		limit := ratelimit.Limit{Requests: 2, Period: 10 * time.Second}
+		now, advance := clock()
+		store := ratelimit.NewMemoryStore(now)
+		ctx := context.Background()
+
+		_, err := store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+
+		// Half a bucket is refilled, the client keeps the token it's owed.
+		advance(4 * time.Second)
+		require.NoError(t, store.Prune(ctx))
+		_, err = store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		result, err := store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+
+		advance(10 * time.Second)
+		require.NoError(t, store.Prune(ctx))
+		result, err = store.Take(ctx, "client", limit)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Remaining)
+	})
+}
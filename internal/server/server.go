@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Config for the http server and its shutdown.
+type Config struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// How long in-flight requests are given to finish once shutdown starts.
+	ShutdownTimeout time.Duration
+}
+
+// Server serves http requests until its context is cancelled, then drains in-flight requests
+// and closes the resources the handlers depend on, such as the database connection.
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+	closers         []io.Closer
+}
+
+// New creates a new server for the handler, closers are closed in order once the server stops.
+func New(cfg Config, handler http.Handler, closers ...io.Closer) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		},
+		shutdownTimeout: cfg.ShutdownTimeout,
+		closers:         closers,
+	}
+}
+
+// Run listens on the configured address and serves requests until the context is cancelled.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		s.close()
+		return fmt.Errorf("error listening on %s: %w", s.httpServer.Addr, err)
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves requests accepted by the listener until the context is cancelled,
+// then shuts down gracefully within the shutdown timeout.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+
+	go func() {
+		slog.Info("starting server", "addr", listener.Addr().String())
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		s.close()
+		return fmt.Errorf("error serving http: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	err := s.httpServer.Shutdown(shutdownCtx)
+	if err != nil {
+		// Requests still running after the deadline are cut off.
+		s.httpServer.Close()
+		err = fmt.Errorf("error draining in-flight requests: %w", err)
+	}
+
+	if serveErr := <-serveErr; !errors.Is(serveErr, http.ErrServerClosed) && err == nil {
+		err = fmt.Errorf("error serving http: %w", serveErr)
+	}
+
+	s.close()
+
+	if err == nil {
+		slog.Info("server stopped")
+	}
+
+	return err
+}
+
+// close closes the resources of the server, failures are logged as there's nothing left to do about them.
+func (s *Server) close() {
+	for _, closer := range s.closers {
+		err := closer.Close()
+		if err != nil {
+			slog.Error("error closing server resource", "error", err)
+		}
+	}
+}
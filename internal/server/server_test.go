@@ -0,0 +1,111 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloser records whether it was closed.
+type fakeCloser struct {
+	closed bool
+}
+
+func (c *fakeCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+// startServer serves the handler on a random local port and returns the url and the result of serving.
+func startServer(ctx context.Context, t *testing.T, srv *server.Server) (string, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(ctx, listener)
+	}()
+
+	return "http://" + listener.Addr().String(), served
+}
+
+func TestServer_Serve(t *testing.T) {
+	t.Run("Should drain in-flight requests and close resources on shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		started := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte("done"))
+		})
+
+		closer := &fakeCloser{}
+		url, served := startServer(ctx, t, server.New(server.Config{ShutdownTimeout: time.Second}, handler, closer))
+
+		type result struct {
+			body string
+			err  error
+		}
+		responses := make(chan result, 1)
+		go func() {
+			res, err := http.Get(url)
+			if err != nil {
+				responses <- result{err: err}
+				return
+			}
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			responses <- result{body: string(body), err: err}
+		}()
+
+		<-started
+		cancel()
+
+		response := <-responses
+		assert.NoError(t, response.err)
+		assert.Equal(t, "done", response.body)
+
+		assert.NoError(t, <-served)
+		assert.True(t, closer.closed)
+
+		_, err := http.Get(url)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should fail when in-flight requests outlive the shutdown timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})
+
+		closer := &fakeCloser{}
+		url, served := startServer(ctx, t,
+			server.New(server.Config{ShutdownTimeout: 50 * time.Millisecond}, handler, closer))
+
+		go http.Get(url)
+
+		<-started
+		cancel()
+
+		err := <-served
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.True(t, closer.closed)
+	})
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// Every api key starts with this marker, followed by the hex prefix and the secret separated by underscores.
+const apiKeyMarker = "hwk"
+
+// Attempts at generating a prefix no other api key has, before creating the key fails.
+const apiKeyPrefixAttempts = 3
+
+// Last use of an api key is only recorded once it is older than this, so keys don't cause a write on every request.
+const apiKeyLastUsedPrecision = time.Minute
+
+// APIKeyStorer represents necessary api key storage implementation for api key service.
+type APIKeyStorer interface {
+	CreateAPIKey(ctx context.Context, key entity.APIKey) (int, error)
+	GetAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (entity.APIKey, error)
+	UpdateAPIKeyLastUsed(ctx context.Context, keyID int, lastUsedAt time.Time) error
+	DeleteAPIKey(ctx context.Context, keyID int) error
+}
+
+// APIKeyService contains business logic for managing and authenticating api keys.
+type APIKeyService struct {
+	apiKeyStore APIKeyStorer
+	clock       Clock
+}
+
+// Instantiates a new api key service struct with api key repo and clock.
+func NewAPIKeyService(apiKeyStore APIKeyStorer, clock Clock) *APIKeyService {
+	return &APIKeyService{
+		apiKeyStore: apiKeyStore,
+		clock:       clock,
+	}
+}
+
+// CreateAPIKey handles the logic for generating a new api key, only its hash is stored.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, keyCreation APIKeyCreationDTO) (CreatedAPIKeyDTO, error) {
+	now := s.clock()
+
+	if keyCreation.ExpiresAt != nil && !keyCreation.ExpiresAt.After(now) {
+		return CreatedAPIKeyDTO{}, fmt.Errorf("%w: expires at must be in the future", ErrInvalidInput)
+	}
+
+	secret, err := newRandomToken()
+	if err != nil {
+		return CreatedAPIKeyDTO{}, fmt.Errorf("error trying to generate api key: %w", err)
+	}
+
+	key := entity.APIKey{
+		Name:      keyCreation.Name,
+		KeyHash:   hashToken(secret),
+		Scopes:    uniqueScopes(keyCreation.Scopes),
+		CreatedAt: now,
+		ExpiresAt: keyCreation.ExpiresAt,
+	}
+
+	// Prefixes are short, a new one is generated when the store reports another key already has it.
+	for attempt := 1; ; attempt++ {
+		key.Prefix, err = randomHex(4)
+		if err != nil {
+			return CreatedAPIKeyDTO{}, fmt.Errorf("error trying to generate api key: %w", err)
+		}
+
+		key.ID, err = s.apiKeyStore.CreateAPIKey(ctx, key)
+		if errors.Is(err, ErrConflict) && attempt < apiKeyPrefixAttempts {
+			continue
+		}
+		if err != nil {
+			return CreatedAPIKeyDTO{}, fmt.Errorf("error trying to create api key: %w", err)
+		}
+
+		break
+	}
+
+	return CreatedAPIKeyDTO{
+		APIKeyDTO: newAPIKeyDTO(key),
+		Key:       strings.Join([]string{apiKeyMarker, key.Prefix, secret}, "_"),
+	}, nil
+}
+
+// GetAPIKeys handles the logic for listing api keys without their secrets.
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) ([]APIKeyDTO, error) {
+	keys, err := s.apiKeyStore.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDTOs := []APIKeyDTO{}
+	for _, key := range keys {
+		keyDTOs = append(keyDTOs, newAPIKeyDTO(key))
+	}
+
+	return keyDTOs, nil
+}
+
+// DeleteAPIKey handles the logic for deleting an api key, which can't be used afterwards.
+func (s *APIKeyService) DeleteAPIKey(ctx context.Context, keyID int) error {
+	return s.apiKeyStore.DeleteAPIKey(ctx, keyID)
+}
+
+// AuthenticateAPIKey handles the logic for resolving an api key and recording its use, to the minute.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (auth.APIKey, error) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyMarker {
+		return auth.APIKey{}, fmt.Errorf("%w: malformed api key", ErrUnauthorized)
+	}
+
+	key, err := s.apiKeyStore.GetAPIKeyByPrefix(ctx, parts[1])
+	if errors.Is(err, ErrNotFound) {
+		return auth.APIKey{}, fmt.Errorf("%w: invalid api key", ErrUnauthorized)
+	}
+	if err != nil {
+		return auth.APIKey{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(parts[2])), []byte(key.KeyHash)) != 1 {
+		return auth.APIKey{}, fmt.Errorf("%w: invalid api key", ErrUnauthorized)
+	}
+
+	now := s.clock()
+
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return auth.APIKey{}, fmt.Errorf("%w: api key expired", ErrUnauthorized)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyLastUsedPrecision {
+		err = s.apiKeyStore.UpdateAPIKeyLastUsed(ctx, key.ID, now)
+		if err != nil {
+			return auth.APIKey{}, err
+		}
+	}
+
+	return auth.APIKey{
+		ID:       key.ID,
+		Name:     key.Name,
+		Scopes:   key.Scopes,
+		TenantID: key.TenantID,
+	}, nil
+}
+
+// randomHex generates a random hex string of n bytes.
+func randomHex(n int) (string, error) {
+	value := make([]byte, n)
+
+	_, err := rand.Read(value)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(value), nil
+}
+
+// uniqueScopes returns the scopes without duplicates, keeping their order.
+func uniqueScopes(scopes []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	return unique
+}
+
+// newAPIKeyDTO converts api key entity into a response dto without the key hash.
+func newAPIKeyDTO(key entity.APIKey) APIKeyDTO {
+	return APIKeyDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
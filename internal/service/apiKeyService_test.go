@@ -0,0 +1,192 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/apiKeyStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func initMockAPIKeyService(t *testing.T) (*apiKeyStorerMock.MockAPIKeyStorer, *service.APIKeyService) {
+	ctrl := gomock.NewController(t)
+
+	apiKeyStorer := apiKeyStorerMock.NewMockAPIKeyStorer(ctrl)
+
+	svc := service.NewAPIKeyService(apiKeyStorer, func() time.Time { return apiKeyNow })
+
+	assert.NotEmpty(t, svc)
+
+	return apiKeyStorer, svc
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	t.Run("Should create key which authenticates with its scopes", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		var storedKey entity.APIKey
+
+		apiKeyStorer.EXPECT().CreateAPIKey(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, key entity.APIKey) (int, error) {
+				storedKey = key
+				return 3, nil
+			})
+
+		res, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{
+			Name:   "pipeline",
+			Scopes: []string{"read", "write", "read"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res.ID)
+		assert.Equal(t, []string{"read", "write"}, res.Scopes)
+		assert.True(t, strings.HasPrefix(res.Key, "hwk_"+res.Prefix+"_"))
+		assert.False(t, strings.Contains(storedKey.KeyHash, res.Key))
+
+		storedKey.ID = 3
+		storedKey.TenantID = "school-a"
+		gomock.InOrder(
+			apiKeyStorer.EXPECT().GetAPIKeyByPrefix(ctx, res.Prefix).Return(storedKey, nil),
+			apiKeyStorer.EXPECT().UpdateAPIKeyLastUsed(ctx, 3, apiKeyNow).Return(nil),
+		)
+
+		key, err := svc.AuthenticateAPIKey(ctx, res.Key)
+		assert.Nil(t, err)
+		assert.Equal(t, auth.APIKey{ID: 3, Name: "pipeline", Scopes: []string{"read", "write"}, TenantID: "school-a"}, key)
+		assert.True(t, key.HasScope(auth.ScopeWrite))
+	})
+
+	t.Run("Should generate another prefix when the prefix is taken", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		prefixes := []string{}
+
+		apiKeyStorer.EXPECT().CreateAPIKey(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, key entity.APIKey) (int, error) {
+				prefixes = append(prefixes, key.Prefix)
+				if len(prefixes) == 1 {
+					return 0, service.ErrConflict
+				}
+				return 3, nil
+			}).Times(2)
+
+		res, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{Name: "pipeline", Scopes: []string{"read"}})
+		assert.Nil(t, err)
+		assert.Equal(t, prefixes[1], res.Prefix)
+		assert.True(t, strings.HasPrefix(res.Key, "hwk_"+prefixes[1]+"_"))
+	})
+
+	t.Run("Should give up after repeated prefix conflicts", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		apiKeyStorer.EXPECT().CreateAPIKey(ctx, gomock.Any()).Return(0, service.ErrConflict).Times(3)
+
+		_, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{Name: "pipeline", Scopes: []string{"read"}})
+		assert.True(t, errors.Is(err, service.ErrConflict))
+	})
+
+	t.Run("Should reject expiry in the past", func(t *testing.T) {
+		ctx := context.Background()
+		_, svc := initMockAPIKeyService(t)
+
+		expiresAt := apiKeyNow.Add(-time.Hour)
+
+		_, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{
+			Name:      "pipeline",
+			Scopes:    []string{"read"},
+			ExpiresAt: &expiresAt,
+		})
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+}
+
+func TestAPIKeyService_AuthenticateAPIKey(t *testing.T) {
+	t.Run("Should reject malformed key", func(t *testing.T) {
+		ctx := context.Background()
+		_, svc := initMockAPIKeyService(t)
+
+		_, err := svc.AuthenticateAPIKey(ctx, "not-an-api-key")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject key with wrong secret", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		apiKeyStorer.EXPECT().GetAPIKeyByPrefix(ctx, "abcd1234").
+			Return(entity.APIKey{ID: 3, Prefix: "abcd1234", KeyHash: "stored-hash"}, nil)
+
+		_, err := svc.AuthenticateAPIKey(ctx, "hwk_abcd1234_wrong")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject unknown prefix", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		apiKeyStorer.EXPECT().GetAPIKeyByPrefix(ctx, "abcd1234").Return(entity.APIKey{}, service.ErrNotFound)
+
+		_, err := svc.AuthenticateAPIKey(ctx, "hwk_abcd1234_secret")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should not record use of key used within the last minute", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		var storedKey entity.APIKey
+
+		apiKeyStorer.EXPECT().CreateAPIKey(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, key entity.APIKey) (int, error) {
+				storedKey = key
+				return 3, nil
+			})
+
+		res, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{Name: "pipeline", Scopes: []string{"read"}})
+		assert.Nil(t, err)
+
+		lastUsedAt := apiKeyNow.Add(-30 * time.Second)
+		storedKey.ID = 3
+		storedKey.LastUsedAt = &lastUsedAt
+
+		apiKeyStorer.EXPECT().GetAPIKeyByPrefix(ctx, res.Prefix).Return(storedKey, nil)
+
+		_, err = svc.AuthenticateAPIKey(ctx, res.Key)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Should reject expired key", func(t *testing.T) {
+		ctx := context.Background()
+		apiKeyStorer, svc := initMockAPIKeyService(t)
+
+		var storedKey entity.APIKey
+
+		apiKeyStorer.EXPECT().CreateAPIKey(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, key entity.APIKey) (int, error) {
+				storedKey = key
+				return 3, nil
+			})
+
+		res, err := svc.CreateAPIKey(ctx, service.APIKeyCreationDTO{Name: "pipeline", Scopes: []string{"read"}})
+		assert.Nil(t, err)
+
+		expiresAt := apiKeyNow
+		storedKey.ExpiresAt = &expiresAt
+
+		apiKeyStorer.EXPECT().GetAPIKeyByPrefix(ctx, res.Prefix).Return(storedKey, nil)
+
+		_, err = svc.AuthenticateAPIKey(ctx, res.Key)
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/logging"
+)
+
+// AttachmentStorer represents necessary attachment storage implementation for attachment service.
+type AttachmentStorer interface {
+	GetAttachmentsByQuestionID(ctx context.Context, questionID int) ([]entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, attachmentID int) (entity.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment entity.Attachment) (int, error)
+	DeleteAttachment(ctx context.Context, attachmentID int) error
+}
+
+// BlobStorer represents pluggable storage for attachment contents.
+type BlobStorer interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Limits which uploaded attachments have to satisfy.
+type AttachmentPolicy struct {
+	MaxSize             int64
+	AllowedContentTypes []string
+}
+
+// AttachmentService contains business logic for working with attachments.
+type AttachmentService struct {
+	attachmentStore     AttachmentStorer
+	blobStore           BlobStorer
+	questionStore       QuestionStorer
+	questionOptionStore QuestionOptionStorer
+	policy              AttachmentPolicy
+	questionPolicy      QuestionPolicy
+}
+
+// Instantiates a new attachment service struct with attachment, blob and question repos,
+// attachments can be added and deleted by callers who may modify their question.
+func NewAttachmentService(attachmentStore AttachmentStorer, blobStore BlobStorer,
+	questionStore QuestionStorer, questionOptionStore QuestionOptionStorer,
+	policy AttachmentPolicy, questionPolicy QuestionPolicy) *AttachmentService {
+	return &AttachmentService{
+		attachmentStore:     attachmentStore,
+		blobStore:           blobStore,
+		questionStore:       questionStore,
+		questionOptionStore: questionOptionStore,
+		policy:              policy,
+		questionPolicy:      questionPolicy,
+	}
+}
+
+// CreateAttachment handles the logic for storing an attachment of a question,
+// or of one of its options when optionID is not zero.
+func (s *AttachmentService) CreateAttachment(ctx context.Context,
+	questionID, optionID int, filename string, content io.Reader) (AttachmentDTO, error) {
+	err := authorizeModification(ctx, s.questionStore, s.questionPolicy, questionID)
+	if err != nil {
+		return AttachmentDTO{}, err
+	}
+
+	attachment := entity.Attachment{
+		QuestionID: questionID,
+		Filename:   filepath.Base(filepath.Clean("/" + filename)),
+	}
+
+	if optionID != 0 {
+		options, err := s.questionOptionStore.GetQuestionOptions(ctx, questionID)
+		if err != nil {
+			return AttachmentDTO{}, err
+		}
+
+		if !hasOption(options, optionID) {
+			return AttachmentDTO{}, fmt.Errorf("option %d of question %d %w", optionID, questionID, ErrNotFound)
+		}
+
+		attachment.QuestionID = 0
+		attachment.QuestionOptionID = optionID
+	}
+
+	// Read one byte over the limit to detect content which is too large.
+	data, err := io.ReadAll(io.LimitReader(content, s.policy.MaxSize+1))
+	if err != nil {
+		return AttachmentDTO{}, fmt.Errorf("error reading attachment: %w", err)
+	}
+
+	if int64(len(data)) > s.policy.MaxSize {
+		return AttachmentDTO{}, fmt.Errorf("%w: attachment exceeds %d bytes", ErrTooLarge, s.policy.MaxSize)
+	}
+
+	if len(data) == 0 {
+		return AttachmentDTO{}, fmt.Errorf("%w: attachment is empty", ErrInvalidInput)
+	}
+
+	// Content type is detected from the content itself, the one sent by the client is not trusted.
+	attachment.ContentType = http.DetectContentType(data)
+	if !s.isAllowedContentType(attachment.ContentType) {
+		return AttachmentDTO{}, fmt.Errorf("%w: content type %s is not allowed", ErrInvalidInput, attachment.ContentType)
+	}
+
+	attachment.Size = int64(len(data))
+
+	attachment.StorageKey, err = newStorageKey()
+	if err != nil {
+		return AttachmentDTO{}, fmt.Errorf("error trying to create attachment: %w", err)
+	}
+
+	err = s.blobStore.Put(ctx, attachment.StorageKey, bytes.NewReader(data))
+	if err != nil {
+		return AttachmentDTO{}, fmt.Errorf("error trying to create attachment: %w", err)
+	}
+
+	attachment.ID, err = s.attachmentStore.CreateAttachment(ctx, attachment)
+	if err != nil {
+		// Don't leave content without metadata behind.
+		deleteErr := s.blobStore.Delete(ctx, attachment.StorageKey)
+		if deleteErr != nil {
+			logging.FromContext(ctx).Error("error deleting attachment content without metadata",
+				"storage_key", attachment.StorageKey, "error", deleteErr)
+		}
+		return AttachmentDTO{}, fmt.Errorf("error trying to create attachment: %w", err)
+	}
+
+	return newAttachmentDTO(attachment), nil
+}
+
+// GetAttachmentContent handles the logic for retrieving attachment metadata and its content.
+// Caller is responsible for closing the returned content.
+func (s *AttachmentService) GetAttachmentContent(ctx context.Context,
+	attachmentID int) (AttachmentDTO, io.ReadCloser, error) {
+	attachment, err := s.attachmentStore.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return AttachmentDTO{}, nil, err
+	}
+
+	content, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return AttachmentDTO{}, nil, fmt.Errorf("error trying to get attachment: %w", err)
+	}
+
+	return newAttachmentDTO(attachment), content, nil
+}
+
+// DeleteAttachment handles the logic for deleting attachment metadata and its content.
+// Once the metadata is gone the attachment is deleted, failing to delete its content
+// only leaves unreferenced content behind and is logged.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, attachmentID int) error {
+	attachment, err := s.attachmentStore.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	err = authorizeModification(ctx, s.questionStore, s.questionPolicy, attachment.QuestionID)
+	if err != nil {
+		return err
+	}
+
+	err = s.attachmentStore.DeleteAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	err = s.blobStore.Delete(ctx, attachment.StorageKey)
+	if err != nil {
+		logging.FromContext(ctx).Error("error deleting content of deleted attachment",
+			"storage_key", attachment.StorageKey, "error", err)
+	}
+
+	return nil
+}
+
+func (s *AttachmentService) isAllowedContentType(contentType string) bool {
+	for _, allowed := range s.policy.AllowedContentTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// newAttachmentDTO converts attachment entity into a response dto.
+func newAttachmentDTO(attachment entity.Attachment) AttachmentDTO {
+	return AttachmentDTO{
+		ID:          attachment.ID,
+		URL:         fmt.Sprintf("/attachments/%d", attachment.ID),
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+}
+
+// newStorageKey generates a random key for storing attachment content.
+func newStorageKey() (string, error) {
+	key := make([]byte, 16)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+// hasOption checks whether option with the id is in the list.
+func hasOption(options []entity.QuestionOption, optionID int) bool {
+	for _, option := range options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/attachmentStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/blobStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionOptionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Attachment service mocks.
+type AttachmentMocks struct {
+	attachmentStorer     *attachmentStorerMock.MockAttachmentStorer
+	blobStorer           *blobStorerMock.MockBlobStorer
+	questionStorer       *questionStorerMock.MockQuestionStorer
+	questionOptionStorer *questionOptionStorerMock.MockQuestionOptionStorer
+}
+
+// PNG file signature followed by some content.
+var pngContent = append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), []byte("content")...)
+
+func initMockAttachmentService(t *testing.T) (AttachmentMocks, *service.AttachmentService) {
+	ctrl := gomock.NewController(t)
+
+	mocks := AttachmentMocks{
+		attachmentStorer:     attachmentStorerMock.NewMockAttachmentStorer(ctrl),
+		blobStorer:           blobStorerMock.NewMockBlobStorer(ctrl),
+		questionStorer:       questionStorerMock.NewMockQuestionStorer(ctrl),
+		questionOptionStorer: questionOptionStorerMock.NewMockQuestionOptionStorer(ctrl),
+	}
+
+	svc := service.NewAttachmentService(mocks.attachmentStorer, mocks.blobStorer,
+		mocks.questionStorer, mocks.questionOptionStorer, service.AttachmentPolicy{
+			MaxSize:             100,
+			AllowedContentTypes: []string{"image/png"},
+		}, service.NewRolePolicy())
+
+	assert.NotEmpty(t, svc)
+
+	return mocks, svc
+}
+
+func TestAttachmentService_CreateAttachment(t *testing.T) {
+	t.Run("Should create option attachment successfuly", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		returnQuestionOptions := []entity.QuestionOption{
+			{
+				ID:         2,
+				Body:       "first-option",
+				QuestionID: 1,
+			},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(returnQuestionOptions, nil),
+			mocks.blobStorer.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return(nil),
+			mocks.attachmentStorer.EXPECT().CreateAttachment(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, attachment entity.Attachment) (int, error) {
+					assert.Equal(t, 0, attachment.QuestionID)
+					assert.Equal(t, 2, attachment.QuestionOptionID)
+					assert.Equal(t, "image.png", attachment.Filename)
+					assert.NotEmpty(t, attachment.StorageKey)
+					return 5, nil
+				}),
+		)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 2, "../../image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{
+			ID:          5,
+			URL:         "/attachments/5",
+			Filename:    "image.png",
+			ContentType: "image/png",
+			Size:        int64(len(pngContent)),
+		}, attachment)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should require a caller", func(t *testing.T) {
+		ctx := context.Background()
+		_, svc := initMockAttachmentService(t)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 0, "image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should forbid attaching to question of another author", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "author-2", Role: auth.RoleAuthor})
+		mocks, svc := initMockAttachmentService(t)
+
+		mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 0, "image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should forbid takers from attaching", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "user:1", Role: auth.RoleTaker})
+		mocks, svc := initMockAttachmentService(t)
+
+		mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 2, "image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should fail because option doesn't belong to the question", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return([]entity.QuestionOption{}, nil),
+		)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 2, "image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+
+	t.Run("Should fail because content type is not allowed", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+		)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 0, "image.png", bytes.NewReader([]byte("<script></script>")))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+
+	t.Run("Should fail because content is too large", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+		)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 0, "image.png", bytes.NewReader(make([]byte, 101)))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.True(t, errors.Is(err, service.ErrTooLarge))
+	})
+
+	t.Run("Should delete stored content because creating metadata fails", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+		someErr := errors.New("some-error")
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.blobStorer.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Return(nil),
+			mocks.attachmentStorer.EXPECT().CreateAttachment(ctx, gomock.Any()).Return(0, someErr),
+			mocks.blobStorer.EXPECT().Delete(ctx, gomock.Any()).Return(nil),
+		)
+
+		attachment, err := svc.CreateAttachment(ctx, 1, 0, "image.png", bytes.NewReader(pngContent))
+		assert.Equal(t, service.AttachmentDTO{}, attachment)
+		assert.Error(t, err)
+	})
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	t.Run("Should delete attachment metadata and content successfuly", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.attachmentStorer.EXPECT().GetAttachmentByID(ctx, 1).
+				Return(entity.Attachment{ID: 1, QuestionID: 1, StorageKey: "key"}, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 1).Return(nil),
+			mocks.blobStorer.EXPECT().Delete(ctx, "key").Return(nil),
+		)
+
+		err := svc.DeleteAttachment(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should delete attachment when deleting its content fails", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.attachmentStorer.EXPECT().GetAttachmentByID(ctx, 1).
+				Return(entity.Attachment{ID: 1, QuestionID: 1, StorageKey: "key"}, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 1).Return(nil),
+			mocks.blobStorer.EXPECT().Delete(ctx, "key").Return(errors.New("disk failure")),
+		)
+
+		err := svc.DeleteAttachment(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should forbid deleting option attachment of question of another author", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "author-2", Role: auth.RoleAuthor})
+		mocks, svc := initMockAttachmentService(t)
+
+		gomock.InOrder(
+			mocks.attachmentStorer.EXPECT().GetAttachmentByID(ctx, 1).
+				Return(entity.Attachment{ID: 1, QuestionID: 1, QuestionOptionID: 2, StorageKey: "key"}, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+		)
+
+		err := svc.DeleteAttachment(ctx, 1)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should require a caller", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockAttachmentService(t)
+
+		mocks.attachmentStorer.EXPECT().GetAttachmentByID(ctx, 1).
+			Return(entity.Attachment{ID: 1, QuestionID: 1, StorageKey: "key"}, nil)
+
+		err := svc.DeleteAttachment(ctx, 1)
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
@@ -0,0 +1,6 @@
+package service
+
+import "time"
+
+// Clock returns the current time, services accept it so time dependent logic can be tested.
+type Clock func() time.Time
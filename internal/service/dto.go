@@ -1,27 +1,287 @@
 package service
 
-// Question option dto used for response.
+import "time"
+
+// Question option dto used for response, correct is omitted for callers who may not see it.
 type QuestionOptionDTO struct {
-	ID      int    `json:"id"`
-	Body    string `json:"body"`
-	Correct bool   `json:"correct"`
+	ID          int             `json:"id"`
+	Body        string          `json:"body"`
+	Correct     *bool           `json:"correct,omitempty"`
+	Attachments []AttachmentDTO `json:"attachments,omitempty"`
 }
 
 // Question dto used for response.
+// Body contains the markdown source and BodyHTML its sanitised html rendering.
+// EmpiricalDifficulty is the proportion of correct responses, null until calculated.
 type QuestionDTO struct {
-	ID      int                 `json:"id"`
-	Body    string              `json:"body"`
-	Options []QuestionOptionDTO `json:"options"`
+	ID                  int                 `json:"id"`
+	Body                string              `json:"body"`
+	BodyHTML            string              `json:"body_html"`
+	Difficulty          int                 `json:"difficulty,omitempty"`
+	EmpiricalDifficulty *float64            `json:"empirical_difficulty"`
+	ResponseCount       int                 `json:"response_count"`
+	Owner               string              `json:"owner,omitempty"`
+	Options             []QuestionOptionDTO `json:"options"`
+	Attachments         []AttachmentDTO     `json:"attachments,omitempty"`
+}
+
+// Attachment dto used for response.
+type AttachmentDTO struct {
+	ID          int    `json:"id"`
+	URL         string `json:"url"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
 }
 
 // Question option dto used for create and update request.
 type QuestionOptionCreationDTO struct {
-	Body    string `json:"body" validate:"required"`
-	Correct bool   `json:"correct"`
+	Body        string `json:"body" validate:"required"`
+	Correct     bool   `json:"correct"`
+	Explanation string `json:"explanation"`
 }
 
-// Question dto used for create and update requests.
+// Question dto used for create and update requests, body is written in markdown.
 type QuestionCreationDTO struct {
-	Body    string                      `json:"body" validate:"required"`
-	Options []QuestionOptionCreationDTO `json:"options" validate:"dive,required"`
+	Body        string                      `json:"body" validate:"required,max=10000"`
+	Options     []QuestionOptionCreationDTO `json:"options" validate:"dive,required"`
+	Explanation string                      `json:"explanation"`
+	Hints       []string                    `json:"hints" validate:"dive,required"`
+	Difficulty  int                         `json:"difficulty" validate:"omitempty,min=1,max=5"`
+}
+
+// Option explanation dto used for response.
+type QuestionOptionExplanationDTO struct {
+	ID          int    `json:"id"`
+	Body        string `json:"body"`
+	Correct     bool   `json:"correct"`
+	Explanation string `json:"explanation"`
+}
+
+// Question explanation dto used for response.
+type QuestionExplanationDTO struct {
+	QuestionID  int                            `json:"question_id"`
+	Explanation string                         `json:"explanation"`
+	Options     []QuestionOptionExplanationDTO `json:"options"`
+}
+
+// Question hint dto used for response, number starts from 1.
+type QuestionHintDTO struct {
+	QuestionID int    `json:"question_id"`
+	Number     int    `json:"number"`
+	Total      int    `json:"total"`
+	Body       string `json:"body"`
+}
+
+// QTI import dto used for response.
+type QTIImportDTO struct {
+	Imported []QTIImportedItemDTO `json:"imported"`
+	Skipped  []QTISkippedItemDTO  `json:"skipped"`
+}
+
+// Item of a QTI package which was imported as a question.
+type QTIImportedItemDTO struct {
+	Identifier string      `json:"identifier"`
+	Question   QuestionDTO `json:"question"`
+}
+
+// Item of a QTI package which couldn't be imported.
+type QTISkippedItemDTO struct {
+	Identifier string `json:"identifier"`
+	Reason     string `json:"reason"`
+}
+
+// Question response dto used for create request, duration is the time spent answering.
+type QuestionResponseCreationDTO struct {
+	OptionIDs  []int `json:"option_ids" validate:"required,min=1"`
+	DurationMs int   `json:"duration_ms" validate:"min=0"`
+}
+
+// Question response dto used for response, the explanation shows the learner why their answer is right or wrong.
+type QuestionResponseDTO struct {
+	ID               int                    `json:"id"`
+	QuestionID       int                    `json:"question_id"`
+	Correct          bool                   `json:"correct"`
+	CorrectOptionIDs []int                  `json:"correct_option_ids"`
+	Explanation      QuestionExplanationDTO `json:"explanation"`
+}
+
+// Option statistics dto used for response, correct is omitted for callers who may not see it.
+type OptionStatsDTO struct {
+	ID            int     `json:"id"`
+	Body          string  `json:"body"`
+	Correct       *bool   `json:"correct,omitempty"`
+	Selections    int     `json:"selections"`
+	SelectionRate float64 `json:"selection_rate"`
+}
+
+// Question statistics dto used for response.
+// NonFunctionalDistractors holds ids of incorrect options nobody selected, omitted for callers who may not see
+// option correctness.
+// DistractorEffectiveness is the share of incorrect options selected at least once.
+type QuestionStatsDTO struct {
+	QuestionID               int              `json:"question_id"`
+	Attempts                 int              `json:"attempts"`
+	CorrectCount             int              `json:"correct_count"`
+	PercentCorrect           float64          `json:"percent_correct"`
+	AverageTimeMs            *float64         `json:"average_time_ms"`
+	Options                  []OptionStatsDTO `json:"options"`
+	NonFunctionalDistractors []int            `json:"non_functional_distractors,omitempty"`
+	DistractorEffectiveness  float64          `json:"distractor_effectiveness"`
+}
+
+// Review dto used for create request, grade is the SM-2 quality of recall from 0 to 5.
+type ReviewCreationDTO struct {
+	QuestionID int `json:"question_id" validate:"required"`
+	Grade      int `json:"grade" validate:"min=0,max=5"`
+}
+
+// Review dto used for response, question is only included when listing due reviews.
+type ReviewDTO struct {
+	QuestionID     int          `json:"question_id"`
+	EaseFactor     float64      `json:"ease_factor"`
+	IntervalDays   int          `json:"interval_days"`
+	Repetitions    int          `json:"repetitions"`
+	DueAt          time.Time    `json:"due_at"`
+	LastReviewedAt time.Time    `json:"last_reviewed_at"`
+	Question       *QuestionDTO `json:"question,omitempty"`
+}
+
+// Practice session dto used for create request.
+type PracticeSessionCreationDTO struct {
+	UserID string `json:"user_id" validate:"required,max=255"`
+}
+
+// Practice session dto used for response, rating is the current rating of the user.
+type PracticeSessionDTO struct {
+	ID     int     `json:"id"`
+	UserID string  `json:"user_id"`
+	Rating float64 `json:"rating"`
+}
+
+// Practice question dto used for response to next question request.
+type PracticeQuestionDTO struct {
+	SessionID      int         `json:"session_id"`
+	UserRating     float64     `json:"user_rating"`
+	QuestionRating float64     `json:"question_rating"`
+	Question       QuestionDTO `json:"question"`
+}
+
+// Practice answer dto used for create request.
+type PracticeAnswerCreationDTO struct {
+	QuestionID int   `json:"question_id" validate:"required"`
+	OptionIDs  []int `json:"option_ids" validate:"required,min=1"`
+}
+
+// Practice answer dto used for response, ratings are the ones after the answer.
+type PracticeAnswerDTO struct {
+	QuestionID       int     `json:"question_id"`
+	Correct          bool    `json:"correct"`
+	CorrectOptionIDs []int   `json:"correct_option_ids"`
+	UserRating       float64 `json:"user_rating"`
+	QuestionRating   float64 `json:"question_rating"`
+}
+
+// Run answer dto used in run create request.
+type RunAnswerDTO struct {
+	QuestionID int   `json:"question_id" validate:"required"`
+	OptionIDs  []int `json:"option_ids" validate:"required,min=1"`
+}
+
+// Run dto used for create request.
+type RunCreationDTO struct {
+	PlayerName string         `json:"player_name" validate:"required,max=255"`
+	Answers    []RunAnswerDTO `json:"answers" validate:"required,min=1,dive"`
+	DurationMs int            `json:"duration_ms" validate:"min=0"`
+}
+
+// Run dto used for response.
+type RunDTO struct {
+	ID          int       `json:"id"`
+	PlayerName  string    `json:"player_name"`
+	Score       int       `json:"score"`
+	Total       int       `json:"total"`
+	DurationMs  int64     `json:"duration_ms"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Leaderboard entry dto used for response.
+type LeaderboardEntryDTO struct {
+	Rank        int       `json:"rank"`
+	PlayerName  string    `json:"player_name"`
+	RunID       int       `json:"run_id"`
+	Score       int       `json:"score"`
+	Total       int       `json:"total"`
+	DurationMs  int64     `json:"duration_ms"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Leaderboard dto used for response.
+type LeaderboardDTO struct {
+	Window  string                `json:"window"`
+	Entries []LeaderboardEntryDTO `json:"entries"`
+}
+
+// User dto used for registration request, passwords longer than 72 bytes are rejected on registration.
+type UserRegistrationDTO struct {
+	Username string `json:"username" validate:"required,min=3,max=64"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// User dto used for login request.
+type UserLoginDTO struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// User dto used for response.
+type UserDTO struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Session dto used for login response, token is sent as a bearer token on later requests.
+type SessionDTO struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      UserDTO   `json:"user"`
+}
+
+// Refresh token dto used for refresh and revoke requests.
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// Token pair dto used for response, access token is sent as a bearer token on later requests.
+type TokenPairDTO struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// Api key dto used for create request, key never expires when expires at is omitted.
+type APIKeyCreationDTO struct {
+	Name      string     `json:"name" validate:"required,max=255"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Api key dto used for response.
+type APIKeyDTO struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// Api key dto used for create response, the key is only ever returned here.
+type CreatedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
 }
@@ -0,0 +1,18 @@
+package service
+
+import "errors"
+
+var (
+	// Returned when the requested record doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// Returned when the provided input can't be processed.
+	ErrInvalidInput = errors.New("invalid input")
+	// Returned when the provided content exceeds the allowed size.
+	ErrTooLarge = errors.New("content too large")
+	// Returned when the record being created already exists.
+	ErrConflict = errors.New("already exists")
+	// Returned when the caller can't be authenticated.
+	ErrUnauthorized = errors.New("unauthorized")
+	// Returned when the caller isn't allowed to perform the operation.
+	ErrForbidden = errors.New("forbidden")
+)
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// gradeResponse checks the selected options against the options of the question.
+// Response is correct when exactly the correct options are selected.
+// Returns the selected option ids without duplicates and the correct option ids.
+func gradeResponse(options []entity.QuestionOption, selectedIDs []int) (bool, []int, []int, error) {
+	correctOptions := map[int]bool{}
+	for _, option := range options {
+		correctOptions[option.ID] = option.Correct
+	}
+
+	selected := map[int]bool{}
+	for _, optionID := range selectedIDs {
+		if _, ok := correctOptions[optionID]; !ok {
+			return false, nil, nil, fmt.Errorf("%w: option %d doesn't belong to the question", ErrInvalidInput, optionID)
+		}
+		selected[optionID] = true
+	}
+
+	correct := true
+	correctIDs := []int{}
+	for optionID, isCorrect := range correctOptions {
+		if isCorrect {
+			correctIDs = append(correctIDs, optionID)
+		}
+		if isCorrect != selected[optionID] {
+			correct = false
+		}
+	}
+
+	uniqueSelectedIDs := []int{}
+	for optionID := range selected {
+		uniqueSelectedIDs = append(uniqueSelectedIDs, optionID)
+	}
+
+	sort.Ints(correctIDs)
+	sort.Ints(uniqueSelectedIDs)
+
+	return correct, uniqueSelectedIDs, correctIDs, nil
+}
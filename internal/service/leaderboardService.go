@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// Leaderboard time windows, all covers every run while the others are rolling windows ending at the latest run.
+var leaderboardWindows = map[string]time.Duration{
+	"all":   0,
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// RunStorer represents necessary run and leaderboard storage implementation for leaderboard service.
+// Creating a run refreshes the materialised leaderboards of every window from runs submitted since its cutoff.
+type RunStorer interface {
+	CreateRun(ctx context.Context, run entity.Run, cutoffs map[string]time.Time) (int, error)
+	GetLeaderboard(ctx context.Context, window string, pageSize, offset int) ([]entity.LeaderboardEntry, error)
+	GetLeaderboardEntry(ctx context.Context, window, playerName string) (entity.LeaderboardEntry, error)
+}
+
+// LeaderboardService contains business logic for scoring runs and ranking players.
+type LeaderboardService struct {
+	runStore            RunStorer
+	questionOptionStore QuestionOptionStorer
+	clock               Clock
+}
+
+// Instantiates a new leaderboard service struct with run and question option repos and clock.
+func NewLeaderboardService(runStore RunStorer,
+	questionOptionStore QuestionOptionStorer, clock Clock) *LeaderboardService {
+	return &LeaderboardService{
+		runStore:            runStore,
+		questionOptionStore: questionOptionStore,
+		clock:               clock,
+	}
+}
+
+// SubmitRun handles the logic for grading the answers of a run, storing it and refreshing the leaderboards.
+func (s *LeaderboardService) SubmitRun(ctx context.Context, runCreation RunCreationDTO) (RunDTO, error) {
+	score := 0
+	answered := map[int]bool{}
+
+	for _, answer := range runCreation.Answers {
+		if answered[answer.QuestionID] {
+			return RunDTO{}, fmt.Errorf("%w: question %d is answered more than once", ErrInvalidInput, answer.QuestionID)
+		}
+		answered[answer.QuestionID] = true
+
+		options, err := s.questionOptionStore.GetQuestionOptions(ctx, answer.QuestionID)
+		if err != nil {
+			return RunDTO{}, err
+		}
+		if len(options) == 0 {
+			return RunDTO{}, fmt.Errorf("%w: question %d has no options to answer", ErrInvalidInput, answer.QuestionID)
+		}
+
+		correct, _, _, err := gradeResponse(options, answer.OptionIDs)
+		if err != nil {
+			return RunDTO{}, err
+		}
+		if correct {
+			score++
+		}
+	}
+
+	now := s.clock()
+	run := entity.Run{
+		PlayerName:  runCreation.PlayerName,
+		Score:       score,
+		Total:       len(runCreation.Answers),
+		Duration:    time.Duration(runCreation.DurationMs) * time.Millisecond,
+		SubmittedAt: now,
+	}
+
+	cutoffs := map[string]time.Time{}
+	for window, period := range leaderboardWindows {
+		cutoffs[window] = cutoff(now, period)
+	}
+
+	runID, err := s.runStore.CreateRun(ctx, run, cutoffs)
+	if err != nil {
+		return RunDTO{}, fmt.Errorf("error trying to create run: %w", err)
+	}
+
+	return RunDTO{
+		ID:          runID,
+		PlayerName:  run.PlayerName,
+		Score:       run.Score,
+		Total:       run.Total,
+		DurationMs:  run.Duration.Milliseconds(),
+		SubmittedAt: run.SubmittedAt,
+	}, nil
+}
+
+// GetLeaderboard handles the logic for getting a page of the leaderboard of the window.
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context,
+	window string, pageSize, offset int) (LeaderboardDTO, error) {
+	err := validateWindow(window)
+	if err != nil {
+		return LeaderboardDTO{}, err
+	}
+
+	entries, err := s.runStore.GetLeaderboard(ctx, window, pageSize, offset)
+	if err != nil {
+		return LeaderboardDTO{}, err
+	}
+
+	leaderboard := LeaderboardDTO{
+		Window:  window,
+		Entries: []LeaderboardEntryDTO{},
+	}
+
+	for _, entry := range entries {
+		leaderboard.Entries = append(leaderboard.Entries, newLeaderboardEntryDTO(entry))
+	}
+
+	return leaderboard, nil
+}
+
+// GetPlayerRank handles the logic for getting the leaderboard entry of the player in the window.
+func (s *LeaderboardService) GetPlayerRank(ctx context.Context,
+	window, playerName string) (LeaderboardEntryDTO, error) {
+	err := validateWindow(window)
+	if err != nil {
+		return LeaderboardEntryDTO{}, err
+	}
+
+	entry, err := s.runStore.GetLeaderboardEntry(ctx, window, playerName)
+	if err != nil {
+		return LeaderboardEntryDTO{}, err
+	}
+
+	return newLeaderboardEntryDTO(entry), nil
+}
+
+// validateWindow checks the leaderboard window exists.
+func validateWindow(window string) error {
+	if _, ok := leaderboardWindows[window]; !ok {
+		return fmt.Errorf("leaderboard %s %w", window, ErrNotFound)
+	}
+
+	return nil
+}
+
+// cutoff returns the time runs of a window with the period are submitted since, the zero time for all time.
+// Rolling windows end at the latest submission, which refreshes the leaderboards.
+func cutoff(now time.Time, period time.Duration) time.Time {
+	if period == 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-period)
+}
+
+// newLeaderboardEntryDTO converts leaderboard entry entity into a response dto.
+func newLeaderboardEntryDTO(entry entity.LeaderboardEntry) LeaderboardEntryDTO {
+	return LeaderboardEntryDTO{
+		Rank:        entry.Rank,
+		PlayerName:  entry.PlayerName,
+		RunID:       entry.RunID,
+		Score:       entry.Score,
+		Total:       entry.Total,
+		DurationMs:  entry.Duration.Milliseconds(),
+		SubmittedAt: entry.SubmittedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionOptionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/runStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var leaderboardNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func initMockLeaderboardService(t *testing.T) (*runStorerMock.MockRunStorer,
+	*questionOptionStorerMock.MockQuestionOptionStorer, *service.LeaderboardService) {
+	ctrl := gomock.NewController(t)
+
+	runStorer := runStorerMock.NewMockRunStorer(ctrl)
+	questionOptionStorer := questionOptionStorerMock.NewMockQuestionOptionStorer(ctrl)
+
+	svc := service.NewLeaderboardService(runStorer, questionOptionStorer, func() time.Time { return leaderboardNow })
+
+	assert.NotEmpty(t, svc)
+
+	return runStorer, questionOptionStorer, svc
+}
+
+func TestLeaderboardService_SubmitRun(t *testing.T) {
+	t.Run("Should score and store run", func(t *testing.T) {
+		ctx := context.Background()
+		runStorer, questionOptionStorer, svc := initMockLeaderboardService(t)
+
+		expectedRun := entity.Run{
+			PlayerName:  "player",
+			Score:       1,
+			Total:       2,
+			Duration:    90 * time.Second,
+			SubmittedAt: leaderboardNow,
+		}
+
+		gomock.InOrder(
+			questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return([]entity.QuestionOption{
+				{ID: 1, Correct: true, QuestionID: 1},
+				{ID: 2, Correct: false, QuestionID: 1},
+			}, nil),
+			questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 2).Return([]entity.QuestionOption{
+				{ID: 3, Correct: true, QuestionID: 2},
+				{ID: 4, Correct: false, QuestionID: 2},
+			}, nil),
+			runStorer.EXPECT().CreateRun(ctx, expectedRun, map[string]time.Time{
+				"all":   {},
+				"day":   leaderboardNow.Add(-24 * time.Hour),
+				"week":  leaderboardNow.Add(-7 * 24 * time.Hour),
+				"month": leaderboardNow.Add(-30 * 24 * time.Hour),
+			}).Return(5, nil),
+		)
+
+		res, err := svc.SubmitRun(ctx, service.RunCreationDTO{
+			PlayerName: "player",
+			Answers: []service.RunAnswerDTO{
+				{QuestionID: 1, OptionIDs: []int{1}},
+				{QuestionID: 2, OptionIDs: []int{4}},
+			},
+			DurationMs: 90000,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, service.RunDTO{
+			ID:          5,
+			PlayerName:  "player",
+			Score:       1,
+			Total:       2,
+			DurationMs:  90000,
+			SubmittedAt: leaderboardNow,
+		}, res)
+	})
+
+	t.Run("Should reject run which answers the same question twice", func(t *testing.T) {
+		ctx := context.Background()
+		_, questionOptionStorer, svc := initMockLeaderboardService(t)
+
+		questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return([]entity.QuestionOption{
+			{ID: 1, Correct: true, QuestionID: 1},
+		}, nil)
+
+		_, err := svc.SubmitRun(ctx, service.RunCreationDTO{
+			PlayerName: "player",
+			Answers: []service.RunAnswerDTO{
+				{QuestionID: 1, OptionIDs: []int{1}},
+				{QuestionID: 1, OptionIDs: []int{1}},
+			},
+		})
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+
+	t.Run("Should reject run which answers question without options", func(t *testing.T) {
+		ctx := context.Background()
+		_, questionOptionStorer, svc := initMockLeaderboardService(t)
+
+		questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 9).Return([]entity.QuestionOption{}, nil)
+
+		_, err := svc.SubmitRun(ctx, service.RunCreationDTO{
+			PlayerName: "player",
+			Answers:    []service.RunAnswerDTO{{QuestionID: 9, OptionIDs: []int{1}}},
+		})
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+}
+
+func TestLeaderboardService_GetLeaderboard(t *testing.T) {
+	t.Run("Should return leaderboard entries of the window", func(t *testing.T) {
+		ctx := context.Background()
+		runStorer, _, svc := initMockLeaderboardService(t)
+
+		runStorer.EXPECT().GetLeaderboard(ctx, "week", 10, 0).Return([]entity.LeaderboardEntry{
+			{Window: "week", Rank: 1, PlayerName: "player", RunID: 5, Score: 2, Total: 2, Duration: time.Second},
+		}, nil)
+
+		res, err := svc.GetLeaderboard(ctx, "week", 10, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, service.LeaderboardDTO{
+			Window: "week",
+			Entries: []service.LeaderboardEntryDTO{
+				{Rank: 1, PlayerName: "player", RunID: 5, Score: 2, Total: 2, DurationMs: 1000},
+			},
+		}, res)
+	})
+
+	t.Run("Should return not found for unknown window", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, svc := initMockLeaderboardService(t)
+
+		_, err := svc.GetLeaderboard(ctx, "year", 10, 0)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+func TestLeaderboardService_GetPlayerRank(t *testing.T) {
+	t.Run("Should return rank of the player", func(t *testing.T) {
+		ctx := context.Background()
+		runStorer, _, svc := initMockLeaderboardService(t)
+
+		runStorer.EXPECT().GetLeaderboardEntry(ctx, "all", "player").Return(entity.LeaderboardEntry{
+			Window: "all", Rank: 3, PlayerName: "player", RunID: 5, Score: 1, Total: 2,
+		}, nil)
+
+		res, err := svc.GetPlayerRank(ctx, "all", "player")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res.Rank)
+	})
+}
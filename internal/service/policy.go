@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// QuestionPolicy decides which question operations a caller may perform.
+type QuestionPolicy interface {
+	CanSeeCorrectness(caller auth.Caller) bool
+	CanCreate(caller auth.Caller) bool
+	CanModify(caller auth.Caller, question entity.Question) bool
+}
+
+// RolePolicy is the question policy based on the role of the caller.
+type RolePolicy struct{}
+
+// NewRolePolicy creates a new instance of role policy.
+func NewRolePolicy() RolePolicy {
+	return RolePolicy{}
+}
+
+// CanSeeCorrectness reports whether the caller may see which options are correct, takers may not.
+func (RolePolicy) CanSeeCorrectness(caller auth.Caller) bool {
+	return caller.Role == auth.RoleAdmin || caller.Role == auth.RoleAuthor || caller.Role == auth.RoleReviewer
+}
+
+// CanCreate reports whether the caller may create questions.
+func (RolePolicy) CanCreate(caller auth.Caller) bool {
+	return caller.Role == auth.RoleAdmin || caller.Role == auth.RoleAuthor
+}
+
+// CanModify reports whether the caller may update or delete the question, authors only their own.
+func (RolePolicy) CanModify(caller auth.Caller, question entity.Question) bool {
+	switch caller.Role {
+	case auth.RoleAdmin:
+		return true
+	case auth.RoleAuthor:
+		return question.Owner != "" && question.Owner == caller.ID
+	default:
+		return false
+	}
+}
+
+// requireCaller returns the caller carried by the context, failing when there is none.
+func requireCaller(ctx context.Context) (auth.Caller, error) {
+	caller, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return auth.Caller{}, fmt.Errorf("%w: caller is required", ErrUnauthorized)
+	}
+
+	return caller, nil
+}
+
+// authorizeModification checks the caller in the context may modify the question with the policy.
+func authorizeModification(ctx context.Context,
+	questionStore QuestionStorer, policy QuestionPolicy, questionID int) error {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return err
+	}
+
+	question, err := questionStore.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return err
+	}
+
+	if !policy.CanModify(caller, question) {
+		return fmt.Errorf("%w: %s can't modify question %d", ErrForbidden, caller.Role, questionID)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+const (
+	// Rating of users and questions which were not rated yet.
+	initialRating = 1500.0
+	// Maximum change of a rating after a single answer.
+	ratingKFactor = 32.0
+)
+
+// PracticeStorer represents necessary practice storage implementation for practice service.
+type PracticeStorer interface {
+	CreatePracticeSession(ctx context.Context, session entity.PracticeSession) (int, error)
+	GetPracticeSession(ctx context.Context, sessionID int) (entity.PracticeSession, error)
+	HasPracticeAnswer(ctx context.Context, sessionID, questionID int) (bool, error)
+	GetUserRating(ctx context.Context, userID string) (float64, error)
+	GetQuestionRating(ctx context.Context, questionID int) (float64, error)
+	CreatePracticeAnswer(ctx context.Context, userID string, answer entity.PracticeAnswer,
+		defaultRating float64, updateRatings RatingUpdate) (entity.PracticeAnswer, error)
+}
+
+// RatingUpdate returns ratings of the user and the question after an answer from their current ratings.
+type RatingUpdate func(userRating, questionRating float64) (float64, float64)
+
+// PracticeQuestionPicker represents storage which can pick the next question of a practice session.
+type PracticeQuestionPicker interface {
+	GetNextPracticeQuestionID(ctx context.Context, sessionID int, rating, defaultRating float64) (int, error)
+}
+
+// PracticeService contains business logic for adaptive practice sessions based on Elo ratings.
+type PracticeService struct {
+	practiceStore       PracticeStorer
+	questionPicker      PracticeQuestionPicker
+	questionService     QuestionGetter
+	questionOptionStore QuestionOptionStorer
+	clock               Clock
+}
+
+// Instantiates a new practice service struct with practice and question repos, question service and clock.
+func NewPracticeService(practiceStore PracticeStorer, questionPicker PracticeQuestionPicker,
+	questionService QuestionGetter, questionOptionStore QuestionOptionStorer, clock Clock) *PracticeService {
+	return &PracticeService{
+		practiceStore:       practiceStore,
+		questionPicker:      questionPicker,
+		questionService:     questionService,
+		questionOptionStore: questionOptionStore,
+		clock:               clock,
+	}
+}
+
+// CreatePracticeSession handles the logic for starting a new practice session for the user.
+func (s *PracticeService) CreatePracticeSession(ctx context.Context,
+	sessionCreation PracticeSessionCreationDTO) (PracticeSessionDTO, error) {
+	rating, err := s.userRating(ctx, sessionCreation.UserID)
+	if err != nil {
+		return PracticeSessionDTO{}, err
+	}
+
+	sessionID, err := s.practiceStore.CreatePracticeSession(ctx, entity.PracticeSession{
+		UserID:    sessionCreation.UserID,
+		CreatedAt: s.clock(),
+	})
+	if err != nil {
+		return PracticeSessionDTO{}, fmt.Errorf("error trying to create practice session: %w", err)
+	}
+
+	return PracticeSessionDTO{
+		ID:     sessionID,
+		UserID: sessionCreation.UserID,
+		Rating: rating,
+	}, nil
+}
+
+// GetNextQuestion handles the logic for picking the unseen question which best matches the user rating.
+func (s *PracticeService) GetNextQuestion(ctx context.Context, sessionID int) (PracticeQuestionDTO, error) {
+	session, err := s.practiceStore.GetPracticeSession(ctx, sessionID)
+	if err != nil {
+		return PracticeQuestionDTO{}, err
+	}
+
+	userRating, err := s.userRating(ctx, session.UserID)
+	if err != nil {
+		return PracticeQuestionDTO{}, err
+	}
+
+	questionID, err := s.questionPicker.GetNextPracticeQuestionID(ctx, sessionID, userRating, initialRating)
+	if err != nil {
+		return PracticeQuestionDTO{}, err
+	}
+
+	questionRating, err := s.questionRating(ctx, questionID)
+	if err != nil {
+		return PracticeQuestionDTO{}, err
+	}
+
+	question, err := s.questionService.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return PracticeQuestionDTO{}, err
+	}
+
+	return PracticeQuestionDTO{
+		SessionID:      sessionID,
+		UserRating:     userRating,
+		QuestionRating: questionRating,
+		Question:       question,
+	}, nil
+}
+
+// SubmitAnswer handles the logic for grading an answer within the session and updating the ratings.
+func (s *PracticeService) SubmitAnswer(ctx context.Context,
+	sessionID int, answerCreation PracticeAnswerCreationDTO) (PracticeAnswerDTO, error) {
+	session, err := s.practiceStore.GetPracticeSession(ctx, sessionID)
+	if err != nil {
+		return PracticeAnswerDTO{}, err
+	}
+
+	answered, err := s.practiceStore.HasPracticeAnswer(ctx, sessionID, answerCreation.QuestionID)
+	if err != nil {
+		return PracticeAnswerDTO{}, err
+	}
+	if answered {
+		return PracticeAnswerDTO{}, fmt.Errorf("%w: question %d was already answered in the session",
+			ErrInvalidInput, answerCreation.QuestionID)
+	}
+
+	options, err := s.questionOptionStore.GetQuestionOptions(ctx, answerCreation.QuestionID)
+	if err != nil {
+		return PracticeAnswerDTO{}, err
+	}
+	if len(options) == 0 {
+		return PracticeAnswerDTO{}, fmt.Errorf("options of question %d %w", answerCreation.QuestionID, ErrNotFound)
+	}
+
+	correct, _, correctIDs, err := gradeResponse(options, answerCreation.OptionIDs)
+	if err != nil {
+		return PracticeAnswerDTO{}, err
+	}
+
+	// Ratings are read and updated by the store within the transaction recording the answer.
+	answer, err := s.practiceStore.CreatePracticeAnswer(ctx, session.UserID, entity.PracticeAnswer{
+		SessionID:  sessionID,
+		QuestionID: answerCreation.QuestionID,
+		Correct:    correct,
+		AnsweredAt: s.clock(),
+	}, initialRating, func(userRating, questionRating float64) (float64, float64) {
+		return updateRatings(userRating, questionRating, correct)
+	})
+	if err != nil {
+		return PracticeAnswerDTO{}, fmt.Errorf("error trying to record practice answer: %w", err)
+	}
+
+	return PracticeAnswerDTO{
+		QuestionID:       answerCreation.QuestionID,
+		Correct:          correct,
+		CorrectOptionIDs: correctIDs,
+		UserRating:       answer.UserRating,
+		QuestionRating:   answer.QuestionRating,
+	}, nil
+}
+
+// userRating returns current rating of the user, or the initial rating if the user was never rated.
+func (s *PracticeService) userRating(ctx context.Context, userID string) (float64, error) {
+	rating, err := s.practiceStore.GetUserRating(ctx, userID)
+	if errors.Is(err, ErrNotFound) {
+		return initialRating, nil
+	}
+
+	return rating, err
+}
+
+// questionRating returns current rating of the question, or the initial rating if the question was never rated.
+func (s *PracticeService) questionRating(ctx context.Context, questionID int) (float64, error) {
+	rating, err := s.practiceStore.GetQuestionRating(ctx, questionID)
+	if errors.Is(err, ErrNotFound) {
+		return initialRating, nil
+	}
+
+	return rating, err
+}
+
+// updateRatings applies the Elo update to the user and the question after an answer,
+// a correct answer is a win of the user against the question.
+func updateRatings(userRating, questionRating float64, correct bool) (float64, float64) {
+	expected := 1 / (1 + math.Pow(10, (questionRating-userRating)/400))
+
+	score := 0.0
+	if correct {
+		score = 1
+	}
+
+	change := ratingKFactor * (score - expected)
+
+	return userRating + change, questionRating - change
+}
@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/practiceQuestionPickerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/practiceStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionGetterMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionOptionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var practiceNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+type PracticeMocks struct {
+	practiceStorer      *practiceStorerMock.MockPracticeStorer
+	questionPicker      *practiceQuestionPickerMock.MockPracticeQuestionPicker
+	questionGetter      *questionGetterMock.MockQuestionGetter
+	questionOptionStore *questionOptionStorerMock.MockQuestionOptionStorer
+}
+
+func initMockPracticeService(t *testing.T) (PracticeMocks, *service.PracticeService) {
+	ctrl := gomock.NewController(t)
+
+	mocks := PracticeMocks{
+		practiceStorer:      practiceStorerMock.NewMockPracticeStorer(ctrl),
+		questionPicker:      practiceQuestionPickerMock.NewMockPracticeQuestionPicker(ctrl),
+		questionGetter:      questionGetterMock.NewMockQuestionGetter(ctrl),
+		questionOptionStore: questionOptionStorerMock.NewMockQuestionOptionStorer(ctrl),
+	}
+
+	svc := service.NewPracticeService(mocks.practiceStorer, mocks.questionPicker,
+		mocks.questionGetter, mocks.questionOptionStore, func() time.Time { return practiceNow })
+
+	assert.NotEmpty(t, svc)
+
+	return mocks, svc
+}
+
+func TestPracticeService_CreatePracticeSession(t *testing.T) {
+	t.Run("Should create session with initial rating for a new user", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetUserRating(ctx, "user-1").Return(0.0, service.ErrNotFound),
+			mocks.practiceStorer.EXPECT().CreatePracticeSession(ctx, entity.PracticeSession{
+				UserID:    "user-1",
+				CreatedAt: practiceNow,
+			}).Return(7, nil),
+		)
+
+		res, err := svc.CreatePracticeSession(ctx, service.PracticeSessionCreationDTO{UserID: "user-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, service.PracticeSessionDTO{ID: 7, UserID: "user-1", Rating: 1500}, res)
+	})
+}
+
+func TestPracticeService_GetNextQuestion(t *testing.T) {
+	t.Run("Should pick unseen question closest to the user rating", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).
+				Return(entity.PracticeSession{ID: 7, UserID: "user-1"}, nil),
+			mocks.practiceStorer.EXPECT().GetUserRating(ctx, "user-1").Return(1620.0, nil),
+			mocks.questionPicker.EXPECT().GetNextPracticeQuestionID(ctx, 7, 1620.0, 1500.0).Return(3, nil),
+			mocks.practiceStorer.EXPECT().GetQuestionRating(ctx, 3).Return(1600.0, nil),
+			mocks.questionGetter.EXPECT().GetQuestionByID(ctx, 3).Return(service.QuestionDTO{ID: 3}, nil),
+		)
+
+		res, err := svc.GetNextQuestion(ctx, 7)
+		assert.Nil(t, err)
+		assert.Equal(t, service.PracticeQuestionDTO{
+			SessionID:      7,
+			UserRating:     1620,
+			QuestionRating: 1600,
+			Question:       service.QuestionDTO{ID: 3},
+		}, res)
+	})
+
+	t.Run("Should return not found when every question was answered", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).
+				Return(entity.PracticeSession{ID: 7, UserID: "user-1"}, nil),
+			mocks.practiceStorer.EXPECT().GetUserRating(ctx, "user-1").Return(1500.0, nil),
+			mocks.questionPicker.EXPECT().GetNextPracticeQuestionID(ctx, 7, 1500.0, 1500.0).
+				Return(0, service.ErrNotFound),
+		)
+
+		_, err := svc.GetNextQuestion(ctx, 7)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+// rateAnswer mocks the store updating the current ratings of the user and the question with the answer.
+func rateAnswer(userRating, questionRating float64) func(context.Context, string, entity.PracticeAnswer,
+	float64, service.RatingUpdate) (entity.PracticeAnswer, error) {
+	return func(_ context.Context, _ string, answer entity.PracticeAnswer,
+		_ float64, updateRatings service.RatingUpdate) (entity.PracticeAnswer, error) {
+		answer.ID = 1
+		answer.UserRating, answer.QuestionRating = updateRatings(userRating, questionRating)
+		return answer, nil
+	}
+}
+
+func TestPracticeService_SubmitAnswer(t *testing.T) {
+	returnOptions := []entity.QuestionOption{
+		{ID: 1, Body: "first-option", Correct: true, QuestionID: 3},
+		{ID: 2, Body: "second-option", Correct: false, QuestionID: 3},
+	}
+
+	t.Run("Should raise user rating and lower question rating on correct answer", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		expectedAnswer := entity.PracticeAnswer{
+			SessionID:  7,
+			QuestionID: 3,
+			Correct:    true,
+			AnsweredAt: practiceNow,
+		}
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).
+				Return(entity.PracticeSession{ID: 7, UserID: "user-1"}, nil),
+			mocks.practiceStorer.EXPECT().HasPracticeAnswer(ctx, 7, 3).Return(false, nil),
+			mocks.questionOptionStore.EXPECT().GetQuestionOptions(ctx, 3).Return(returnOptions, nil),
+			mocks.practiceStorer.EXPECT().CreatePracticeAnswer(ctx, "user-1", expectedAnswer, 1500.0, gomock.Any()).
+				DoAndReturn(rateAnswer(1500, 1500)),
+		)
+
+		res, err := svc.SubmitAnswer(ctx, 7, service.PracticeAnswerCreationDTO{QuestionID: 3, OptionIDs: []int{1}})
+		assert.Nil(t, err)
+		assert.Equal(t, service.PracticeAnswerDTO{
+			QuestionID:       3,
+			Correct:          true,
+			CorrectOptionIDs: []int{1},
+			UserRating:       1516,
+			QuestionRating:   1484,
+		}, res)
+	})
+
+	t.Run("Should lower user rating less when missing a harder question", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).
+				Return(entity.PracticeSession{ID: 7, UserID: "user-1"}, nil),
+			mocks.practiceStorer.EXPECT().HasPracticeAnswer(ctx, 7, 3).Return(false, nil),
+			mocks.questionOptionStore.EXPECT().GetQuestionOptions(ctx, 3).Return(returnOptions, nil),
+			mocks.practiceStorer.EXPECT().CreatePracticeAnswer(ctx, "user-1", gomock.Any(), 1500.0, gomock.Any()).
+				DoAndReturn(rateAnswer(1500, 1900)),
+		)
+
+		res, err := svc.SubmitAnswer(ctx, 7, service.PracticeAnswerCreationDTO{QuestionID: 3, OptionIDs: []int{2}})
+		assert.Nil(t, err)
+		assert.False(t, res.Correct)
+		assert.InDelta(t, 1497.09, res.UserRating, 0.01)
+		assert.InDelta(t, 1902.91, res.QuestionRating, 0.01)
+	})
+
+	t.Run("Should reject question already answered in the session", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		gomock.InOrder(
+			mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).
+				Return(entity.PracticeSession{ID: 7, UserID: "user-1"}, nil),
+			mocks.practiceStorer.EXPECT().HasPracticeAnswer(ctx, 7, 3).Return(true, nil),
+		)
+
+		_, err := svc.SubmitAnswer(ctx, 7, service.PracticeAnswerCreationDTO{QuestionID: 3, OptionIDs: []int{1}})
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+
+	t.Run("Should return not found for unknown session", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockPracticeService(t)
+
+		mocks.practiceStorer.EXPECT().GetPracticeSession(ctx, 7).Return(entity.PracticeSession{}, service.ErrNotFound)
+
+		_, err := svc.SubmitAnswer(ctx, 7, service.PracticeAnswerCreationDTO{QuestionID: 3, OptionIDs: []int{1}})
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
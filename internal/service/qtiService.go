@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/qti"
+)
+
+// QuestionGetter represents question service implementation for services which only read questions.
+type QuestionGetter interface {
+	GetQuestionByID(ctx context.Context, questionID int) (QuestionDTO, error)
+}
+
+// QuestionManager represents necessary question service implementation for qti service.
+type QuestionManager interface {
+	QuestionGetter
+	CreateQuestion(ctx context.Context, questionCreation QuestionCreationDTO) (QuestionDTO, error)
+}
+
+// QTIService contains business logic for exchanging questions as IMS QTI 2.1 packages.
+type QTIService struct {
+	questionService QuestionManager
+	transactor      Transactor
+}
+
+// Instantiates a new qti service struct with question service and transactor.
+func NewQTIService(questionService QuestionManager, transactor Transactor) *QTIService {
+	return &QTIService{
+		questionService: questionService,
+		transactor:      transactor,
+	}
+}
+
+// ExportQuestions handles the logic for packaging the questions with provided ids.
+func (s *QTIService) ExportQuestions(ctx context.Context, questionIDs []int) ([]byte, error) {
+	items := []qti.Item{}
+
+	for _, questionID := range questionIDs {
+		question, err := s.questionService.GetQuestionByID(ctx, questionID)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to export question %d: %w", questionID, err)
+		}
+
+		item := qti.Item{
+			Identifier: fmt.Sprintf("question-%d", question.ID),
+			Title:      fmt.Sprintf("Question %d", question.ID),
+			Prompt:     question.Body,
+		}
+
+		for _, option := range question.Options {
+			// Correctness is hidden from callers who may not see it, such items can't be exported.
+			if option.Correct == nil {
+				return nil, fmt.Errorf("%w: correctness of question %d is hidden", ErrForbidden, questionID)
+			}
+
+			item.Choices = append(item.Choices, qti.Choice{
+				Identifier: fmt.Sprintf("choice-%d", option.ID),
+				Text:       option.Body,
+				Correct:    *option.Correct,
+			})
+		}
+
+		items = append(items, item)
+	}
+
+	buf := bytes.Buffer{}
+
+	err := qti.WritePackage(&buf, items)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to export questions: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImportQuestions handles the logic for creating questions from choice items of a package.
+// Items are imported within a single transaction, none of them is kept when creating any of them fails.
+func (s *QTIService) ImportQuestions(ctx context.Context, data []byte) (QTIImportDTO, error) {
+	results, err := qti.ReadPackage(data)
+	if err != nil {
+		return QTIImportDTO{}, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+
+	imported := QTIImportDTO{
+		Imported: []QTIImportedItemDTO{},
+		Skipped:  []QTISkippedItemDTO{},
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.importItems(ctx, results, &imported)
+	})
+	if err != nil {
+		return QTIImportDTO{}, err
+	}
+
+	return imported, nil
+}
+
+// importItems creates questions from the valid items and records the skipped ones.
+func (s *QTIService) importItems(ctx context.Context, results []qti.ReadResult, imported *QTIImportDTO) error {
+	for _, result := range results {
+		if result.Err != nil {
+			imported.Skipped = append(imported.Skipped, QTISkippedItemDTO{
+				Identifier: result.Identifier,
+				Reason:     result.Err.Error(),
+			})
+			continue
+		}
+
+		questionCreation := QuestionCreationDTO{
+			Body:    result.Item.Prompt,
+			Options: []QuestionOptionCreationDTO{},
+		}
+
+		for _, choice := range result.Item.Choices {
+			questionCreation.Options = append(questionCreation.Options, QuestionOptionCreationDTO{
+				Body:    choice.Text,
+				Correct: choice.Correct,
+			})
+		}
+
+		err := helpers.ValidateStruct(questionCreation)
+		if err != nil {
+			imported.Skipped = append(imported.Skipped, QTISkippedItemDTO{
+				Identifier: result.Identifier,
+				Reason:     err.Error(),
+			})
+			continue
+		}
+
+		question, err := s.questionService.CreateQuestion(ctx, questionCreation)
+		if err != nil {
+			return fmt.Errorf("error trying to import item %s: %w", result.Identifier, err)
+		}
+
+		imported.Imported = append(imported.Imported, QTIImportedItemDTO{
+			Identifier: result.Identifier,
+			Question:   question,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/mock/questionManagerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func initMockQTIService(t *testing.T) (*questionManagerMock.MockQuestionManager, *fakeTransactor, *service.QTIService) {
+	ctrl := gomock.NewController(t)
+
+	questionManager := questionManagerMock.NewMockQuestionManager(ctrl)
+	transactor := &fakeTransactor{}
+
+	svc := service.NewQTIService(questionManager, transactor)
+
+	assert.NotEmpty(t, svc)
+
+	return questionManager, transactor, svc
+}
+
+func TestQTIService_ExportAndImportQuestions(t *testing.T) {
+	t.Run("Should import exported questions with correct options", func(t *testing.T) {
+		ctx := context.Background()
+		questionManager, _, svc := initMockQTIService(t)
+
+		storedQuestion := service.QuestionDTO{
+			ID:   1,
+			Body: "Is 2 < 3 & 3 > 2?",
+			Options: []service.QuestionOptionDTO{
+				{
+					ID:      1,
+					Body:    "yes",
+					Correct: boolPtr(true),
+				},
+				{
+					ID:      2,
+					Body:    "no",
+					Correct: boolPtr(false),
+				},
+			},
+		}
+
+		expectedCreation := service.QuestionCreationDTO{
+			Body: "Is 2 < 3 & 3 > 2?",
+			Options: []service.QuestionOptionCreationDTO{
+				{
+					Body:    "yes",
+					Correct: true,
+				},
+				{
+					Body:    "no",
+					Correct: false,
+				},
+			},
+		}
+
+		createdQuestion := storedQuestion
+		createdQuestion.ID = 2
+
+		gomock.InOrder(
+			questionManager.EXPECT().GetQuestionByID(ctx, 1).Return(storedQuestion, nil),
+			questionManager.EXPECT().CreateQuestion(ctx, expectedCreation).Return(createdQuestion, nil),
+		)
+
+		data, err := svc.ExportQuestions(ctx, []int{1})
+		assert.NoError(t, err)
+
+		res, err := svc.ImportQuestions(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, service.QTIImportDTO{
+			Imported: []service.QTIImportedItemDTO{
+				{
+					Identifier: "question-1",
+					Question:   createdQuestion,
+				},
+			},
+			Skipped: []service.QTISkippedItemDTO{},
+		}, res)
+	})
+
+	t.Run("Should fail to export because getting question fails", func(t *testing.T) {
+		ctx := context.Background()
+		questionManager, _, svc := initMockQTIService(t)
+
+		gomock.InOrder(
+			questionManager.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{}, service.ErrNotFound),
+		)
+
+		data, err := svc.ExportQuestions(ctx, []int{1})
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+
+	t.Run("Should fail to export because option correctness is hidden from the caller", func(t *testing.T) {
+		ctx := context.Background()
+		questionManager, _, svc := initMockQTIService(t)
+
+		hiddenQuestion := service.QuestionDTO{
+			ID:      1,
+			Body:    "first-question",
+			Options: []service.QuestionOptionDTO{{ID: 1, Body: "first-option"}},
+		}
+
+		gomock.InOrder(
+			questionManager.EXPECT().GetQuestionByID(ctx, 1).Return(hiddenQuestion, nil),
+		)
+
+		data, err := svc.ExportQuestions(ctx, []int{1})
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should fail to import because package is not a zip archive", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, svc := initMockQTIService(t)
+
+		res, err := svc.ImportQuestions(ctx, []byte("not-a-zip"))
+		assert.Equal(t, service.QTIImportDTO{}, res)
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+
+	t.Run("Should roll back the import because creating an item fails", func(t *testing.T) {
+		ctx := context.Background()
+		questionManager, transactor, svc := initMockQTIService(t)
+		someErr := errors.New("some-error")
+
+		storedQuestion := service.QuestionDTO{
+			ID:   1,
+			Body: "first-question",
+			Options: []service.QuestionOptionDTO{
+				{
+					ID:      1,
+					Body:    "first-option",
+					Correct: boolPtr(true),
+				},
+			},
+		}
+
+		gomock.InOrder(
+			questionManager.EXPECT().GetQuestionByID(ctx, 1).Return(storedQuestion, nil),
+			questionManager.EXPECT().GetQuestionByID(ctx, 2).Return(storedQuestion, nil),
+			questionManager.EXPECT().CreateQuestion(ctx, gomock.Any()).Return(storedQuestion, nil),
+			questionManager.EXPECT().CreateQuestion(ctx, gomock.Any()).Return(service.QuestionDTO{}, someErr),
+		)
+
+		data, err := svc.ExportQuestions(ctx, []int{1, 2})
+		assert.NoError(t, err)
+
+		res, err := svc.ImportQuestions(ctx, data)
+		assert.Equal(t, service.QTIImportDTO{}, res)
+		assert.True(t, errors.Is(err, someErr))
+		assert.True(t, transactor.rolledBack)
+	})
+}
@@ -4,15 +4,26 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/djurica-surla/backend-homework/internal/auth"
 	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/logging"
 )
 
+// Criteria for filtering questions, nil fields are not applied.
+type QuestionFilter struct {
+	MinDifficulty          *int
+	MaxDifficulty          *int
+	MinEmpiricalDifficulty *float64
+	MaxEmpiricalDifficulty *float64
+}
+
 // QuestionStorer represents necessary question storage implementation for question service.
 type QuestionStorer interface {
-	GetQuestions(ctx context.Context, pageSize, offset int) ([]entity.Question, error)
+	GetQuestions(ctx context.Context, filter QuestionFilter, pageSize, offset int) ([]entity.Question, error)
 	GetQuestionByID(ctx context.Context, questionID int) (entity.Question, error)
-	CreateQuestion(ctx context.Context, body string) (int, error)
-	UpdateQuestion(ctx context.Context, questionID int, body string) (int, error)
+	CreateQuestion(ctx context.Context, question QuestionCreationDTO, owner string) (int, error)
+	UpdateQuestion(ctx context.Context, questionID int, question QuestionCreationDTO) (int, error)
 	DeleteQuestion(ctx context.Context, questionID int) error
 }
 
@@ -24,23 +35,53 @@ type QuestionOptionStorer interface {
 	DeleteQuestionOptions(ctx context.Context, questionID int) error
 }
 
+// QuestionHintStorer represents necessary question hint storage implementation for question service.
+type QuestionHintStorer interface {
+	GetQuestionHints(ctx context.Context, questionID int) ([]entity.QuestionHint, error)
+	CreateQuestionHint(ctx context.Context, questionID, position int, body string) error
+	DeleteQuestionHints(ctx context.Context, questionID int) error
+}
+
+// ReviewScheduleDeleter represents necessary review schedule storage implementation for question service.
+type ReviewScheduleDeleter interface {
+	DeleteQuestionReviewSchedules(ctx context.Context, questionID int) error
+}
+
 // QuestionService contains business logic for working with question object.
+// Operations are checked against the policy for the caller in the context, writes require a caller
+// while reads without one are internal and unrestricted.
+// Writes run within a transaction, contents of deleted attachments are deleted once it commits.
 type QuestionService struct {
 	questionStore       QuestionStorer
 	questionOptionStore QuestionOptionStorer
+	attachmentStore     AttachmentStorer
+	blobStore           BlobStorer
+	questionHintStore   QuestionHintStorer
+	reviewStore         ReviewScheduleDeleter
+	transactor          Transactor
+	policy              QuestionPolicy
 }
 
 // Instantiates a new question service struct with question repo.
-func NewQuestionService(questionStore QuestionStorer, QuestionOptionStore QuestionOptionStorer) *QuestionService {
+func NewQuestionService(questionStore QuestionStorer, QuestionOptionStore QuestionOptionStorer,
+	attachmentStore AttachmentStorer, blobStore BlobStorer, questionHintStore QuestionHintStorer,
+	reviewStore ReviewScheduleDeleter, transactor Transactor, policy QuestionPolicy) *QuestionService {
 	return &QuestionService{
 		questionStore:       questionStore,
 		questionOptionStore: QuestionOptionStore,
+		attachmentStore:     attachmentStore,
+		blobStore:           blobStore,
+		questionHintStore:   questionHintStore,
+		reviewStore:         reviewStore,
+		transactor:          transactor,
+		policy:              policy,
 	}
 }
 
-// GetQuestions handles the logic for getting questions and its options.
-func (s *QuestionService) GetQuestions(ctx context.Context, pageSize, offset int) ([]QuestionDTO, error) {
-	questionsEntity, err := s.questionStore.GetQuestions(ctx, pageSize, offset)
+// GetQuestions handles the logic for getting questions matching the filter and its options.
+func (s *QuestionService) GetQuestions(ctx context.Context,
+	filter QuestionFilter, pageSize, offset int) ([]QuestionDTO, error) {
+	questionsEntity, err := s.questionStore.GetQuestions(ctx, filter, pageSize, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -48,26 +89,12 @@ func (s *QuestionService) GetQuestions(ctx context.Context, pageSize, offset int
 	questions := []QuestionDTO{}
 
 	for _, question := range questionsEntity {
-		questionOptionsEntity, err := s.questionOptionStore.GetQuestionOptions(ctx, question.ID)
+		questionDTO, err := s.toQuestionDTO(ctx, question)
 		if err != nil {
 			return nil, err
 		}
 
-		questionOptions := []QuestionOptionDTO{}
-
-		for _, questionOption := range questionOptionsEntity {
-			questionOptions = append(questionOptions, QuestionOptionDTO{
-				questionOption.ID,
-				questionOption.Body,
-				questionOption.Correct,
-			})
-		}
-
-		questions = append(questions, QuestionDTO{
-			ID:      question.ID,
-			Body:    question.Body,
-			Options: questionOptions,
-		})
+		questions = append(questions, questionDTO)
 	}
 
 	return questions, err
@@ -80,91 +107,361 @@ func (s *QuestionService) GetQuestionByID(ctx context.Context, questionID int) (
 		return QuestionDTO{}, err
 	}
 
-	questionOptionsEntity, err := s.questionOptionStore.GetQuestionOptions(ctx, questionEntity.ID)
+	return s.toQuestionDTO(ctx, questionEntity)
+}
+
+// CreateQuestion handles the logic for creating question and its options in database.
+func (s *QuestionService) CreateQuestion(ctx context.Context, questionCreation QuestionCreationDTO) (QuestionDTO, error) {
+	caller, err := requireCaller(ctx)
 	if err != nil {
 		return QuestionDTO{}, err
 	}
 
-	questionOptions := []QuestionOptionDTO{}
-
-	for _, questionOption := range questionOptionsEntity {
-		questionOptions = append(questionOptions, QuestionOptionDTO{
-			questionOption.ID,
-			questionOption.Body,
-			questionOption.Correct,
-		})
+	if !s.policy.CanCreate(caller) {
+		return QuestionDTO{}, fmt.Errorf("%w: %s can't create questions", ErrForbidden, caller.Role)
 	}
 
-	question := QuestionDTO{
-		ID:      questionEntity.ID,
-		Body:    questionEntity.Body,
-		Options: questionOptions,
-	}
+	questionDTO := QuestionDTO{}
 
-	return question, err
-}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		questionID, err := s.questionStore.CreateQuestion(ctx, questionCreation, caller.ID)
+		if err != nil {
+			return err
+		}
 
-// CreateQuestion handles the logic for creating question and its options in database.
-func (s *QuestionService) CreateQuestion(ctx context.Context, questionCreation QuestionCreationDTO) (QuestionDTO, error) {
-	questionID, err := s.questionStore.CreateQuestion(ctx, questionCreation.Body)
-	if err != nil {
-		return QuestionDTO{}, err
-	}
+		for _, option := range questionCreation.Options {
+			err := s.questionOptionStore.CreateQuestionOption(ctx, questionID, option)
+			if err != nil {
+				return err
+			}
+		}
 
-	for _, option := range questionCreation.Options {
-		err := s.questionOptionStore.CreateQuestionOption(ctx, questionID, option)
+		err = s.createQuestionHints(ctx, questionID, questionCreation.Hints)
 		if err != nil {
-			return QuestionDTO{}, err
+			return err
 		}
-	}
 
-	// Retrieve the new records.
-	questionDTO, err := s.GetQuestionByID(ctx, questionID)
+		// Retrieve the new records.
+		questionDTO, err = s.GetQuestionByID(ctx, questionID)
+		if err != nil {
+			return fmt.Errorf("error trying to update question: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return QuestionDTO{}, fmt.Errorf("error trying to update question: %w", err)
+		return QuestionDTO{}, err
 	}
 
 	return questionDTO, nil
 }
 
 // UpdateQuestion handles the logic for updating question and its options in database.
+// Options are replaced by new ones with new ids, so attachments of the previous options are deleted with them
+// and per option statistics start over, statistics of the question itself are kept.
 func (s *QuestionService) UpdateQuestion(ctx context.Context,
 	questionID int, questionCreation QuestionCreationDTO) (QuestionDTO, error) {
-	// Update the question record first
-	rowsAffected, err := s.questionStore.UpdateQuestion(ctx, questionID, questionCreation.Body)
+	questionDTO := QuestionDTO{}
+	storageKeys := []string{}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		questionDTO, storageKeys, err = s.updateQuestion(ctx, questionID, questionCreation)
+		return err
+	})
 	if err != nil {
 		return QuestionDTO{}, err
 	}
 
+	s.deleteAttachmentContents(ctx, storageKeys)
+
+	return questionDTO, nil
+}
+
+// updateQuestion updates the question within the transaction of the context
+// and returns storage keys of the deleted option attachments.
+func (s *QuestionService) updateQuestion(ctx context.Context,
+	questionID int, questionCreation QuestionCreationDTO) (QuestionDTO, []string, error) {
+	err := s.authorizeModification(ctx, questionID)
+	if err != nil {
+		return QuestionDTO{}, nil, err
+	}
+
+	// Update the question record first
+	rowsAffected, err := s.questionStore.UpdateQuestion(ctx, questionID, questionCreation)
+	if err != nil {
+		return QuestionDTO{}, nil, err
+	}
+
 	// If rows affected are zero, return empty dto meaning nothing was updated
 	if rowsAffected == 0 {
-		return QuestionDTO{}, err
+		return QuestionDTO{}, nil, err
+	}
+
+	// Delete the previous options and their attachments since we are replacing them.
+	storageKeys, err := s.deleteAttachments(ctx, questionID, true)
+	if err != nil {
+		return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
 	}
 
-	// Delete the previous options since we are replacing them.
 	err = s.questionOptionStore.DeleteQuestionOptions(ctx, questionID)
 	if err != nil {
-		return QuestionDTO{}, fmt.Errorf("error trying to update question: %w", err)
+		return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
 	}
 
 	for _, option := range questionCreation.Options {
 		// Insert new options into the database.
 		err = s.questionOptionStore.CreateQuestionOption(ctx, questionID, option)
 		if err != nil {
-			return QuestionDTO{}, fmt.Errorf("error trying to update question: %w", err)
+			return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
 		}
 	}
 
+	// Hints are replaced the same way as options.
+	err = s.questionHintStore.DeleteQuestionHints(ctx, questionID)
+	if err != nil {
+		return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
+	}
+
+	err = s.createQuestionHints(ctx, questionID, questionCreation.Hints)
+	if err != nil {
+		return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
+	}
+
 	// Retrieve the new records.
 	questionDTO, err := s.GetQuestionByID(ctx, questionID)
 	if err != nil {
-		return QuestionDTO{}, fmt.Errorf("error trying to update question: %w", err)
+		return QuestionDTO{}, nil, fmt.Errorf("error trying to update question: %w", err)
 	}
 
-	return questionDTO, nil
+	return questionDTO, storageKeys, nil
 }
 
-// DeleteQuestion handles the logic for deleting question and its options in database.
+// DeleteQuestion handles the logic for deleting question, its options, hints, attachments
+// and review schedules in database.
 func (s *QuestionService) DeleteQuestion(ctx context.Context, questionID int) error {
-	return s.questionStore.DeleteQuestion(ctx, questionID)
+	storageKeys := []string{}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.authorizeModification(ctx, questionID)
+		if err != nil {
+			return err
+		}
+
+		storageKeys, err = s.deleteAttachments(ctx, questionID, false)
+		if err != nil {
+			return err
+		}
+
+		err = s.questionOptionStore.DeleteQuestionOptions(ctx, questionID)
+		if err != nil {
+			return err
+		}
+
+		err = s.questionHintStore.DeleteQuestionHints(ctx, questionID)
+		if err != nil {
+			return err
+		}
+
+		err = s.reviewStore.DeleteQuestionReviewSchedules(ctx, questionID)
+		if err != nil {
+			return err
+		}
+
+		return s.questionStore.DeleteQuestion(ctx, questionID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteAttachmentContents(ctx, storageKeys)
+
+	return nil
+}
+
+// GetQuestionExplanation handles the logic for getting explanation of question and its options.
+// Takers can't read it before answering, they get it with the graded response to the question.
+func (s *QuestionService) GetQuestionExplanation(ctx context.Context, questionID int) (QuestionExplanationDTO, error) {
+	// Explanations reveal which options are correct.
+	if !s.canSeeCorrectness(ctx) {
+		return QuestionExplanationDTO{}, fmt.Errorf("%w: explanations reveal option correctness", ErrForbidden)
+	}
+
+	questionEntity, err := s.questionStore.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return QuestionExplanationDTO{}, err
+	}
+
+	questionOptionsEntity, err := s.questionOptionStore.GetQuestionOptions(ctx, questionID)
+	if err != nil {
+		return QuestionExplanationDTO{}, err
+	}
+
+	return newQuestionExplanationDTO(questionEntity, questionOptionsEntity), nil
+}
+
+// newQuestionExplanationDTO converts question and its options into an explanation dto.
+func newQuestionExplanationDTO(question entity.Question, options []entity.QuestionOption) QuestionExplanationDTO {
+	explanation := QuestionExplanationDTO{
+		QuestionID:  question.ID,
+		Explanation: question.Explanation,
+		Options:     []QuestionOptionExplanationDTO{},
+	}
+
+	for _, option := range options {
+		explanation.Options = append(explanation.Options, QuestionOptionExplanationDTO{
+			ID:          option.ID,
+			Body:        option.Body,
+			Correct:     option.Correct,
+			Explanation: option.Explanation,
+		})
+	}
+
+	return explanation
+}
+
+// GetQuestionHint handles the logic for getting n-th hint of question, counting from 1.
+func (s *QuestionService) GetQuestionHint(ctx context.Context, questionID, number int) (QuestionHintDTO, error) {
+	_, err := s.questionStore.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return QuestionHintDTO{}, err
+	}
+
+	questionHints, err := s.questionHintStore.GetQuestionHints(ctx, questionID)
+	if err != nil {
+		return QuestionHintDTO{}, err
+	}
+
+	if number < 1 || number > len(questionHints) {
+		return QuestionHintDTO{}, fmt.Errorf("hint %d of question %d %w", number, questionID, ErrNotFound)
+	}
+
+	return QuestionHintDTO{
+		QuestionID: questionID,
+		Number:     number,
+		Total:      len(questionHints),
+		Body:       questionHints[number-1].Body,
+	}, nil
+}
+
+// createQuestionHints stores hints of the question in the provided order.
+func (s *QuestionService) createQuestionHints(ctx context.Context, questionID int, hints []string) error {
+	for i, hint := range hints {
+		err := s.questionHintStore.CreateQuestionHint(ctx, questionID, i+1, hint)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAttachments deletes attachments of the question and of its options, or only those of its options,
+// and returns storage keys of their contents.
+func (s *QuestionService) deleteAttachments(ctx context.Context, questionID int, optionsOnly bool) ([]string, error) {
+	attachments, err := s.attachmentStore.GetAttachmentsByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	storageKeys := []string{}
+
+	for _, attachment := range attachments {
+		if optionsOnly && attachment.QuestionOptionID == 0 {
+			continue
+		}
+
+		err := s.attachmentStore.DeleteAttachment(ctx, attachment.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		storageKeys = append(storageKeys, attachment.StorageKey)
+	}
+
+	return storageKeys, nil
+}
+
+// deleteAttachmentContents deletes contents of deleted attachments, their metadata is already gone
+// so failures only leave unreferenced content behind and are logged.
+func (s *QuestionService) deleteAttachmentContents(ctx context.Context, storageKeys []string) {
+	for _, storageKey := range storageKeys {
+		err := s.blobStore.Delete(ctx, storageKey)
+		if err != nil {
+			logging.FromContext(ctx).Error("error deleting content of deleted attachment",
+				"storage_key", storageKey, "error", err)
+		}
+	}
+}
+
+// authorizeModification checks the caller may update or delete the question.
+func (s *QuestionService) authorizeModification(ctx context.Context, questionID int) error {
+	return authorizeModification(ctx, s.questionStore, s.policy, questionID)
+}
+
+// canSeeCorrectness reports whether the caller in the context may see option correctness,
+// a context without a caller is treated as a taker and may not.
+func (s *QuestionService) canSeeCorrectness(ctx context.Context) bool {
+	caller, ok := auth.CallerFromContext(ctx)
+	return ok && s.policy.CanSeeCorrectness(caller)
+}
+
+// toQuestionDTO retrieves options and attachments of the question and converts it into a response dto.
+func (s *QuestionService) toQuestionDTO(ctx context.Context, question entity.Question) (QuestionDTO, error) {
+	questionOptionsEntity, err := s.questionOptionStore.GetQuestionOptions(ctx, question.ID)
+	if err != nil {
+		return QuestionDTO{}, err
+	}
+
+	attachmentsEntity, err := s.attachmentStore.GetAttachmentsByQuestionID(ctx, question.ID)
+	if err != nil {
+		return QuestionDTO{}, err
+	}
+
+	bodyHTML, err := helpers.RenderMarkdown(question.Body)
+	if err != nil {
+		return QuestionDTO{}, err
+	}
+
+	questionDTO := QuestionDTO{
+		ID:                  question.ID,
+		Body:                question.Body,
+		BodyHTML:            bodyHTML,
+		Difficulty:          question.Difficulty,
+		EmpiricalDifficulty: question.EmpiricalDifficulty,
+		ResponseCount:       question.ResponseCount,
+		Owner:               question.Owner,
+		Options:             []QuestionOptionDTO{},
+	}
+
+	optionAttachments := map[int][]AttachmentDTO{}
+
+	for _, attachment := range attachmentsEntity {
+		if attachment.QuestionOptionID != 0 {
+			optionAttachments[attachment.QuestionOptionID] = append(
+				optionAttachments[attachment.QuestionOptionID], newAttachmentDTO(attachment))
+			continue
+		}
+
+		questionDTO.Attachments = append(questionDTO.Attachments, newAttachmentDTO(attachment))
+	}
+
+	showCorrect := s.canSeeCorrectness(ctx)
+
+	for _, questionOption := range questionOptionsEntity {
+		optionDTO := QuestionOptionDTO{
+			ID:          questionOption.ID,
+			Body:        questionOption.Body,
+			Attachments: optionAttachments[questionOption.ID],
+		}
+
+		if showCorrect {
+			correct := questionOption.Correct
+			optionDTO.Correct = &correct
+		}
+
+		questionDTO.Options = append(questionDTO.Options, optionDTO)
+	}
+
+	return questionDTO, nil
 }
@@ -5,9 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/djurica-surla/backend-homework/internal/auth"
 	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/attachmentStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/blobStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionHintStorerMock"
 	"github.com/djurica-surla/backend-homework/internal/mock/questionOptionStorerMock"
 	"github.com/djurica-surla/backend-homework/internal/mock/questionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/reviewScheduleDeleterMock"
 	"github.com/djurica-surla/backend-homework/internal/service"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -17,12 +22,43 @@ import (
 type Mocks struct {
 	questionStorer       *questionStorerMock.MockQuestionStorer
 	questionOptionStorer *questionOptionStorerMock.MockQuestionOptionStorer
+	attachmentStorer     *attachmentStorerMock.MockAttachmentStorer
+	blobStorer           *blobStorerMock.MockBlobStorer
+	questionHintStorer   *questionHintStorerMock.MockQuestionHintStorer
+	reviewStorer         *reviewScheduleDeleterMock.MockReviewScheduleDeleter
+}
+
+// Caller which authors the questions of write tests and the question it owns.
+var (
+	author        = auth.Caller{ID: "author-1", Role: auth.RoleAuthor}
+	ownedQuestion = entity.Question{ID: 1, Body: "first-question", Owner: "author-1"}
+)
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+// fakeTransactor runs functions within the provided context, as the stores are mocked,
+// and records whether the last one was rolled back.
+type fakeTransactor struct {
+	rolledBack bool
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	f.rolledBack = err != nil
+
+	return err
 }
 
 func createMocks(ctrl *gomock.Controller) Mocks {
 	return Mocks{
 		questionStorer:       questionStorerMock.NewMockQuestionStorer(ctrl),
 		questionOptionStorer: questionOptionStorerMock.NewMockQuestionOptionStorer(ctrl),
+		attachmentStorer:     attachmentStorerMock.NewMockAttachmentStorer(ctrl),
+		blobStorer:           blobStorerMock.NewMockBlobStorer(ctrl),
+		questionHintStorer:   questionHintStorerMock.NewMockQuestionHintStorer(ctrl),
+		reviewStorer:         reviewScheduleDeleterMock.NewMockReviewScheduleDeleter(ctrl),
 	}
 }
 
@@ -31,7 +67,9 @@ func initMockService(t *testing.T) (Mocks, *service.QuestionService) {
 
 	mocks := createMocks(ctrl)
 
-	svc := service.NewQuestionService(mocks.questionStorer, mocks.questionOptionStorer)
+	svc := service.NewQuestionService(mocks.questionStorer, mocks.questionOptionStorer,
+		mocks.attachmentStorer, mocks.blobStorer, mocks.questionHintStorer, mocks.reviewStorer,
+		&fakeTransactor{}, service.NewRolePolicy())
 
 	assert.NotEmpty(t, svc)
 
@@ -40,7 +78,7 @@ func initMockService(t *testing.T) (Mocks, *service.QuestionService) {
 
 func TestService_GetQuestions(t *testing.T) {
 	t.Run("Should retrieve questions successfuly", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		pageSize := 10
 		offset := 0
 		mocks, svc := initMockService(t)
@@ -74,77 +112,81 @@ func TestService_GetQuestions(t *testing.T) {
 
 		expectedResult := []service.QuestionDTO{
 			{
-				ID:   1,
-				Body: "first-question",
+				ID:       1,
+				Body:     "first-question",
+				BodyHTML: "<p>first-question</p>\n",
 				Options: []service.QuestionOptionDTO{
 					{
 						ID:      1,
 						Body:    "first-option",
-						Correct: false,
+						Correct: boolPtr(false),
 					},
 					{
 						ID:      2,
 						Body:    "second-option",
-						Correct: false,
+						Correct: boolPtr(false),
 					},
 					{
 						ID:      3,
 						Body:    "third-option",
-						Correct: true,
+						Correct: boolPtr(true),
 					},
 				},
 			},
 			{
-				ID:   2,
-				Body: "second-question",
+				ID:       2,
+				Body:     "second-question",
+				BodyHTML: "<p>second-question</p>\n",
 				Options: []service.QuestionOptionDTO{
 					{
 						ID:      1,
 						Body:    "first-option",
-						Correct: false,
+						Correct: boolPtr(false),
 					},
 					{
 						ID:      2,
 						Body:    "second-option",
-						Correct: false,
+						Correct: boolPtr(false),
 					},
 					{
 						ID:      3,
 						Body:    "third-option",
-						Correct: true,
+						Correct: boolPtr(true),
 					},
 				},
 			},
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().GetQuestions(ctx, pageSize, offset).Return(returnQuestions, nil),
+			mocks.questionStorer.EXPECT().GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset).Return(returnQuestions, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(returnQuestionOptions, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 2).Return(returnQuestionOptions, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 2).Return(nil, nil),
 		)
 
-		questions, err := svc.GetQuestions(ctx, pageSize, offset)
+		questions, err := svc.GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset)
 		assert.EqualValues(t, expectedResult, questions)
 		assert.NoError(t, err)
 	})
 	t.Run("Should fail because getting questions from database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		pageSize := 10
 		offset := 0
 		mocks, svc := initMockService(t)
 		someErr := errors.New("some-error")
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().GetQuestions(ctx, pageSize, offset).Return(nil, someErr),
+			mocks.questionStorer.EXPECT().GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset).Return(nil, someErr),
 		)
 
-		questions, err := svc.GetQuestions(ctx, pageSize, offset)
+		questions, err := svc.GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset)
 		assert.Nil(t, questions)
 		assert.Error(t, err)
 	})
 
 	t.Run("Should fail because getting question options from database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		pageSize := 10
 		offset := 0
 		mocks, svc := initMockService(t)
@@ -158,11 +200,11 @@ func TestService_GetQuestions(t *testing.T) {
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().GetQuestions(ctx, pageSize, offset).Return(returnQuestions, nil),
+			mocks.questionStorer.EXPECT().GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset).Return(returnQuestions, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(nil, someErr),
 		)
 
-		questions, err := svc.GetQuestions(ctx, pageSize, offset)
+		questions, err := svc.GetQuestions(ctx, service.QuestionFilter{}, pageSize, offset)
 		assert.Nil(t, questions)
 		assert.Error(t, err)
 	})
@@ -170,7 +212,7 @@ func TestService_GetQuestions(t *testing.T) {
 
 func TestService_GetQuestionByID(t *testing.T) {
 	t.Run("Should retrieve question successfuly", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		mocks, svc := initMockService(t)
 
 		returnQuestion := entity.Question{
@@ -197,23 +239,24 @@ func TestService_GetQuestionByID(t *testing.T) {
 		}
 
 		expectedResult := service.QuestionDTO{
-			ID:   1,
-			Body: "first-question",
+			ID:       1,
+			Body:     "first-question",
+			BodyHTML: "<p>first-question</p>\n",
 			Options: []service.QuestionOptionDTO{
 				{
 					ID:      1,
 					Body:    "first-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 				{
 					ID:      2,
 					Body:    "second-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 				{
 					ID:      3,
 					Body:    "third-option",
-					Correct: true,
+					Correct: boolPtr(true),
 				},
 			},
 		}
@@ -221,6 +264,7 @@ func TestService_GetQuestionByID(t *testing.T) {
 		gomock.InOrder(
 			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(returnQuestion, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(returnQuestionOptions, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 		)
 
 		questions, err := svc.GetQuestionByID(ctx, 1)
@@ -228,8 +272,108 @@ func TestService_GetQuestionByID(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Should retrieve question with question and option attachments", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		returnQuestion := entity.Question{
+			ID:   1,
+			Body: "first-question",
+		}
+
+		returnQuestionOptions := []entity.QuestionOption{
+			{
+				ID:      1,
+				Body:    "first-option",
+				Correct: true,
+			},
+		}
+
+		returnAttachments := []entity.Attachment{
+			{
+				ID:          1,
+				QuestionID:  1,
+				Filename:    "question.png",
+				ContentType: "image/png",
+				Size:        10,
+			},
+			{
+				ID:               2,
+				QuestionOptionID: 1,
+				Filename:         "option.png",
+				ContentType:      "image/png",
+				Size:             20,
+			},
+		}
+
+		expectedResult := service.QuestionDTO{
+			ID:       1,
+			Body:     "first-question",
+			BodyHTML: "<p>first-question</p>\n",
+			Options: []service.QuestionOptionDTO{
+				{
+					ID:      1,
+					Body:    "first-option",
+					Correct: boolPtr(true),
+					Attachments: []service.AttachmentDTO{
+						{
+							ID:          2,
+							URL:         "/attachments/2",
+							Filename:    "option.png",
+							ContentType: "image/png",
+							Size:        20,
+						},
+					},
+				},
+			},
+			Attachments: []service.AttachmentDTO{
+				{
+					ID:          1,
+					URL:         "/attachments/1",
+					Filename:    "question.png",
+					ContentType: "image/png",
+					Size:        10,
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(returnQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(returnQuestionOptions, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(returnAttachments, nil),
+		)
+
+		question, err := svc.GetQuestionByID(ctx, 1)
+		assert.EqualValues(t, expectedResult, question)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should render markdown body without scripts and event handlers", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		returnQuestion := entity.Question{
+			ID:   1,
+			Body: "**bold** <script>alert(1)</script><img src=x onerror=alert(1)> [link](javascript:alert(1))",
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(returnQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(nil, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+		)
+
+		question, err := svc.GetQuestionByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, returnQuestion.Body, question.Body)
+		assert.Contains(t, question.BodyHTML, "<strong>bold</strong>")
+		assert.NotContains(t, question.BodyHTML, "<script")
+		assert.NotContains(t, question.BodyHTML, "onerror")
+		assert.NotContains(t, question.BodyHTML, "javascript:")
+	})
+
 	t.Run("Should fail because getting question from database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 		someErr := errors.New("some-error")
@@ -244,7 +388,7 @@ func TestService_GetQuestionByID(t *testing.T) {
 	})
 
 	t.Run("Should fail because getting question options from database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 		someErr := errors.New("some-error")
@@ -267,7 +411,7 @@ func TestService_GetQuestionByID(t *testing.T) {
 
 func TestService_CreateQuestion(t *testing.T) {
 	t.Run("Should create question successfuly", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		mocks, svc := initMockService(t)
 
 		questionCreationDTO := service.QuestionCreationDTO{
@@ -311,28 +455,30 @@ func TestService_CreateQuestion(t *testing.T) {
 		}
 
 		expectedResult := service.QuestionDTO{
-			ID:   1,
-			Body: "first-question",
+			ID:       1,
+			Body:     "first-question",
+			BodyHTML: "<p>first-question</p>\n",
 			Options: []service.QuestionOptionDTO{
 				{
 					ID:      1,
 					Body:    "first-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 				{
 					ID:      2,
 					Body:    "second-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 			},
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO.Body).Return(1, nil),
+			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO, "author-1").Return(1, nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO1).Return(nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO2).Return(nil),
 			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(storedQuestion, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(storedQuestionOption, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 		)
 
 		question, err := svc.CreateQuestion(ctx, questionCreationDTO)
@@ -341,13 +487,13 @@ func TestService_CreateQuestion(t *testing.T) {
 	})
 
 	t.Run("Should fail because creating question in the database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 		someErr := errors.New("some-error")
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().CreateQuestion(ctx, "").Return(0, someErr),
+			mocks.questionStorer.EXPECT().CreateQuestion(ctx, service.QuestionCreationDTO{}, "author-1").Return(0, someErr),
 		)
 
 		question, err := svc.CreateQuestion(ctx, service.QuestionCreationDTO{})
@@ -356,7 +502,7 @@ func TestService_CreateQuestion(t *testing.T) {
 	})
 
 	t.Run("Should fail because creating question options in the database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 		someErr := errors.New("some-error")
@@ -377,7 +523,7 @@ func TestService_CreateQuestion(t *testing.T) {
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO.Body).Return(1, nil),
+			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO, "author-1").Return(1, nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO1).Return(someErr),
 		)
 
@@ -389,7 +535,7 @@ func TestService_CreateQuestion(t *testing.T) {
 
 func TestService_UpdateQuestion(t *testing.T) {
 	t.Run("Should update question successfuly", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		mocks, svc := initMockService(t)
 
 		questionCreationDTO := service.QuestionCreationDTO{
@@ -433,29 +579,34 @@ func TestService_UpdateQuestion(t *testing.T) {
 		}
 
 		expectedResult := service.QuestionDTO{
-			ID:   1,
-			Body: "first-question",
+			ID:       1,
+			Body:     "first-question",
+			BodyHTML: "<p>first-question</p>\n",
 			Options: []service.QuestionOptionDTO{
 				{
 					ID:      1,
 					Body:    "first-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 				{
 					ID:      2,
 					Body:    "second-option",
-					Correct: false,
+					Correct: boolPtr(false),
 				},
 			},
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO.Body).Return(1, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO).Return(1, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO1).Return(nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO2).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
 			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(storedQuestion, nil),
 			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(storedQuestionOption, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 		)
 
 		question, err := svc.UpdateQuestion(ctx, 1, questionCreationDTO)
@@ -464,7 +615,7 @@ func TestService_UpdateQuestion(t *testing.T) {
 	})
 
 	t.Run("Should return empty dto response because update affects no rows", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 
@@ -483,7 +634,8 @@ func TestService_UpdateQuestion(t *testing.T) {
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO.Body).Return(0, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO).Return(0, nil),
 		)
 
 		question, err := svc.UpdateQuestion(ctx, 1, questionCreationDTO)
@@ -492,7 +644,7 @@ func TestService_UpdateQuestion(t *testing.T) {
 	})
 
 	t.Run("Should return error because deleting previous option fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 
@@ -513,7 +665,9 @@ func TestService_UpdateQuestion(t *testing.T) {
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO.Body).Return(1, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO).Return(1, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(someErr),
 		)
 
@@ -523,7 +677,7 @@ func TestService_UpdateQuestion(t *testing.T) {
 	})
 
 	t.Run("Should return error because creating new options fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 
 		mocks, svc := initMockService(t)
 
@@ -544,7 +698,9 @@ func TestService_UpdateQuestion(t *testing.T) {
 		}
 
 		gomock.InOrder(
-			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO.Body).Return(1, nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO).Return(1, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
 			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
 			mocks.questionOptionStorer.EXPECT().CreateQuestionOption(ctx, 1, optionCreationDTO1).Return(someErr),
 		)
@@ -553,14 +709,48 @@ func TestService_UpdateQuestion(t *testing.T) {
 		assert.Equal(t, service.QuestionDTO{}, question)
 		assert.Error(t, err)
 	})
+
+	t.Run("Should delete attachments of replaced options with their contents", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		questionCreationDTO := service.QuestionCreationDTO{Body: "first-question"}
+
+		returnAttachments := []entity.Attachment{
+			{ID: 1, QuestionID: 1, StorageKey: "question-key"},
+			{ID: 2, QuestionOptionID: 1, StorageKey: "option-key"},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionStorer.EXPECT().UpdateQuestion(ctx, 1, questionCreationDTO).Return(1, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(returnAttachments, nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 2).Return(nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(nil, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(returnAttachments[:1], nil),
+			mocks.blobStorer.EXPECT().Delete(ctx, "option-key").Return(nil),
+		)
+
+		question, err := svc.UpdateQuestion(ctx, 1, questionCreationDTO)
+		assert.NoError(t, err)
+		assert.Len(t, question.Attachments, 1)
+	})
 }
 
 func TestService_DeleteQuestion(t *testing.T) {
 	t.Run("Should delete question successfuly", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
 		mocks, svc := initMockService(t)
 
 		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.reviewStorer.EXPECT().DeleteQuestionReviewSchedules(ctx, 1).Return(nil),
 			mocks.questionStorer.EXPECT().DeleteQuestion(ctx, 1).Return(nil),
 		)
 
@@ -569,16 +759,323 @@ func TestService_DeleteQuestion(t *testing.T) {
 	})
 
 	t.Run("Should fail to delete question when database fails", func(t *testing.T) {
-		ctx := context.Background()
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		someErr := errors.New("some-error")
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.reviewStorer.EXPECT().DeleteQuestionReviewSchedules(ctx, 1).Return(nil),
+			mocks.questionStorer.EXPECT().DeleteQuestion(ctx, 1).Return(someErr),
+		)
+
+		err := svc.DeleteQuestion(ctx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("Should delete attachments of question and its options with their contents", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		returnAttachments := []entity.Attachment{
+			{ID: 1, QuestionID: 1, StorageKey: "question-key"},
+			{ID: 2, QuestionOptionID: 1, StorageKey: "option-key"},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(returnAttachments, nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 1).Return(nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 2).Return(nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.reviewStorer.EXPECT().DeleteQuestionReviewSchedules(ctx, 1).Return(nil),
+			mocks.questionStorer.EXPECT().DeleteQuestion(ctx, 1).Return(nil),
+			mocks.blobStorer.EXPECT().Delete(ctx, "question-key").Return(nil),
+			mocks.blobStorer.EXPECT().Delete(ctx, "option-key").Return(errors.New("some-error")),
+		)
+
+		err := svc.DeleteQuestion(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should keep attachment contents because deleting question fails", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
 		mocks, svc := initMockService(t)
 
 		someErr := errors.New("some-error")
 
 		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(
+				[]entity.Attachment{{ID: 1, QuestionID: 1, StorageKey: "question-key"}}, nil),
+			mocks.attachmentStorer.EXPECT().DeleteAttachment(ctx, 1).Return(nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.reviewStorer.EXPECT().DeleteQuestionReviewSchedules(ctx, 1).Return(nil),
 			mocks.questionStorer.EXPECT().DeleteQuestion(ctx, 1).Return(someErr),
 		)
 
 		err := svc.DeleteQuestion(ctx, 1)
+		assert.True(t, errors.Is(err, someErr))
+	})
+}
+
+func TestService_CreateQuestionWithHints(t *testing.T) {
+	t.Run("Should create question hints in order", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		questionCreationDTO := service.QuestionCreationDTO{
+			Body:        "first-question",
+			Explanation: "first-explanation",
+			Hints:       []string{"first-hint", "second-hint"},
+		}
+
+		storedQuestion := entity.Question{
+			ID:          1,
+			Body:        "first-question",
+			Explanation: "first-explanation",
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO, "author-1").Return(1, nil),
+			mocks.questionHintStorer.EXPECT().CreateQuestionHint(ctx, 1, 1, "first-hint").Return(nil),
+			mocks.questionHintStorer.EXPECT().CreateQuestionHint(ctx, 1, 2, "second-hint").Return(nil),
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(storedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(nil, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+		)
+
+		question, err := svc.CreateQuestion(ctx, questionCreationDTO)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, question.ID)
+	})
+
+	t.Run("Should fail because creating question hint fails", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+		someErr := errors.New("some-error")
+
+		questionCreationDTO := service.QuestionCreationDTO{
+			Body:  "first-question",
+			Hints: []string{"first-hint"},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().CreateQuestion(ctx, questionCreationDTO, "author-1").Return(1, nil),
+			mocks.questionHintStorer.EXPECT().CreateQuestionHint(ctx, 1, 1, "first-hint").Return(someErr),
+		)
+
+		question, err := svc.CreateQuestion(ctx, questionCreationDTO)
+		assert.Equal(t, service.QuestionDTO{}, question)
 		assert.Error(t, err)
 	})
 }
+
+func TestService_GetQuestionExplanation(t *testing.T) {
+	t.Run("Should retrieve question explanation successfuly", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		returnQuestion := entity.Question{
+			ID:          1,
+			Body:        "first-question",
+			Explanation: "first-explanation",
+		}
+
+		returnQuestionOptions := []entity.QuestionOption{
+			{
+				ID:          1,
+				Body:        "first-option",
+				Correct:     true,
+				Explanation: "first-option-explanation",
+			},
+		}
+
+		expectedResult := service.QuestionExplanationDTO{
+			QuestionID:  1,
+			Explanation: "first-explanation",
+			Options: []service.QuestionOptionExplanationDTO{
+				{
+					ID:          1,
+					Body:        "first-option",
+					Correct:     true,
+					Explanation: "first-option-explanation",
+				},
+			},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(returnQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(returnQuestionOptions, nil),
+		)
+
+		explanation, err := svc.GetQuestionExplanation(ctx, 1)
+		assert.EqualValues(t, expectedResult, explanation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail because question doesn't exist", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		mocks, svc := initMockService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{}, service.ErrNotFound),
+		)
+
+		explanation, err := svc.GetQuestionExplanation(ctx, 1)
+		assert.Equal(t, service.QuestionExplanationDTO{}, explanation)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+func TestService_GetQuestionHint(t *testing.T) {
+	returnHints := []entity.QuestionHint{
+		{
+			ID:         1,
+			QuestionID: 1,
+			Position:   1,
+			Body:       "first-hint",
+		},
+		{
+			ID:         2,
+			QuestionID: 1,
+			Position:   2,
+			Body:       "second-hint",
+		},
+	}
+
+	t.Run("Should retrieve second hint successfuly", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			mocks.questionHintStorer.EXPECT().GetQuestionHints(ctx, 1).Return(returnHints, nil),
+		)
+
+		hint, err := svc.GetQuestionHint(ctx, 1, 2)
+		assert.Equal(t, service.QuestionHintDTO{
+			QuestionID: 1,
+			Number:     2,
+			Total:      2,
+			Body:       "second-hint",
+		}, hint)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail because hint number is out of range", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			mocks.questionHintStorer.EXPECT().GetQuestionHints(ctx, 1).Return(returnHints, nil),
+		)
+
+		hint, err := svc.GetQuestionHint(ctx, 1, 3)
+		assert.Equal(t, service.QuestionHintDTO{}, hint)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+func TestService_QuestionAccessControl(t *testing.T) {
+	t.Run("Should hide option correctness from takers", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "user:1", Role: auth.RoleTaker})
+		mocks, svc := initMockService(t)
+
+		storedQuestionOption := []entity.QuestionOption{
+			{ID: 1, Body: "first-option", Correct: true},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(storedQuestionOption, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+		)
+
+		question, err := svc.GetQuestionByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []service.QuestionOptionDTO{{ID: 1, Body: "first-option"}}, question.Options)
+	})
+
+	t.Run("Should hide option correctness without a caller", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockService(t)
+
+		storedQuestionOption := []entity.QuestionOption{
+			{ID: 1, Body: "first-option", Correct: true},
+		}
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(storedQuestionOption, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+		)
+
+		question, err := svc.GetQuestionByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []service.QuestionOptionDTO{{ID: 1, Body: "first-option"}}, question.Options)
+	})
+
+	t.Run("Should forbid takers from reading explanations", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "user:1", Role: auth.RoleTaker})
+		_, svc := initMockService(t)
+
+		explanation, err := svc.GetQuestionExplanation(ctx, 1)
+		assert.Equal(t, service.QuestionExplanationDTO{}, explanation)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should forbid reviewers from creating questions", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "reviewer-1", Role: auth.RoleReviewer})
+		_, svc := initMockService(t)
+
+		question, err := svc.CreateQuestion(ctx, service.QuestionCreationDTO{Body: "first-question"})
+		assert.Equal(t, service.QuestionDTO{}, question)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should require a caller to create questions", func(t *testing.T) {
+		_, svc := initMockService(t)
+
+		question, err := svc.CreateQuestion(context.Background(), service.QuestionCreationDTO{Body: "first-question"})
+		assert.Equal(t, service.QuestionDTO{}, question)
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should forbid authors from updating questions of other authors", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "author-2", Role: auth.RoleAuthor})
+		mocks, svc := initMockService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+		)
+
+		question, err := svc.UpdateQuestion(ctx, 1, service.QuestionCreationDTO{Body: "second-question"})
+		assert.Equal(t, service.QuestionDTO{}, question)
+		assert.True(t, errors.Is(err, service.ErrForbidden))
+	})
+
+	t.Run("Should allow admins to delete questions of any author", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "admin-1", Role: auth.RoleAdmin})
+		mocks, svc := initMockService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(ownedQuestion, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(ctx, 1).Return(nil, nil),
+			mocks.questionOptionStorer.EXPECT().DeleteQuestionOptions(ctx, 1).Return(nil),
+			mocks.questionHintStorer.EXPECT().DeleteQuestionHints(ctx, 1).Return(nil),
+			mocks.reviewStorer.EXPECT().DeleteQuestionReviewSchedules(ctx, 1).Return(nil),
+			mocks.questionStorer.EXPECT().DeleteQuestion(ctx, 1).Return(nil),
+		)
+
+		err := svc.DeleteQuestion(ctx, 1)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// QuestionResponseStorer represents necessary question response storage implementation for response service.
+type QuestionResponseStorer interface {
+	CreateQuestionResponse(ctx context.Context, response entity.QuestionResponse) (int, error)
+}
+
+// DifficultyCalculator represents storage which can recalculate empirical difficulty of questions.
+type DifficultyCalculator interface {
+	RecalculateEmpiricalDifficulty(ctx context.Context) error
+}
+
+// ResponseService contains business logic for recording and grading responses to questions.
+type ResponseService struct {
+	responseStore        QuestionResponseStorer
+	questionStore        QuestionStorer
+	questionOptionStore  QuestionOptionStorer
+	difficultyCalculator DifficultyCalculator
+}
+
+// Instantiates a new response service struct with response and question repos.
+func NewResponseService(responseStore QuestionResponseStorer, questionStore QuestionStorer,
+	questionOptionStore QuestionOptionStorer, difficultyCalculator DifficultyCalculator) *ResponseService {
+	return &ResponseService{
+		responseStore:        responseStore,
+		questionStore:        questionStore,
+		questionOptionStore:  questionOptionStore,
+		difficultyCalculator: difficultyCalculator,
+	}
+}
+
+// RecordResponse handles the logic for grading a response against the question options and storing it.
+// The graded response carries the explanation of the question, revealed once it was answered.
+func (s *ResponseService) RecordResponse(ctx context.Context,
+	questionID int, responseCreation QuestionResponseCreationDTO) (QuestionResponseDTO, error) {
+	question, err := s.questionStore.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return QuestionResponseDTO{}, err
+	}
+
+	options, err := s.questionOptionStore.GetQuestionOptions(ctx, questionID)
+	if err != nil {
+		return QuestionResponseDTO{}, err
+	}
+
+	correct, selectedIDs, correctIDs, err := gradeResponse(options, responseCreation.OptionIDs)
+	if err != nil {
+		return QuestionResponseDTO{}, err
+	}
+
+	responseID, err := s.responseStore.CreateQuestionResponse(ctx, entity.QuestionResponse{
+		QuestionID: questionID,
+		OptionIDs:  selectedIDs,
+		Correct:    correct,
+		AnsweredAt: time.Now(),
+		Duration:   time.Duration(responseCreation.DurationMs) * time.Millisecond,
+	})
+	if err != nil {
+		return QuestionResponseDTO{}, fmt.Errorf("error trying to record response: %w", err)
+	}
+
+	return QuestionResponseDTO{
+		ID:               responseID,
+		QuestionID:       questionID,
+		Correct:          correct,
+		CorrectOptionIDs: correctIDs,
+		Explanation:      newQuestionExplanationDTO(question, options),
+	}, nil
+}
+
+// RecalculateDifficulty handles the logic for refreshing empirical difficulty from recorded responses.
+func (s *ResponseService) RecalculateDifficulty(ctx context.Context) error {
+	return s.difficultyCalculator.RecalculateEmpiricalDifficulty(ctx)
+}
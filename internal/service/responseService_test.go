@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/difficultyCalculatorMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionOptionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionResponseStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Response service mocks.
+type ResponseMocks struct {
+	responseStorer       *questionResponseStorerMock.MockQuestionResponseStorer
+	questionStorer       *questionStorerMock.MockQuestionStorer
+	questionOptionStorer *questionOptionStorerMock.MockQuestionOptionStorer
+	difficultyCalculator *difficultyCalculatorMock.MockDifficultyCalculator
+}
+
+// Options of a question with two correct options.
+var multipleCorrectOptions = []entity.QuestionOption{
+	{
+		ID:      1,
+		Body:    "first-option",
+		Correct: true,
+	},
+	{
+		ID:          2,
+		Body:        "second-option",
+		Correct:     false,
+		Explanation: "second-option-explanation",
+	},
+	{
+		ID:      3,
+		Body:    "third-option",
+		Correct: true,
+	},
+}
+
+func initMockResponseService(t *testing.T) (ResponseMocks, *service.ResponseService) {
+	ctrl := gomock.NewController(t)
+
+	mocks := ResponseMocks{
+		responseStorer:       questionResponseStorerMock.NewMockQuestionResponseStorer(ctrl),
+		questionStorer:       questionStorerMock.NewMockQuestionStorer(ctrl),
+		questionOptionStorer: questionOptionStorerMock.NewMockQuestionOptionStorer(ctrl),
+		difficultyCalculator: difficultyCalculatorMock.NewMockDifficultyCalculator(ctrl),
+	}
+
+	svc := service.NewResponseService(mocks.responseStorer, mocks.questionStorer,
+		mocks.questionOptionStorer, mocks.difficultyCalculator)
+
+	assert.NotEmpty(t, svc)
+
+	return mocks, svc
+}
+
+func TestResponseService_RecordResponse(t *testing.T) {
+	t.Run("Should record correct response when exactly the correct options are selected", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockResponseService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(multipleCorrectOptions, nil),
+			mocks.responseStorer.EXPECT().CreateQuestionResponse(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, response entity.QuestionResponse) (int, error) {
+					assert.Equal(t, []int{1, 3}, response.OptionIDs)
+					assert.True(t, response.Correct)
+					return 7, nil
+				}),
+		)
+
+		response, err := svc.RecordResponse(ctx, 1, service.QuestionResponseCreationDTO{OptionIDs: []int{3, 1, 3}})
+		assert.Equal(t, service.QuestionResponseDTO{
+			ID:               7,
+			QuestionID:       1,
+			Correct:          true,
+			CorrectOptionIDs: []int{1, 3},
+			Explanation: service.QuestionExplanationDTO{
+				QuestionID: 1,
+				Options: []service.QuestionOptionExplanationDTO{
+					{ID: 1, Body: "first-option", Correct: true},
+					{ID: 2, Body: "second-option", Explanation: "second-option-explanation"},
+					{ID: 3, Body: "third-option", Correct: true},
+				},
+			},
+		}, response)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should record incorrect response when only some correct options are selected", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockResponseService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).
+				Return(entity.Question{ID: 1, Explanation: "question-explanation"}, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(multipleCorrectOptions, nil),
+			mocks.responseStorer.EXPECT().CreateQuestionResponse(ctx, gomock.Any()).Return(8, nil),
+		)
+
+		response, err := svc.RecordResponse(ctx, 1, service.QuestionResponseCreationDTO{OptionIDs: []int{1}})
+		assert.False(t, response.Correct)
+		assert.Equal(t, service.QuestionExplanationDTO{
+			QuestionID:  1,
+			Explanation: "question-explanation",
+			Options: []service.QuestionOptionExplanationDTO{
+				{ID: 1, Body: "first-option", Correct: true},
+				{ID: 2, Body: "second-option", Explanation: "second-option-explanation"},
+				{ID: 3, Body: "third-option", Correct: true},
+			},
+		}, response.Explanation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail because option doesn't belong to the question", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockResponseService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(ctx, 1).Return(multipleCorrectOptions, nil),
+		)
+
+		response, err := svc.RecordResponse(ctx, 1, service.QuestionResponseCreationDTO{OptionIDs: []int{4}})
+		assert.Equal(t, service.QuestionResponseDTO{}, response)
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+}
+
+func TestResponseService_RecalculateDifficulty(t *testing.T) {
+	t.Run("Should recalculate empirical difficulty", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, svc := initMockResponseService(t)
+
+		gomock.InOrder(
+			mocks.difficultyCalculator.EXPECT().RecalculateEmpiricalDifficulty(ctx).Return(nil),
+		)
+
+		err := svc.RecalculateDifficulty(ctx)
+		assert.NoError(t, err)
+	})
+}
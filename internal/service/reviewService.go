@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+const (
+	// Ease factor of a question which was never reviewed.
+	initialEaseFactor = 2.5
+	// Ease factor never drops below this value.
+	minEaseFactor = 1.3
+	// Lowest grade which counts as a successful recall.
+	passingGrade = 3
+)
+
+// ReviewStorer represents necessary review schedule storage implementation for review service.
+type ReviewStorer interface {
+	GetReviewSchedule(ctx context.Context, userID string, questionID int) (entity.ReviewSchedule, error)
+	GetDueReviewSchedules(ctx context.Context, userID string, now time.Time, pageSize, offset int) ([]entity.ReviewSchedule, error)
+	SaveReviewSchedule(ctx context.Context, schedule entity.ReviewSchedule) error
+}
+
+// ReviewService contains business logic for SM-2 spaced repetition scheduling.
+type ReviewService struct {
+	reviewStore     ReviewStorer
+	questionService QuestionGetter
+	clock           Clock
+}
+
+// Instantiates a new review service struct with review repo, question service and clock.
+func NewReviewService(reviewStore ReviewStorer, questionService QuestionGetter, clock Clock) *ReviewService {
+	return &ReviewService{
+		reviewStore:     reviewStore,
+		questionService: questionService,
+		clock:           clock,
+	}
+}
+
+// GetDueReviews handles the logic for getting questions the user should review now.
+// Schedules of questions which can't be found anymore are left out.
+func (s *ReviewService) GetDueReviews(ctx context.Context, userID string, pageSize, offset int) ([]ReviewDTO, error) {
+	schedules, err := s.reviewStore.GetDueReviewSchedules(ctx, userID, s.clock(), pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []ReviewDTO{}
+
+	for _, schedule := range schedules {
+		question, err := s.questionService.GetQuestionByID(ctx, schedule.QuestionID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		review := newReviewDTO(schedule)
+		review.Question = &question
+
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+// RecordReview handles the logic for updating the schedule of a question after the user reviewed it.
+func (s *ReviewService) RecordReview(ctx context.Context, userID string, reviewCreation ReviewCreationDTO) (ReviewDTO, error) {
+	_, err := s.questionService.GetQuestionByID(ctx, reviewCreation.QuestionID)
+	if err != nil {
+		return ReviewDTO{}, err
+	}
+
+	schedule, err := s.reviewStore.GetReviewSchedule(ctx, userID, reviewCreation.QuestionID)
+	if errors.Is(err, ErrNotFound) {
+		schedule = entity.ReviewSchedule{
+			UserID:     userID,
+			QuestionID: reviewCreation.QuestionID,
+			EaseFactor: initialEaseFactor,
+		}
+	} else if err != nil {
+		return ReviewDTO{}, err
+	}
+
+	schedule = nextReviewSchedule(schedule, reviewCreation.Grade, s.clock())
+
+	err = s.reviewStore.SaveReviewSchedule(ctx, schedule)
+	if err != nil {
+		return ReviewDTO{}, err
+	}
+
+	return newReviewDTO(schedule), nil
+}
+
+// nextReviewSchedule applies the SM-2 algorithm to the schedule for a review graded at now.
+func nextReviewSchedule(schedule entity.ReviewSchedule, grade int, now time.Time) entity.ReviewSchedule {
+	if grade >= passingGrade {
+		switch schedule.Repetitions {
+		case 0:
+			schedule.IntervalDays = 1
+		case 1:
+			schedule.IntervalDays = 6
+		default:
+			schedule.IntervalDays = int(math.Round(float64(schedule.IntervalDays) * schedule.EaseFactor))
+		}
+		schedule.Repetitions++
+	} else {
+		// Failed recall starts the repetitions from the beginning.
+		schedule.Repetitions = 0
+		schedule.IntervalDays = 1
+	}
+
+	miss := float64(5 - grade)
+	schedule.EaseFactor += 0.1 - miss*(0.08+miss*0.02)
+	if schedule.EaseFactor < minEaseFactor {
+		schedule.EaseFactor = minEaseFactor
+	}
+
+	schedule.LastReviewedAt = now
+	schedule.DueAt = now.AddDate(0, 0, schedule.IntervalDays)
+
+	return schedule
+}
+
+// newReviewDTO converts review schedule entity into a response dto.
+func newReviewDTO(schedule entity.ReviewSchedule) ReviewDTO {
+	return ReviewDTO{
+		QuestionID:     schedule.QuestionID,
+		EaseFactor:     schedule.EaseFactor,
+		IntervalDays:   schedule.IntervalDays,
+		Repetitions:    schedule.Repetitions,
+		DueAt:          schedule.DueAt,
+		LastReviewedAt: schedule.LastReviewedAt,
+	}
+}
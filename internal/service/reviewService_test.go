@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionGetterMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/reviewStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var reviewNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func initMockReviewService(t *testing.T) (*reviewStorerMock.MockReviewStorer,
+	*questionGetterMock.MockQuestionGetter, *service.ReviewService) {
+	ctrl := gomock.NewController(t)
+
+	reviewStorer := reviewStorerMock.NewMockReviewStorer(ctrl)
+	questionGetter := questionGetterMock.NewMockQuestionGetter(ctrl)
+
+	svc := service.NewReviewService(reviewStorer, questionGetter, func() time.Time { return reviewNow })
+
+	assert.NotEmpty(t, svc)
+
+	return reviewStorer, questionGetter, svc
+}
+
+func TestReviewService_RecordReview(t *testing.T) {
+	t.Run("Should schedule first review of a question for the next day", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, questionGetter, svc := initMockReviewService(t)
+
+		expectedSchedule := entity.ReviewSchedule{
+			UserID:         "user-1",
+			QuestionID:     1,
+			EaseFactor:     2.6,
+			IntervalDays:   1,
+			Repetitions:    1,
+			DueAt:          reviewNow.AddDate(0, 0, 1),
+			LastReviewedAt: reviewNow,
+		}
+
+		gomock.InOrder(
+			questionGetter.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{ID: 1}, nil),
+			reviewStorer.EXPECT().GetReviewSchedule(ctx, "user-1", 1).Return(entity.ReviewSchedule{}, service.ErrNotFound),
+			reviewStorer.EXPECT().SaveReviewSchedule(ctx, expectedSchedule).Return(nil),
+		)
+
+		res, err := svc.RecordReview(ctx, "user-1", service.ReviewCreationDTO{QuestionID: 1, Grade: 5})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res.IntervalDays)
+		assert.Equal(t, 1, res.Repetitions)
+		assert.InDelta(t, 2.6, res.EaseFactor, 1e-9)
+		assert.Equal(t, reviewNow.AddDate(0, 0, 1), res.DueAt)
+	})
+
+	t.Run("Should grow interval by ease factor after the second repetition", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, questionGetter, svc := initMockReviewService(t)
+
+		returnSchedule := entity.ReviewSchedule{
+			UserID:       "user-1",
+			QuestionID:   1,
+			EaseFactor:   2.5,
+			IntervalDays: 6,
+			Repetitions:  2,
+		}
+
+		gomock.InOrder(
+			questionGetter.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{ID: 1}, nil),
+			reviewStorer.EXPECT().GetReviewSchedule(ctx, "user-1", 1).Return(returnSchedule, nil),
+			reviewStorer.EXPECT().SaveReviewSchedule(ctx, gomock.Any()).Return(nil),
+		)
+
+		res, err := svc.RecordReview(ctx, "user-1", service.ReviewCreationDTO{QuestionID: 1, Grade: 4})
+		assert.Nil(t, err)
+		assert.Equal(t, 15, res.IntervalDays)
+		assert.Equal(t, 3, res.Repetitions)
+		assert.InDelta(t, 2.5, res.EaseFactor, 1e-9)
+		assert.Equal(t, reviewNow.AddDate(0, 0, 15), res.DueAt)
+	})
+
+	t.Run("Should reset repetitions and keep ease factor above minimum on failed recall", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, questionGetter, svc := initMockReviewService(t)
+
+		returnSchedule := entity.ReviewSchedule{
+			UserID:       "user-1",
+			QuestionID:   1,
+			EaseFactor:   1.4,
+			IntervalDays: 20,
+			Repetitions:  4,
+		}
+
+		gomock.InOrder(
+			questionGetter.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{ID: 1}, nil),
+			reviewStorer.EXPECT().GetReviewSchedule(ctx, "user-1", 1).Return(returnSchedule, nil),
+			reviewStorer.EXPECT().SaveReviewSchedule(ctx, gomock.Any()).Return(nil),
+		)
+
+		res, err := svc.RecordReview(ctx, "user-1", service.ReviewCreationDTO{QuestionID: 1, Grade: 0})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res.IntervalDays)
+		assert.Equal(t, 0, res.Repetitions)
+		assert.InDelta(t, 1.3, res.EaseFactor, 1e-9)
+	})
+
+	t.Run("Should return error when question does not exist", func(t *testing.T) {
+		ctx := context.Background()
+		_, questionGetter, svc := initMockReviewService(t)
+
+		questionGetter.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{}, service.ErrNotFound)
+
+		_, err := svc.RecordReview(ctx, "user-1", service.ReviewCreationDTO{QuestionID: 1, Grade: 3})
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+func TestReviewService_GetDueReviews(t *testing.T) {
+	t.Run("Should return due reviews with their questions", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, questionGetter, svc := initMockReviewService(t)
+
+		returnSchedules := []entity.ReviewSchedule{
+			{UserID: "user-1", QuestionID: 2, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, DueAt: reviewNow},
+		}
+
+		gomock.InOrder(
+			reviewStorer.EXPECT().GetDueReviewSchedules(ctx, "user-1", reviewNow, 10, 0).Return(returnSchedules, nil),
+			questionGetter.EXPECT().GetQuestionByID(ctx, 2).Return(service.QuestionDTO{ID: 2, Body: "question"}, nil),
+		)
+
+		res, err := svc.GetDueReviews(ctx, "user-1", 10, 0)
+		assert.Nil(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 2, res[0].QuestionID)
+		assert.Equal(t, "question", res[0].Question.Body)
+	})
+
+	t.Run("Should leave out reviews of questions which can't be found", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, questionGetter, svc := initMockReviewService(t)
+
+		returnSchedules := []entity.ReviewSchedule{
+			{UserID: "user-1", QuestionID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, DueAt: reviewNow},
+			{UserID: "user-1", QuestionID: 2, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, DueAt: reviewNow},
+		}
+
+		gomock.InOrder(
+			reviewStorer.EXPECT().GetDueReviewSchedules(ctx, "user-1", reviewNow, 10, 0).Return(returnSchedules, nil),
+			questionGetter.EXPECT().GetQuestionByID(ctx, 1).Return(service.QuestionDTO{}, service.ErrNotFound),
+			questionGetter.EXPECT().GetQuestionByID(ctx, 2).Return(service.QuestionDTO{ID: 2, Body: "question"}, nil),
+		)
+
+		res, err := svc.GetDueReviews(ctx, "user-1", 10, 0)
+		assert.Nil(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 2, res[0].QuestionID)
+	})
+
+	t.Run("Should return error when storage fails", func(t *testing.T) {
+		ctx := context.Background()
+		reviewStorer, _, svc := initMockReviewService(t)
+
+		reviewStorer.EXPECT().GetDueReviewSchedules(ctx, "user-1", reviewNow, 10, 0).Return(nil, errors.New("test"))
+
+		_, err := svc.GetDueReviews(ctx, "user-1", 10, 0)
+		assert.NotNil(t, err)
+	})
+}
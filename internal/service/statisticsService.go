@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// StatisticsStorer represents necessary statistics storage implementation for statistics service.
+type StatisticsStorer interface {
+	GetResponseSummaries(ctx context.Context, questionIDs []int) ([]entity.QuestionResponseSummary, error)
+	GetOptionSelections(ctx context.Context, questionIDs []int) ([]entity.OptionSelection, error)
+}
+
+// StatisticsService contains business logic for item analysis of recorded responses.
+// Which options are correct is only reported to callers the policy lets see correctness.
+type StatisticsService struct {
+	statisticsStore StatisticsStorer
+	questionStore   QuestionStorer
+	policy          QuestionPolicy
+}
+
+// Instantiates a new statistics service struct with statistics and question repos and question policy.
+func NewStatisticsService(statisticsStore StatisticsStorer,
+	questionStore QuestionStorer, policy QuestionPolicy) *StatisticsService {
+	return &StatisticsService{
+		statisticsStore: statisticsStore,
+		questionStore:   questionStore,
+		policy:          policy,
+	}
+}
+
+// GetQuestionStats handles the logic for getting statistics of a single question.
+func (s *StatisticsService) GetQuestionStats(ctx context.Context, questionID int) (QuestionStatsDTO, error) {
+	report, err := s.GetQuestionStatsReport(ctx, []int{questionID})
+	if err != nil {
+		return QuestionStatsDTO{}, err
+	}
+
+	return report[0], nil
+}
+
+// GetQuestionStatsReport handles the logic for getting statistics of every question with provided ids,
+// in the order of the ids.
+func (s *StatisticsService) GetQuestionStatsReport(ctx context.Context, questionIDs []int) ([]QuestionStatsDTO, error) {
+	for _, questionID := range questionIDs {
+		_, err := s.questionStore.GetQuestionByID(ctx, questionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	summaries, err := s.statisticsStore.GetResponseSummaries(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	selections, err := s.statisticsStore.GetOptionSelections(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaryByQuestion := map[int]entity.QuestionResponseSummary{}
+	for _, summary := range summaries {
+		summaryByQuestion[summary.QuestionID] = summary
+	}
+
+	selectionsByQuestion := map[int][]entity.OptionSelection{}
+	for _, selection := range selections {
+		selectionsByQuestion[selection.QuestionID] = append(selectionsByQuestion[selection.QuestionID], selection)
+	}
+
+	caller, ok := auth.CallerFromContext(ctx)
+	showCorrect := ok && s.policy.CanSeeCorrectness(caller)
+
+	report := []QuestionStatsDTO{}
+
+	for _, questionID := range questionIDs {
+		report = append(report, newQuestionStatsDTO(questionID,
+			summaryByQuestion[questionID], selectionsByQuestion[questionID], showCorrect))
+	}
+
+	return report, nil
+}
+
+// newQuestionStatsDTO derives rates and distractor analysis from aggregated responses.
+// Without showCorrect the options which are correct and the non functional distractors are left out.
+func newQuestionStatsDTO(questionID int, summary entity.QuestionResponseSummary,
+	selections []entity.OptionSelection, showCorrect bool) QuestionStatsDTO {
+	stats := QuestionStatsDTO{
+		QuestionID:               questionID,
+		Attempts:                 summary.Attempts,
+		CorrectCount:             summary.CorrectCount,
+		AverageTimeMs:            summary.AverageDurationMs,
+		Options:                  []OptionStatsDTO{},
+		NonFunctionalDistractors: []int{},
+	}
+
+	if summary.Attempts > 0 {
+		stats.PercentCorrect = float64(summary.CorrectCount) / float64(summary.Attempts) * 100
+	}
+
+	distractors, selectedDistractors := 0, 0
+
+	for _, selection := range selections {
+		option := OptionStatsDTO{
+			ID:         selection.OptionID,
+			Body:       selection.Body,
+			Selections: selection.Selections,
+		}
+
+		if showCorrect {
+			correct := selection.Correct
+			option.Correct = &correct
+		}
+
+		if summary.Attempts > 0 {
+			option.SelectionRate = float64(selection.Selections) / float64(summary.Attempts)
+		}
+
+		if !selection.Correct {
+			distractors++
+			if selection.Selections > 0 {
+				selectedDistractors++
+			} else if summary.Attempts > 0 {
+				stats.NonFunctionalDistractors = append(stats.NonFunctionalDistractors, selection.OptionID)
+			}
+		}
+
+		stats.Options = append(stats.Options, option)
+	}
+
+	if distractors > 0 && summary.Attempts > 0 {
+		stats.DistractorEffectiveness = float64(selectedDistractors) / float64(distractors)
+	}
+
+	// Distractors are the incorrect options.
+	if !showCorrect {
+		stats.NonFunctionalDistractors = nil
+	}
+
+	return stats
+}
@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/questionStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/statisticsStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func initMockStatisticsService(t *testing.T) (*statisticsStorerMock.MockStatisticsStorer,
+	*questionStorerMock.MockQuestionStorer, *service.StatisticsService) {
+	ctrl := gomock.NewController(t)
+
+	statisticsStorer := statisticsStorerMock.NewMockStatisticsStorer(ctrl)
+	questionStorer := questionStorerMock.NewMockQuestionStorer(ctrl)
+
+	svc := service.NewStatisticsService(statisticsStorer, questionStorer, service.NewRolePolicy())
+
+	assert.NotEmpty(t, svc)
+
+	return statisticsStorer, questionStorer, svc
+}
+
+func TestStatisticsService_GetQuestionStatsReport(t *testing.T) {
+	t.Run("Should compute rates and distractor analysis successfuly", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), author)
+		statisticsStorer, questionStorer, svc := initMockStatisticsService(t)
+		averageDuration := 1500.0
+
+		returnSummaries := []entity.QuestionResponseSummary{
+			{
+				QuestionID:        1,
+				Attempts:          4,
+				CorrectCount:      3,
+				AverageDurationMs: &averageDuration,
+			},
+		}
+
+		returnSelections := []entity.OptionSelection{
+			{QuestionID: 1, OptionID: 1, Body: "first-option", Correct: true, Selections: 3},
+			{QuestionID: 1, OptionID: 2, Body: "second-option", Correct: false, Selections: 1},
+			{QuestionID: 1, OptionID: 3, Body: "third-option", Correct: false, Selections: 0},
+			{QuestionID: 2, OptionID: 4, Body: "fourth-option", Correct: true, Selections: 0},
+		}
+
+		expectedResult := []service.QuestionStatsDTO{
+			{
+				QuestionID:     1,
+				Attempts:       4,
+				CorrectCount:   3,
+				PercentCorrect: 75,
+				AverageTimeMs:  &averageDuration,
+				Options: []service.OptionStatsDTO{
+					{ID: 1, Body: "first-option", Correct: boolPtr(true), Selections: 3, SelectionRate: 0.75},
+					{ID: 2, Body: "second-option", Correct: boolPtr(false), Selections: 1, SelectionRate: 0.25},
+					{ID: 3, Body: "third-option", Correct: boolPtr(false), Selections: 0, SelectionRate: 0},
+				},
+				NonFunctionalDistractors: []int{3},
+				DistractorEffectiveness:  0.5,
+			},
+			{
+				QuestionID: 2,
+				Options: []service.OptionStatsDTO{
+					{ID: 4, Body: "fourth-option", Correct: boolPtr(true)},
+				},
+				NonFunctionalDistractors: []int{},
+			},
+		}
+
+		gomock.InOrder(
+			questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			questionStorer.EXPECT().GetQuestionByID(ctx, 2).Return(entity.Question{ID: 2}, nil),
+			statisticsStorer.EXPECT().GetResponseSummaries(ctx, []int{1, 2}).Return(returnSummaries, nil),
+			statisticsStorer.EXPECT().GetOptionSelections(ctx, []int{1, 2}).Return(returnSelections, nil),
+		)
+
+		report, err := svc.GetQuestionStatsReport(ctx, []int{1, 2})
+		assert.EqualValues(t, expectedResult, report)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should leave out option correctness for takers", func(t *testing.T) {
+		ctx := auth.WithCaller(context.Background(), auth.Caller{ID: "taker-1", Role: auth.RoleTaker})
+		statisticsStorer, questionStorer, svc := initMockStatisticsService(t)
+
+		returnSummaries := []entity.QuestionResponseSummary{{QuestionID: 1, Attempts: 2, CorrectCount: 1}}
+
+		returnSelections := []entity.OptionSelection{
+			{QuestionID: 1, OptionID: 1, Body: "first-option", Correct: true, Selections: 1},
+			{QuestionID: 1, OptionID: 2, Body: "second-option", Correct: false, Selections: 1},
+			{QuestionID: 1, OptionID: 3, Body: "third-option", Correct: false, Selections: 0},
+		}
+
+		expectedResult := service.QuestionStatsDTO{
+			QuestionID:     1,
+			Attempts:       2,
+			CorrectCount:   1,
+			PercentCorrect: 50,
+			Options: []service.OptionStatsDTO{
+				{ID: 1, Body: "first-option", Selections: 1, SelectionRate: 0.5},
+				{ID: 2, Body: "second-option", Selections: 1, SelectionRate: 0.5},
+				{ID: 3, Body: "third-option", Selections: 0, SelectionRate: 0},
+			},
+			DistractorEffectiveness: 0.5,
+		}
+
+		gomock.InOrder(
+			questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{ID: 1}, nil),
+			statisticsStorer.EXPECT().GetResponseSummaries(ctx, []int{1}).Return(returnSummaries, nil),
+			statisticsStorer.EXPECT().GetOptionSelections(ctx, []int{1}).Return(returnSelections, nil),
+		)
+
+		stats, err := svc.GetQuestionStats(ctx, 1)
+		assert.Equal(t, expectedResult, stats)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should fail because question doesn't exist", func(t *testing.T) {
+		ctx := context.Background()
+		_, questionStorer, svc := initMockStatisticsService(t)
+
+		gomock.InOrder(
+			questionStorer.EXPECT().GetQuestionByID(ctx, 1).Return(entity.Question{}, service.ErrNotFound),
+		)
+
+		stats, err := svc.GetQuestionStats(ctx, 1)
+		assert.Equal(t, service.QuestionStatsDTO{}, stats)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
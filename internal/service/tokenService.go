@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TokenStorer represents necessary refresh token and revocation list storage implementation for token service.
+type TokenStorer interface {
+	CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (entity.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, tokenHash string, replacement entity.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpiredTokens(ctx context.Context, now time.Time) error
+}
+
+// AccessTokenSigner represents implementation which signs and verifies access tokens.
+type AccessTokenSigner interface {
+	Sign(claims auth.Claims) (string, error)
+	Parse(token string, now time.Time) (auth.Claims, error)
+}
+
+// CredentialChecker represents necessary user service implementation for token service.
+type CredentialChecker interface {
+	CheckCredentials(ctx context.Context, login UserLoginDTO) (UserDTO, error)
+	GetUserByID(ctx context.Context, userID int) (UserDTO, error)
+}
+
+// TokenPolicy holds lifetimes of issued tokens.
+type TokenPolicy struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// TokenService contains business logic for issuing, refreshing and revoking jwt access tokens.
+type TokenService struct {
+	tokenStore  TokenStorer
+	signer      AccessTokenSigner
+	userService CredentialChecker
+	policy      TokenPolicy
+	clock       Clock
+}
+
+// Instantiates a new token service struct with token repo, signer, user service, policy and clock.
+func NewTokenService(tokenStore TokenStorer, signer AccessTokenSigner,
+	userService CredentialChecker, policy TokenPolicy, clock Clock) *TokenService {
+	return &TokenService{
+		tokenStore:  tokenStore,
+		signer:      signer,
+		userService: userService,
+		policy:      policy,
+		clock:       clock,
+	}
+}
+
+// IssueTokens handles the logic for checking user credentials and issuing a new token pair.
+func (s *TokenService) IssueTokens(ctx context.Context, login UserLoginDTO) (TokenPairDTO, error) {
+	user, err := s.userService.CheckCredentials(ctx, login)
+	if err != nil {
+		return TokenPairDTO{}, err
+	}
+
+	// Credentials were checked within the tenant of the request, which the user belongs to.
+	tenantID, _ := tenant.FromContext(ctx)
+
+	familyID, err := newRandomToken()
+	if err != nil {
+		return TokenPairDTO{}, fmt.Errorf("error trying to generate token family: %w", err)
+	}
+
+	pair, refreshToken, err := s.newTokenPair(user, tenantID, familyID)
+	if err != nil {
+		return TokenPairDTO{}, err
+	}
+
+	err = s.tokenStore.CreateRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return TokenPairDTO{}, fmt.Errorf("error trying to create refresh token: %w", err)
+	}
+
+	return pair, nil
+}
+
+// RefreshTokens handles the logic for exchanging a refresh token for a new token pair.
+// Refresh token can be used once, using it again revokes every token rotated from the same login.
+func (s *TokenService) RefreshTokens(ctx context.Context, refresh RefreshTokenDTO) (TokenPairDTO, error) {
+	stored, err := s.tokenStore.GetRefreshToken(ctx, hashToken(refresh.RefreshToken))
+	if errors.Is(err, ErrNotFound) {
+		return TokenPairDTO{}, fmt.Errorf("%w: invalid refresh token", ErrUnauthorized)
+	}
+	if err != nil {
+		return TokenPairDTO{}, err
+	}
+
+	now := s.clock()
+
+	if stored.RevokedAt != nil {
+		logging.FromContext(ctx).Warn("refresh token reuse detected, revoking token family",
+			"user_id", stored.UserID, "family_id", stored.FamilyID)
+
+		err := s.tokenStore.RevokeRefreshTokenFamily(ctx, stored.FamilyID, now)
+		if err != nil {
+			return TokenPairDTO{}, err
+		}
+		return TokenPairDTO{}, fmt.Errorf("%w: refresh token was already used", ErrUnauthorized)
+	}
+
+	if !now.Before(stored.ExpiresAt) {
+		return TokenPairDTO{}, fmt.Errorf("%w: refresh token expired", ErrUnauthorized)
+	}
+
+	// The user and the new tokens belong to the tenant of the refresh token, whichever tenant the request named.
+	ctx = tenant.WithTenant(ctx, stored.TenantID)
+
+	user, err := s.userService.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		return TokenPairDTO{}, err
+	}
+
+	pair, refreshToken, err := s.newTokenPair(user, stored.TenantID, stored.FamilyID)
+	if err != nil {
+		return TokenPairDTO{}, err
+	}
+
+	err = s.tokenStore.RotateRefreshToken(ctx, stored.TokenHash, refreshToken)
+	if errors.Is(err, ErrNotFound) {
+		return TokenPairDTO{}, fmt.Errorf("%w: refresh token was already used", ErrUnauthorized)
+	}
+	if err != nil {
+		return TokenPairDTO{}, fmt.Errorf("error trying to rotate refresh token: %w", err)
+	}
+
+	return pair, nil
+}
+
+// RevokeRefreshToken handles the logic for revoking the refresh token and every token rotated with it.
+func (s *TokenService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	stored, err := s.tokenStore.GetRefreshToken(ctx, hashToken(refreshToken))
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: invalid refresh token", ErrUnauthorized)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.tokenStore.RevokeRefreshTokenFamily(ctx, stored.FamilyID, s.clock())
+}
+
+// RevokeAccessToken handles the logic for adding the access token to the revocation list.
+func (s *TokenService) RevokeAccessToken(ctx context.Context, claims auth.Claims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return fmt.Errorf("%w: access token can't be revoked", ErrInvalidInput)
+	}
+
+	return s.tokenStore.RevokeAccessToken(ctx, claims.ID, claims.ExpiresAt.Time)
+}
+
+// VerifyAccessToken handles the logic for validating an access token and checking it wasn't revoked.
+func (s *TokenService) VerifyAccessToken(ctx context.Context, token string) (auth.Claims, error) {
+	claims, err := s.signer.Parse(token, s.clock())
+	if err != nil {
+		return auth.Claims{}, fmt.Errorf("%w: %s", ErrUnauthorized, err)
+	}
+
+	revoked, err := s.tokenStore.IsAccessTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return auth.Claims{}, err
+	}
+	if revoked {
+		return auth.Claims{}, fmt.Errorf("%w: access token was revoked", ErrUnauthorized)
+	}
+
+	return claims, nil
+}
+
+// DeleteExpiredTokens handles the logic for removing tokens which can't be used anymore.
+func (s *TokenService) DeleteExpiredTokens(ctx context.Context) error {
+	return s.tokenStore.DeleteExpiredTokens(ctx, s.clock())
+}
+
+// newTokenPair signs a new access token for the user of the tenant and creates a refresh token in the family.
+func (s *TokenService) newTokenPair(user UserDTO,
+	tenantID, familyID string) (TokenPairDTO, entity.RefreshToken, error) {
+	now := s.clock()
+
+	jti, err := newRandomToken()
+	if err != nil {
+		return TokenPairDTO{}, entity.RefreshToken{}, fmt.Errorf("error trying to generate token id: %w", err)
+	}
+
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.policy.AccessTTL)),
+		},
+		Username: user.Username,
+		Tenant:   tenantID,
+	}
+
+	accessToken, err := s.signer.Sign(claims)
+	if err != nil {
+		return TokenPairDTO{}, entity.RefreshToken{}, fmt.Errorf("error trying to sign access token: %w", err)
+	}
+
+	refreshToken, err := newRandomToken()
+	if err != nil {
+		return TokenPairDTO{}, entity.RefreshToken{}, fmt.Errorf("error trying to generate refresh token: %w", err)
+	}
+
+	stored := entity.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.policy.RefreshTTL),
+		TenantID:  tenantID,
+	}
+
+	return TokenPairDTO{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             claims.ExpiresAt.Time,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, stored, nil
+}
@@ -0,0 +1,188 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/credentialCheckerMock"
+	"github.com/djurica-surla/backend-homework/internal/mock/tokenStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func initMockTokenService(t *testing.T) (*tokenStorerMock.MockTokenStorer,
+	*credentialCheckerMock.MockCredentialChecker, *service.TokenService) {
+	ctrl := gomock.NewController(t)
+
+	tokenStorer := tokenStorerMock.NewMockTokenStorer(ctrl)
+	credentialChecker := credentialCheckerMock.NewMockCredentialChecker(ctrl)
+
+	jwtManager, err := auth.NewJWTManager(auth.JWTConfig{
+		Secret: []byte("test-secret-which-is-long-enough-for-hs256"),
+		Issuer: "test",
+	})
+	assert.Nil(t, err)
+
+	svc := service.NewTokenService(tokenStorer, jwtManager, credentialChecker, service.TokenPolicy{
+		AccessTTL:  15 * time.Minute,
+		RefreshTTL: 24 * time.Hour,
+	}, func() time.Time { return tokenNow })
+
+	assert.NotEmpty(t, svc)
+
+	return tokenStorer, credentialChecker, svc
+}
+
+func TestTokenService_IssueTokens(t *testing.T) {
+	t.Run("Should issue verifiable access token and store refresh token", func(t *testing.T) {
+		ctx := tenant.WithTenant(context.Background(), "school-a")
+		tokenStorer, credentialChecker, svc := initMockTokenService(t)
+
+		login := service.UserLoginDTO{Username: "student", Password: "correct-horse"}
+		var storedToken entity.RefreshToken
+
+		gomock.InOrder(
+			credentialChecker.EXPECT().CheckCredentials(ctx, login).Return(service.UserDTO{ID: 7, Username: "student"}, nil),
+			tokenStorer.EXPECT().CreateRefreshToken(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, token entity.RefreshToken) error {
+					storedToken = token
+					return nil
+				}),
+		)
+
+		res, err := svc.IssueTokens(ctx, login)
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer", res.TokenType)
+		assert.Equal(t, tokenNow.Add(15*time.Minute), res.ExpiresAt)
+		assert.Equal(t, tokenNow.Add(24*time.Hour), res.RefreshTokenExpiresAt)
+		assert.Equal(t, 7, storedToken.UserID)
+		assert.Equal(t, "school-a", storedToken.TenantID)
+		assert.NotEqual(t, res.RefreshToken, storedToken.TokenHash)
+
+		tokenStorer.EXPECT().IsAccessTokenRevoked(ctx, gomock.Any()).Return(false, nil)
+
+		claims, err := svc.VerifyAccessToken(ctx, res.AccessToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "7", claims.Subject)
+		assert.Equal(t, "student", claims.Username)
+		assert.Equal(t, "school-a", claims.Tenant)
+	})
+
+	t.Run("Should return error for invalid credentials", func(t *testing.T) {
+		ctx := context.Background()
+		_, credentialChecker, svc := initMockTokenService(t)
+
+		credentialChecker.EXPECT().CheckCredentials(ctx, gomock.Any()).Return(service.UserDTO{}, service.ErrUnauthorized)
+
+		_, err := svc.IssueTokens(ctx, service.UserLoginDTO{Username: "student", Password: "wrong"})
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
+
+func TestTokenService_RefreshTokens(t *testing.T) {
+	t.Run("Should rotate refresh token within the same family and tenant", func(t *testing.T) {
+		ctx := tenant.WithTenant(context.Background(), "school-b")
+		tokenCtx := tenant.WithTenant(ctx, "school-a")
+		tokenStorer, credentialChecker, svc := initMockTokenService(t)
+
+		returnToken := entity.RefreshToken{
+			TokenHash: "old-hash",
+			UserID:    7,
+			FamilyID:  "family",
+			ExpiresAt: tokenNow.Add(time.Hour),
+			TenantID:  "school-a",
+		}
+
+		var replacement entity.RefreshToken
+
+		gomock.InOrder(
+			tokenStorer.EXPECT().GetRefreshToken(ctx, gomock.Any()).Return(returnToken, nil),
+			credentialChecker.EXPECT().GetUserByID(tokenCtx, 7).Return(service.UserDTO{ID: 7, Username: "student"}, nil),
+			tokenStorer.EXPECT().RotateRefreshToken(tokenCtx, "old-hash", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, token entity.RefreshToken) error {
+					replacement = token
+					return nil
+				}),
+		)
+
+		res, err := svc.RefreshTokens(ctx, service.RefreshTokenDTO{RefreshToken: "old-token"})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, res.AccessToken)
+		assert.NotEqual(t, "old-token", res.RefreshToken)
+		assert.Equal(t, "family", replacement.FamilyID)
+		assert.Equal(t, "school-a", replacement.TenantID)
+
+		tokenStorer.EXPECT().IsAccessTokenRevoked(ctx, gomock.Any()).Return(false, nil)
+
+		claims, err := svc.VerifyAccessToken(ctx, res.AccessToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "school-a", claims.Tenant)
+	})
+
+	t.Run("Should revoke the family when a rotated token is reused", func(t *testing.T) {
+		ctx := context.Background()
+		tokenStorer, _, svc := initMockTokenService(t)
+
+		revokedAt := tokenNow.Add(-time.Minute)
+		returnToken := entity.RefreshToken{
+			TokenHash: "old-hash",
+			UserID:    7,
+			FamilyID:  "family",
+			ExpiresAt: tokenNow.Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}
+
+		gomock.InOrder(
+			tokenStorer.EXPECT().GetRefreshToken(ctx, gomock.Any()).Return(returnToken, nil),
+			tokenStorer.EXPECT().RevokeRefreshTokenFamily(ctx, "family", tokenNow).Return(nil),
+		)
+
+		_, err := svc.RefreshTokens(ctx, service.RefreshTokenDTO{RefreshToken: "old-token"})
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject expired refresh token", func(t *testing.T) {
+		ctx := context.Background()
+		tokenStorer, _, svc := initMockTokenService(t)
+
+		tokenStorer.EXPECT().GetRefreshToken(ctx, gomock.Any()).
+			Return(entity.RefreshToken{UserID: 7, FamilyID: "family", ExpiresAt: tokenNow}, nil)
+
+		_, err := svc.RefreshTokens(ctx, service.RefreshTokenDTO{RefreshToken: "old-token"})
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
+
+func TestTokenService_VerifyAccessToken(t *testing.T) {
+	t.Run("Should reject revoked access token", func(t *testing.T) {
+		ctx := context.Background()
+		tokenStorer, credentialChecker, svc := initMockTokenService(t)
+
+		credentialChecker.EXPECT().CheckCredentials(ctx, gomock.Any()).Return(service.UserDTO{ID: 7}, nil)
+		tokenStorer.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(nil)
+
+		pair, err := svc.IssueTokens(ctx, service.UserLoginDTO{Username: "student", Password: "correct-horse"})
+		assert.Nil(t, err)
+
+		tokenStorer.EXPECT().IsAccessTokenRevoked(ctx, gomock.Any()).Return(true, nil)
+
+		_, err = svc.VerifyAccessToken(ctx, pair.AccessToken)
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject malformed access token", func(t *testing.T) {
+		ctx := context.Background()
+		_, _, svc := initMockTokenService(t)
+
+		_, err := svc.VerifyAccessToken(ctx, "not.a.jwt")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer which starts the question service spans.
+const tracerName = "github.com/djurica-surla/backend-homework/internal/service"
+
+// TracedQuestionService wraps the question service, running every method within a span
+// so the storage spans of a request nest under the service method which made them.
+type TracedQuestionService struct {
+	questionService *QuestionService
+	tracer          trace.Tracer
+}
+
+// NewTracedQuestionService creates a new instance of traced question service.
+func NewTracedQuestionService(questionService *QuestionService,
+	tracerProvider trace.TracerProvider) *TracedQuestionService {
+	return &TracedQuestionService{
+		questionService: questionService,
+		tracer:          tracerProvider.Tracer(tracerName),
+	}
+}
+
+// GetQuestions traces getting questions matching the filter.
+func (s *TracedQuestionService) GetQuestions(ctx context.Context,
+	filter QuestionFilter, pageSize, offset int) ([]QuestionDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.GetQuestions", trace.WithAttributes(
+		attribute.Int("page.size", pageSize), attribute.Int("page.offset", offset)))
+
+	questions, err := s.questionService.GetQuestions(ctx, filter, pageSize, offset)
+	endSpan(span, err)
+
+	return questions, err
+}
+
+// GetQuestionByID traces getting a question by id.
+func (s *TracedQuestionService) GetQuestionByID(ctx context.Context, questionID int) (QuestionDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.GetQuestionByID",
+		trace.WithAttributes(attribute.Int("question.id", questionID)))
+
+	question, err := s.questionService.GetQuestionByID(ctx, questionID)
+	endSpan(span, err)
+
+	return question, err
+}
+
+// CreateQuestion traces creating a question.
+func (s *TracedQuestionService) CreateQuestion(ctx context.Context,
+	questionCreation QuestionCreationDTO) (QuestionDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.CreateQuestion")
+
+	question, err := s.questionService.CreateQuestion(ctx, questionCreation)
+	if err == nil {
+		span.SetAttributes(attribute.Int("question.id", question.ID))
+	}
+	endSpan(span, err)
+
+	return question, err
+}
+
+// UpdateQuestion traces updating a question.
+func (s *TracedQuestionService) UpdateQuestion(ctx context.Context,
+	questionID int, questionCreation QuestionCreationDTO) (QuestionDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.UpdateQuestion",
+		trace.WithAttributes(attribute.Int("question.id", questionID)))
+
+	question, err := s.questionService.UpdateQuestion(ctx, questionID, questionCreation)
+	endSpan(span, err)
+
+	return question, err
+}
+
+// DeleteQuestion traces deleting a question.
+func (s *TracedQuestionService) DeleteQuestion(ctx context.Context, questionID int) error {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.DeleteQuestion",
+		trace.WithAttributes(attribute.Int("question.id", questionID)))
+
+	err := s.questionService.DeleteQuestion(ctx, questionID)
+	endSpan(span, err)
+
+	return err
+}
+
+// GetQuestionExplanation traces getting the explanation of a question.
+func (s *TracedQuestionService) GetQuestionExplanation(ctx context.Context,
+	questionID int) (QuestionExplanationDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.GetQuestionExplanation",
+		trace.WithAttributes(attribute.Int("question.id", questionID)))
+
+	explanation, err := s.questionService.GetQuestionExplanation(ctx, questionID)
+	endSpan(span, err)
+
+	return explanation, err
+}
+
+// GetQuestionHint traces getting the n-th hint of a question.
+func (s *TracedQuestionService) GetQuestionHint(ctx context.Context, questionID, number int) (QuestionHintDTO, error) {
+	ctx, span := s.tracer.Start(ctx, "QuestionService.GetQuestionHint",
+		trace.WithAttributes(attribute.Int("question.id", questionID), attribute.Int("hint.number", number)))
+
+	hint, err := s.questionService.GetQuestionHint(ctx, questionID, number)
+	endSpan(span, err)
+
+	return hint, err
+}
+
+// endSpan records the error, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
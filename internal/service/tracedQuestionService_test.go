@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func initTracedService(t *testing.T) (Mocks, *tracetest.SpanRecorder, *service.TracedQuestionService) {
+	mocks, svc := initMockService(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	return mocks, recorder, service.NewTracedQuestionService(svc, provider)
+}
+
+func TestTracedQuestionService_GetQuestionByID(t *testing.T) {
+	t.Run("Should run the storage calls within the service span", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, recorder, svc := initTracedService(t)
+
+		var storageSpan trace.SpanContext
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(gomock.Any(), 1).DoAndReturn(
+				func(ctx context.Context, questionID int) (entity.Question, error) {
+					storageSpan = trace.SpanContextFromContext(ctx)
+					return entity.Question{ID: 1, Body: "first-question"}, nil
+				}),
+			mocks.questionOptionStorer.EXPECT().GetQuestionOptions(gomock.Any(), 1).Return(nil, nil),
+			mocks.attachmentStorer.EXPECT().GetAttachmentsByQuestionID(gomock.Any(), 1).Return(nil, nil),
+		)
+
+		question, err := svc.GetQuestionByID(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, question.ID)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "QuestionService.GetQuestionByID", spans[0].Name())
+		assert.Equal(t, spans[0].SpanContext(), storageSpan)
+		assert.Contains(t, spans[0].Attributes(), attribute.Int("question.id", 1))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+
+	t.Run("Should record error on the span", func(t *testing.T) {
+		ctx := context.Background()
+		mocks, recorder, svc := initTracedService(t)
+
+		gomock.InOrder(
+			mocks.questionStorer.EXPECT().GetQuestionByID(gomock.Any(), 1).Return(entity.Question{}, service.ErrNotFound),
+		)
+
+		_, err := svc.GetQuestionByID(ctx, 1)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Len(t, spans[0].Events(), 1)
+	})
+}
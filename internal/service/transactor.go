@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+// Transactor runs functions within a single storage transaction carried by their context,
+// so the writes of several stores are committed or rolled back together.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Longest password in bytes, bcrypt ignores everything after the first 72 bytes.
+const maxPasswordBytes = 72
+
+// Hash compared against on login of unknown users, so they take as long as known ones.
+const dummyPasswordHash = "$2a$10$9JG77CaCHsyNueMl6JCSAOsAtPD9P4Ez65RceKXJ7cNhg2BFqDNeC"
+
+// UserStorer represents necessary user and session storage implementation for user service.
+type UserStorer interface {
+	CreateUser(ctx context.Context, user entity.User) (int, error)
+	GetUserByID(ctx context.Context, userID int) (entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (entity.User, error)
+	CreateSession(ctx context.Context, session entity.UserSession) error
+	GetSession(ctx context.Context, tokenHash string) (entity.UserSession, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+}
+
+// UserService contains business logic for user accounts and their sessions.
+type UserService struct {
+	userStore  UserStorer
+	sessionTTL time.Duration
+	clock      Clock
+}
+
+// Instantiates a new user service struct with user repo, session lifetime and clock.
+func NewUserService(userStore UserStorer, sessionTTL time.Duration, clock Clock) *UserService {
+	return &UserService{
+		userStore:  userStore,
+		sessionTTL: sessionTTL,
+		clock:      clock,
+	}
+}
+
+// Register handles the logic for creating a new user with a hashed password.
+func (s *UserService) Register(ctx context.Context, registration UserRegistrationDTO) (UserDTO, error) {
+	// Counted in bytes rather than characters, as bcrypt truncates the password.
+	if len(registration.Password) > maxPasswordBytes {
+		return UserDTO{}, fmt.Errorf("%w: password must be at most %d bytes long", ErrInvalidInput, maxPasswordBytes)
+	}
+
+	_, err := s.userStore.GetUserByUsername(ctx, registration.Username)
+	if err == nil {
+		return UserDTO{}, fmt.Errorf("user %s %w", registration.Username, ErrConflict)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return UserDTO{}, err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(registration.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return UserDTO{}, fmt.Errorf("error trying to hash password: %w", err)
+	}
+
+	user := entity.User{
+		Username:     registration.Username,
+		PasswordHash: string(passwordHash),
+		CreatedAt:    s.clock(),
+	}
+
+	// The store reports a conflict when the username was taken after the check above.
+	user.ID, err = s.userStore.CreateUser(ctx, user)
+	if err != nil {
+		return UserDTO{}, fmt.Errorf("error trying to create user: %w", err)
+	}
+
+	return newUserDTO(user), nil
+}
+
+// Login handles the logic for checking user credentials and starting a new session.
+func (s *UserService) Login(ctx context.Context, login UserLoginDTO) (SessionDTO, error) {
+	user, err := s.CheckCredentials(ctx, login)
+	if err != nil {
+		return SessionDTO{}, err
+	}
+
+	token, err := newRandomToken()
+	if err != nil {
+		return SessionDTO{}, fmt.Errorf("error trying to generate session token: %w", err)
+	}
+
+	now := s.clock()
+	session := entity.UserSession{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	}
+
+	err = s.userStore.CreateSession(ctx, session)
+	if err != nil {
+		return SessionDTO{}, fmt.Errorf("error trying to create session: %w", err)
+	}
+
+	return SessionDTO{
+		Token:     token,
+		ExpiresAt: session.ExpiresAt,
+		User:      user,
+	}, nil
+}
+
+// CheckCredentials handles the logic for checking the password of the user.
+func (s *UserService) CheckCredentials(ctx context.Context, login UserLoginDTO) (UserDTO, error) {
+	user, err := s.userStore.GetUserByUsername(ctx, login.Username)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return UserDTO{}, err
+	}
+
+	passwordHash := user.PasswordHash
+	if err != nil {
+		passwordHash = dummyPasswordHash
+	}
+
+	compareErr := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(login.Password))
+	if err != nil || compareErr != nil {
+		return UserDTO{}, fmt.Errorf("%w: invalid username or password", ErrUnauthorized)
+	}
+
+	return newUserDTO(user), nil
+}
+
+// Logout handles the logic for ending the session of the token.
+func (s *UserService) Logout(ctx context.Context, token string) error {
+	return s.userStore.DeleteSession(ctx, hashToken(token))
+}
+
+// Authenticate handles the logic for resolving the user of a session token.
+// The user is looked up in the tenant of the session, whichever tenant the request named.
+func (s *UserService) Authenticate(ctx context.Context, token string) (auth.User, error) {
+	session, err := s.userStore.GetSession(ctx, hashToken(token))
+	if errors.Is(err, ErrNotFound) {
+		return auth.User{}, fmt.Errorf("%w: invalid session token", ErrUnauthorized)
+	}
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	if !s.clock().Before(session.ExpiresAt) {
+		return auth.User{}, fmt.Errorf("%w: session expired", ErrUnauthorized)
+	}
+
+	user, err := s.userStore.GetUserByID(tenant.WithTenant(ctx, session.TenantID), session.UserID)
+	if err != nil {
+		return auth.User{}, err
+	}
+
+	return auth.User{ID: user.ID, Username: user.Username, TenantID: user.TenantID}, nil
+}
+
+// DeleteExpiredSessions handles the logic for removing sessions which can't be used anymore.
+func (s *UserService) DeleteExpiredSessions(ctx context.Context) error {
+	return s.userStore.DeleteExpiredSessions(ctx, s.clock())
+}
+
+// GetUserByID handles the logic for getting a user by the id.
+func (s *UserService) GetUserByID(ctx context.Context, userID int) (UserDTO, error) {
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return UserDTO{}, err
+	}
+
+	return newUserDTO(user), nil
+}
+
+// newRandomToken generates a random url safe token.
+func newRandomToken() (string, error) {
+	token := make([]byte, 32)
+
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashToken returns the hash under which a session or refresh token is stored.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// newUserDTO converts user entity into a response dto without the password hash.
+func newUserDTO(user entity.User) UserDTO {
+	return UserDTO{
+		ID:        user.ID,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/mock/userStorerMock"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var userNow = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func initMockUserService(t *testing.T) (*userStorerMock.MockUserStorer, *service.UserService) {
+	ctrl := gomock.NewController(t)
+
+	userStorer := userStorerMock.NewMockUserStorer(ctrl)
+
+	svc := service.NewUserService(userStorer, time.Hour, func() time.Time { return userNow })
+
+	assert.NotEmpty(t, svc)
+
+	return userStorer, svc
+}
+
+func TestUserService_Register(t *testing.T) {
+	t.Run("Should create user with hashed password", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		var createdUser entity.User
+
+		gomock.InOrder(
+			userStorer.EXPECT().GetUserByUsername(ctx, "student").Return(entity.User{}, service.ErrNotFound),
+			userStorer.EXPECT().CreateUser(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, user entity.User) (int, error) {
+					createdUser = user
+					return 1, nil
+				}),
+		)
+
+		res, err := svc.Register(ctx, service.UserRegistrationDTO{Username: "student", Password: "correct-horse"})
+		assert.Nil(t, err)
+		assert.Equal(t, service.UserDTO{ID: 1, Username: "student", CreatedAt: userNow}, res)
+		assert.NotEqual(t, "correct-horse", createdUser.PasswordHash)
+		assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(createdUser.PasswordHash), []byte("correct-horse")))
+	})
+
+	t.Run("Should return conflict when username is taken", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		userStorer.EXPECT().GetUserByUsername(ctx, "student").Return(entity.User{ID: 1, Username: "student"}, nil)
+
+		_, err := svc.Register(ctx, service.UserRegistrationDTO{Username: "student", Password: "correct-horse"})
+		assert.True(t, errors.Is(err, service.ErrConflict))
+	})
+
+	t.Run("Should return conflict when username is taken while registering", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		gomock.InOrder(
+			userStorer.EXPECT().GetUserByUsername(ctx, "student").Return(entity.User{}, service.ErrNotFound),
+			userStorer.EXPECT().CreateUser(ctx, gomock.Any()).Return(0, service.ErrConflict),
+		)
+
+		_, err := svc.Register(ctx, service.UserRegistrationDTO{Username: "student", Password: "correct-horse"})
+		assert.True(t, errors.Is(err, service.ErrConflict))
+	})
+
+	t.Run("Should reject password longer than 72 bytes", func(t *testing.T) {
+		ctx := context.Background()
+		_, svc := initMockUserService(t)
+
+		// 36 characters, but 72 bytes and then some in utf-8.
+		password := strings.Repeat("ž", 36) + "a"
+
+		_, err := svc.Register(ctx, service.UserRegistrationDTO{Username: "student", Password: password})
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+	})
+}
+
+func TestUserService_Login(t *testing.T) {
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("correct-horse"), bcrypt.MinCost)
+	returnUser := entity.User{ID: 1, Username: "student", PasswordHash: string(passwordHash)}
+
+	t.Run("Should create session for valid credentials", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		var createdSession entity.UserSession
+
+		gomock.InOrder(
+			userStorer.EXPECT().GetUserByUsername(ctx, "student").Return(returnUser, nil),
+			userStorer.EXPECT().CreateSession(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, session entity.UserSession) error {
+					createdSession = session
+					return nil
+				}),
+		)
+
+		res, err := svc.Login(ctx, service.UserLoginDTO{Username: "student", Password: "correct-horse"})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, res.Token)
+		assert.NotEqual(t, res.Token, createdSession.TokenHash)
+		assert.Equal(t, 1, createdSession.UserID)
+		assert.Equal(t, userNow.Add(time.Hour), res.ExpiresAt)
+		assert.Equal(t, "student", res.User.Username)
+	})
+
+	t.Run("Should reject wrong password", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		userStorer.EXPECT().GetUserByUsername(ctx, "student").Return(returnUser, nil)
+
+		_, err := svc.Login(ctx, service.UserLoginDTO{Username: "student", Password: "wrong-horse"})
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject unknown user", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		userStorer.EXPECT().GetUserByUsername(ctx, "nobody").Return(entity.User{}, service.ErrNotFound)
+
+		_, err := svc.Login(ctx, service.UserLoginDTO{Username: "nobody", Password: "correct-horse"})
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	t.Run("Should resolve user of a valid session", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		gomock.InOrder(
+			userStorer.EXPECT().GetSession(ctx, gomock.Any()).
+				Return(entity.UserSession{UserID: 1, ExpiresAt: userNow.Add(time.Minute), TenantID: "school-a"}, nil),
+			userStorer.EXPECT().GetUserByID(tenant.WithTenant(ctx, "school-a"), 1).
+				Return(entity.User{ID: 1, Username: "student", TenantID: "school-a"}, nil),
+		)
+
+		res, err := svc.Authenticate(ctx, "token")
+		assert.Nil(t, err)
+		assert.Equal(t, auth.User{ID: 1, Username: "student", TenantID: "school-a"}, res)
+	})
+
+	t.Run("Should resolve user in the tenant of the session", func(t *testing.T) {
+		ctx := tenant.WithTenant(context.Background(), "school-b")
+		userStorer, svc := initMockUserService(t)
+
+		gomock.InOrder(
+			userStorer.EXPECT().GetSession(ctx, gomock.Any()).
+				Return(entity.UserSession{UserID: 1, ExpiresAt: userNow.Add(time.Minute), TenantID: "school-a"}, nil),
+			userStorer.EXPECT().GetUserByID(tenant.WithTenant(ctx, "school-a"), 1).
+				Return(entity.User{ID: 1, Username: "student", TenantID: "school-a"}, nil),
+		)
+
+		res, err := svc.Authenticate(ctx, "token")
+		assert.Nil(t, err)
+		assert.Equal(t, "school-a", res.TenantID)
+	})
+
+	t.Run("Should reject expired session", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		userStorer.EXPECT().GetSession(ctx, gomock.Any()).
+			Return(entity.UserSession{UserID: 1, ExpiresAt: userNow}, nil)
+
+		_, err := svc.Authenticate(ctx, "token")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+
+	t.Run("Should reject unknown token", func(t *testing.T) {
+		ctx := context.Background()
+		userStorer, svc := initMockUserService(t)
+
+		userStorer.EXPECT().GetSession(ctx, gomock.Any()).Return(entity.UserSession{}, service.ErrNotFound)
+
+		_, err := svc.Authenticate(ctx, "token")
+		assert.True(t, errors.Is(err, service.ErrUnauthorized))
+	})
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Columns selected for api key, in the order expected by scanAPIKey.
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_at, expires_at, last_used_at, tenant_id`
+
+// Represents sqlite implementation of api key storage.
+// Keys are created, listed and deleted in the tenant of the context,
+// they are authenticated by their prefix alone and carry their tenant.
+type APIKeyStore struct {
+	db *sql.DB
+}
+
+// NewAPIKeyStore creates a new instance of the APIKeyStore.
+func NewAPIKeyStore(connection *sql.DB) *APIKeyStore {
+	return &APIKeyStore{db: connection}
+}
+
+// Creates a new api key in the database.
+func (store *APIKeyStore) CreateAPIKey(ctx context.Context, key entity.APIKey) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var keyID int
+	var expiresAt sql.NullTime
+
+	if key.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: key.ExpiresAt.UTC(), Valid: true}
+	}
+
+	err = store.db.QueryRowContext(ctx,
+		`INSERT INTO api_key (name, prefix, key_hash, scopes, created_at, expires_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, ","), key.CreatedAt.UTC(), expiresAt, tenantID).
+		Scan(&keyID)
+	if isUniqueViolation(err) {
+		return 0, fmt.Errorf("api key prefix %s %w", key.Prefix, service.ErrConflict)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error creating api key in database %w", err)
+	}
+
+	return keyID, nil
+}
+
+// Retrieves every api key of the tenant from the database.
+func (store *APIKeyStore) GetAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []entity.APIKey{}
+
+	rows, err := store.db.QueryContext(ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api keys from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error getting api keys from database %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Retrieves an api key from database by the prefix, of any tenant.
+func (store *APIKeyStore) GetAPIKeyByPrefix(ctx context.Context, prefix string) (entity.APIKey, error) {
+	key, err := scanAPIKey(store.db.QueryRowContext(ctx,
+		`SELECT `+apiKeyColumns+` FROM api_key WHERE prefix = $1`, prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.APIKey{}, fmt.Errorf("error getting api key from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("error getting api key from db %w", err)
+	}
+
+	return key, nil
+}
+
+// Updates the time an api key was last used.
+func (store *APIKeyStore) UpdateAPIKeyLastUsed(ctx context.Context, keyID int, lastUsedAt time.Time) error {
+	_, err := store.db.ExecContext(ctx,
+		`UPDATE api_key SET last_used_at = $1 WHERE id = $2`, lastUsedAt.UTC(), keyID)
+	if err != nil {
+		return fmt.Errorf("failed to update api key %w", err)
+	}
+
+	return nil
+}
+
+// Deletes an api key in the database by the id.
+func (store *APIKeyStore) DeleteAPIKey(ctx context.Context, keyID int) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := store.db.ExecContext(ctx,
+		`DELETE FROM api_key WHERE id = $1 AND tenant_id = $2`, keyID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete api key %w", err)
+	}
+
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("failed to delete api key %w", service.ErrNotFound)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a single api key row selected with apiKeyColumns.
+func scanAPIKey(row scanner) (entity.APIKey, error) {
+	key := entity.APIKey{}
+	var scopes string
+	var expiresAt, lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&scopes,
+		&key.CreatedAt,
+		&expiresAt,
+		&lastUsedAt,
+		&key.TenantID,
+	)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	key.Scopes = strings.Split(scopes, ",")
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return key, nil
+}
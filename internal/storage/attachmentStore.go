@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents sqlite implementation of attachment metadata storage.
+// Queries are scoped to the tenant of the context.
+type AttachmentStore struct {
+	db *sql.DB
+}
+
+// NewAttachmentStore creates a new instance of the AttachmentStore.
+func NewAttachmentStore(connection *sql.DB) *AttachmentStore {
+	return &AttachmentStore{db: connection}
+}
+
+// Retrieves attachments of a question and of all its options from the database.
+// Question id of an option attachment is the id of the question of its option.
+func (store *AttachmentStore) GetAttachmentsByQuestionID(ctx context.Context, questionID int) ([]entity.Attachment, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []entity.Attachment{}
+
+	rows, err := conn(ctx, store.db).QueryContext(ctx,
+		`SELECT a.id, COALESCE(a.question_id, o.question_id), a.question_option_id, a.filename,
+		a.content_type, a.size, a.storage_key, a.created_at
+		FROM attachment a
+		LEFT JOIN question_option o ON a.question_option_id = o.id
+		WHERE a.tenant_id = $2 AND (a.question_id = $1 OR o.question_id = $1)
+		ORDER BY a.id`, questionID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting attachments from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error getting attachments from database %w", err)
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// Retrieves an attachment from the database by the id.
+// Question id of an option attachment is the id of the question of its option.
+func (store *AttachmentStore) GetAttachmentByID(ctx context.Context, attachmentID int) (entity.Attachment, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+
+	attachment, err := scanAttachment(conn(ctx, store.db).QueryRowContext(ctx,
+		`SELECT a.id, COALESCE(a.question_id, o.question_id), a.question_option_id, a.filename,
+		a.content_type, a.size, a.storage_key, a.created_at
+		FROM attachment a
+		LEFT JOIN question_option o ON a.question_option_id = o.id
+		WHERE a.id = $1 AND a.tenant_id = $2`, attachmentID, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Attachment{}, fmt.Errorf("error getting attachment from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("error getting attachment from db %w", err)
+	}
+
+	return attachment, nil
+}
+
+// Creates a new attachment of the tenant in the database.
+func (store *AttachmentStore) CreateAttachment(ctx context.Context, attachment entity.Attachment) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var attachmentID int
+
+	err = conn(ctx, store.db).QueryRowContext(ctx,
+		`INSERT INTO attachment (question_id, question_option_id, filename, content_type, size, storage_key, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		nullableInt(attachment.QuestionID),
+		nullableInt(attachment.QuestionOptionID),
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		tenantID,
+	).Scan(&attachmentID)
+	if err != nil {
+		return 0, fmt.Errorf("error creating attachment in database %w", err)
+	}
+
+	return attachmentID, nil
+}
+
+// Deletes an attachment in the database by the id.
+func (store *AttachmentStore) DeleteAttachment(ctx context.Context, attachmentID int) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
+		`DELETE FROM attachment
+		WHERE id = $1 AND tenant_id = $2`, attachmentID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment %w", err)
+	}
+
+	return nil
+}
+
+// scanner is implemented by both sql.Row and sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAttachment scans a single attachment row.
+func scanAttachment(row scanner) (entity.Attachment, error) {
+	attachment := entity.Attachment{}
+	var questionID, questionOptionID sql.NullInt64
+
+	err := row.Scan(
+		&attachment.ID,
+		&questionID,
+		&questionOptionID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+
+	attachment.QuestionID = int(questionID.Int64)
+	attachment.QuestionOptionID = int(questionOptionID.Int64)
+
+	return attachment, nil
+}
+
+// nullableInt converts zero value into sql NULL.
+func nullableInt(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Names of the instrumented stores and the question operations they count.
+const (
+	questionStoreName       = "QuestionStore"
+	questionOptionStoreName = "QuestionOptionStore"
+
+	questionCreated = "created"
+	questionUpdated = "updated"
+	questionDeleted = "deleted"
+)
+
+// StoreObserver records the metrics of instrumented stores.
+type StoreObserver interface {
+	ObserveQuery(store, method string, duration time.Duration, err error)
+	CountQuestionOperation(operation string)
+}
+
+// InstrumentedQuestionStore wraps the question store, recording the duration of every query
+// and counting questions created, updated and deleted.
+type InstrumentedQuestionStore struct {
+	store    *QuestionStore
+	observer StoreObserver
+}
+
+// NewInstrumentedQuestionStore creates a new instance of the InstrumentedQuestionStore.
+func NewInstrumentedQuestionStore(store *QuestionStore, observer StoreObserver) *InstrumentedQuestionStore {
+	return &InstrumentedQuestionStore{store: store, observer: observer}
+}
+
+// Retrieves a list of questions matching the filter from the database.
+func (s *InstrumentedQuestionStore) GetQuestions(ctx context.Context,
+	filter service.QuestionFilter, pageSize, offset int) ([]entity.Question, error) {
+	start := time.Now()
+	questions, err := s.store.GetQuestions(ctx, filter, pageSize, offset)
+	s.observer.ObserveQuery(questionStoreName, "GetQuestions", time.Since(start), err)
+
+	return questions, err
+}
+
+// Retrieves a question from database the id.
+func (s *InstrumentedQuestionStore) GetQuestionByID(ctx context.Context, questionID int) (entity.Question, error) {
+	start := time.Now()
+	question, err := s.store.GetQuestionByID(ctx, questionID)
+	s.observer.ObserveQuery(questionStoreName, "GetQuestionByID", time.Since(start), err)
+
+	return question, err
+}
+
+// Creates a new question owned by the caller in the database.
+func (s *InstrumentedQuestionStore) CreateQuestion(ctx context.Context,
+	question service.QuestionCreationDTO, owner string) (int, error) {
+	start := time.Now()
+	questionID, err := s.store.CreateQuestion(ctx, question, owner)
+	s.observer.ObserveQuery(questionStoreName, "CreateQuestion", time.Since(start), err)
+
+	if err == nil {
+		s.observer.CountQuestionOperation(questionCreated)
+	}
+
+	return questionID, err
+}
+
+// Updates a question in the database by the id.
+func (s *InstrumentedQuestionStore) UpdateQuestion(ctx context.Context,
+	questionID int, question service.QuestionCreationDTO) (int, error) {
+	start := time.Now()
+	rowsAffected, err := s.store.UpdateQuestion(ctx, questionID, question)
+	s.observer.ObserveQuery(questionStoreName, "UpdateQuestion", time.Since(start), err)
+
+	if err == nil && rowsAffected > 0 {
+		s.observer.CountQuestionOperation(questionUpdated)
+	}
+
+	return rowsAffected, err
+}
+
+// Deletes a question in the database by the id.
+func (s *InstrumentedQuestionStore) DeleteQuestion(ctx context.Context, questionID int) error {
+	start := time.Now()
+	err := s.store.DeleteQuestion(ctx, questionID)
+	s.observer.ObserveQuery(questionStoreName, "DeleteQuestion", time.Since(start), err)
+
+	if err == nil {
+		s.observer.CountQuestionOperation(questionDeleted)
+	}
+
+	return err
+}
+
+// Recalculates empirical difficulty of every question of every tenant.
+func (s *InstrumentedQuestionStore) RecalculateEmpiricalDifficulty(ctx context.Context) error {
+	start := time.Now()
+	err := s.store.RecalculateEmpiricalDifficulty(ctx)
+	s.observer.ObserveQuery(questionStoreName, "RecalculateEmpiricalDifficulty", time.Since(start), err)
+
+	return err
+}
+
+// Retrieves id of the next question of the practice session.
+func (s *InstrumentedQuestionStore) GetNextPracticeQuestionID(ctx context.Context,
+	sessionID int, rating, defaultRating float64) (int, error) {
+	start := time.Now()
+	questionID, err := s.store.GetNextPracticeQuestionID(ctx, sessionID, rating, defaultRating)
+	s.observer.ObserveQuery(questionStoreName, "GetNextPracticeQuestionID", time.Since(start), err)
+
+	return questionID, err
+}
+
+// InstrumentedQuestionOptionStore wraps the question option store, recording the duration of every query.
+type InstrumentedQuestionOptionStore struct {
+	store    *QuestionOptionStore
+	observer StoreObserver
+}
+
+// NewInstrumentedQuestionOptionStore creates a new instance of the InstrumentedQuestionOptionStore.
+func NewInstrumentedQuestionOptionStore(store *QuestionOptionStore,
+	observer StoreObserver) *InstrumentedQuestionOptionStore {
+	return &InstrumentedQuestionOptionStore{store: store, observer: observer}
+}
+
+// Retrieves a list of options for a questions from the database.
+func (s *InstrumentedQuestionOptionStore) GetQuestionOptions(ctx context.Context,
+	questionID int) ([]entity.QuestionOption, error) {
+	start := time.Now()
+	options, err := s.store.GetQuestionOptions(ctx, questionID)
+	s.observer.ObserveQuery(questionOptionStoreName, "GetQuestionOptions", time.Since(start), err)
+
+	return options, err
+}
+
+// Creates a new QuestionOption in the database.
+func (s *InstrumentedQuestionOptionStore) CreateQuestionOption(ctx context.Context,
+	questionID int, option service.QuestionOptionCreationDTO) error {
+	start := time.Now()
+	err := s.store.CreateQuestionOption(ctx, questionID, option)
+	s.observer.ObserveQuery(questionOptionStoreName, "CreateQuestionOption", time.Since(start), err)
+
+	return err
+}
+
+// Deletes a QuestionOption in the database by the question id.
+func (s *InstrumentedQuestionOptionStore) DeleteQuestionOptions(ctx context.Context, questionID int) error {
+	start := time.Now()
+	err := s.store.DeleteQuestionOptions(ctx, questionID)
+	s.observer.ObserveQuery(questionOptionStoreName, "DeleteQuestionOptions", time.Since(start), err)
+
+	return err
+}
@@ -0,0 +1,78 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeObserver records the observed queries as "store.method" and the counted question operations.
+type fakeObserver struct {
+	queries    []string
+	failed     []string
+	operations []string
+}
+
+func (o *fakeObserver) ObserveQuery(store, method string, duration time.Duration, err error) {
+	o.queries = append(o.queries, store+"."+method)
+	if err != nil {
+		o.failed = append(o.failed, store+"."+method)
+	}
+}
+
+func (o *fakeObserver) CountQuestionOperation(operation string) {
+	o.operations = append(o.operations, operation)
+}
+
+func TestInstrumentedStores(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+
+	t.Run("Should observe queries and count question operations", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		observer := &fakeObserver{}
+		instrumentedQuestions := storage.NewInstrumentedQuestionStore(questionStore, observer)
+		instrumentedOptions := storage.NewInstrumentedQuestionOptionStore(optionStore, observer)
+
+		questionID, err := instrumentedQuestions.CreateQuestion(ctx, service.QuestionCreationDTO{Body: "first-question"}, "")
+		assert.NoError(t, err)
+
+		err = instrumentedOptions.CreateQuestionOption(ctx, questionID, service.QuestionOptionCreationDTO{Body: "first-option"})
+		assert.NoError(t, err)
+
+		_, err = instrumentedQuestions.UpdateQuestion(ctx, questionID, service.QuestionCreationDTO{Body: "second-question"})
+		assert.NoError(t, err)
+
+		err = instrumentedQuestions.DeleteQuestion(ctx, questionID)
+		assert.NoError(t, err)
+
+		_, err = instrumentedQuestions.GetQuestionByID(ctx, questionID)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		assert.Equal(t, []string{
+			"QuestionStore.CreateQuestion",
+			"QuestionOptionStore.CreateQuestionOption",
+			"QuestionStore.UpdateQuestion",
+			"QuestionStore.DeleteQuestion",
+			"QuestionStore.GetQuestionByID",
+		}, observer.queries)
+		assert.Equal(t, []string{"QuestionStore.GetQuestionByID"}, observer.failed)
+		assert.Equal(t, []string{"created", "updated", "deleted"}, observer.operations)
+	})
+
+	t.Run("Should not count update which affects no question", func(t *testing.T) {
+		questionStore, _ := initStores(t)
+		observer := &fakeObserver{}
+		instrumentedQuestions := storage.NewInstrumentedQuestionStore(questionStore, observer)
+
+		rowsAffected, err := instrumentedQuestions.UpdateQuestion(ctx, 100, service.QuestionCreationDTO{Body: "question"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rowsAffected)
+		assert.Empty(t, observer.operations)
+	})
+}
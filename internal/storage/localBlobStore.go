@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents blob storage which keeps blobs as files in a local directory.
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore creates a new instance of the LocalBlobStore, creating the root directory if needed.
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	err := os.MkdirAll(root, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob directory %w", err)
+	}
+
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Stores the content read from r under the key.
+func (store *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	// Write into a temporary file first so a failed upload never leaves a partial blob.
+	tmp, err := os.CreateTemp(store.root, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error storing blob %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("error storing blob %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("error storing blob %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("error storing blob %w", err)
+	}
+
+	return nil
+}
+
+// Opens the content stored under the key.
+func (store *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading blob %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading blob %w", err)
+	}
+
+	return file, nil
+}
+
+// Deletes the content stored under the key, missing blobs are ignored.
+func (store *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting blob %w", err)
+	}
+
+	return nil
+}
+
+// path resolves the key to a file inside the root directory.
+func (store *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(store.root, key), nil
+}
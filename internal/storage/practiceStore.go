@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents sqlite implementation of practice session storage.
+// Queries are scoped to the tenant of the context.
+type PracticeStore struct {
+	db *sql.DB
+}
+
+// NewPracticeStore creates a new instance of the PracticeStore.
+func NewPracticeStore(connection *sql.DB) *PracticeStore {
+	return &PracticeStore{db: connection}
+}
+
+// Creates a new practice session of the tenant in the database.
+func (store *PracticeStore) CreatePracticeSession(ctx context.Context, session entity.PracticeSession) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var sessionID int
+
+	err = store.db.QueryRowContext(ctx,
+		`INSERT INTO practice_session (user_id, created_at, tenant_id)
+		VALUES ($1, $2, $3) RETURNING id`, session.UserID, session.CreatedAt.UTC(), tenantID).Scan(&sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("error creating practice session in database %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// Retrieves a practice session from database by the id.
+func (store *PracticeStore) GetPracticeSession(ctx context.Context, sessionID int) (entity.PracticeSession, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.PracticeSession{}, err
+	}
+
+	session := entity.PracticeSession{}
+
+	err = store.db.QueryRowContext(ctx,
+		`SELECT id, user_id, created_at FROM practice_session WHERE id = $1 AND tenant_id = $2`, sessionID, tenantID).
+		Scan(&session.ID, &session.UserID, &session.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PracticeSession{}, fmt.Errorf("error getting practice session from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.PracticeSession{}, fmt.Errorf("error getting practice session from db %w", err)
+	}
+
+	return session, nil
+}
+
+// Checks whether the question was already answered within the practice session.
+func (store *PracticeStore) HasPracticeAnswer(ctx context.Context, sessionID, questionID int) (bool, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+
+	err = store.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM practice_answer WHERE session_id = $1 AND question_id = $2 AND tenant_id = $3)`,
+		sessionID, questionID, tenantID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("error getting practice answer from db %w", err)
+	}
+
+	return exists, nil
+}
+
+// Retrieves current rating of the user from the database.
+func (store *PracticeStore) GetUserRating(ctx context.Context, userID string) (float64, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var rating float64
+
+	err = store.db.QueryRowContext(ctx,
+		`SELECT rating FROM user_rating WHERE user_id = $1 AND tenant_id = $2`, userID, tenantID).Scan(&rating)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("error getting user rating from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error getting user rating from db %w", err)
+	}
+
+	return rating, nil
+}
+
+// Retrieves current rating of the question from the database.
+func (store *PracticeStore) GetQuestionRating(ctx context.Context, questionID int) (float64, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var rating float64
+
+	err = store.db.QueryRowContext(ctx,
+		`SELECT rating FROM question_rating WHERE question_id = $1 AND tenant_id = $2`, questionID, tenantID).Scan(&rating)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("error getting question rating from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error getting question rating from db %w", err)
+	}
+
+	return rating, nil
+}
+
+// Creates a practice answer and stores the ratings of the user and the question after it in the database.
+// Current ratings, or the default rating of those never rated, are read and updated within a single transaction
+// which takes the write lock before reading them, so concurrent answers can't overwrite each other's updates.
+func (store *PracticeStore) CreatePracticeAnswer(ctx context.Context, userID string, answer entity.PracticeAnswer,
+	defaultRating float64, updateRatings service.RatingUpdate) (entity.PracticeAnswer, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.PracticeAnswer{}, err
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error creating practice answer in database %w", err)
+	}
+	defer tx.Rollback()
+
+	// Writing first takes the write lock, a concurrent answer waits for this transaction before reading ratings,
+	// for up to the busy timeout of the connection.
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO user_rating (user_id, rating, tenant_id) VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, user_id) DO NOTHING`, userID, defaultRating, tenantID)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error saving user rating in database %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO question_rating (question_id, rating, tenant_id) VALUES ($1, $2, $3)
+		ON CONFLICT (question_id) DO NOTHING`, answer.QuestionID, defaultRating, tenantID)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error saving question rating in database %w", err)
+	}
+
+	var userRating, questionRating float64
+
+	err = tx.QueryRowContext(ctx,
+		`SELECT u.rating, q.rating FROM user_rating u, question_rating q
+		WHERE u.user_id = $1 AND u.tenant_id = $3 AND q.question_id = $2 AND q.tenant_id = $3`,
+		userID, answer.QuestionID, tenantID).Scan(&userRating, &questionRating)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error getting ratings from db %w", err)
+	}
+
+	answer.UserRating, answer.QuestionRating = updateRatings(userRating, questionRating)
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO practice_answer
+		(session_id, question_id, correct, user_rating, question_rating, answered_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		answer.SessionID,
+		answer.QuestionID,
+		answer.Correct,
+		answer.UserRating,
+		answer.QuestionRating,
+		answer.AnsweredAt.UTC(),
+		tenantID,
+	).Scan(&answer.ID)
+	if isUniqueViolation(err) {
+		return entity.PracticeAnswer{}, fmt.Errorf("%w: question %d was already answered in the session",
+			service.ErrInvalidInput, answer.QuestionID)
+	}
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error creating practice answer in database %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE user_rating SET rating = $1 WHERE user_id = $2 AND tenant_id = $3`, answer.UserRating, userID, tenantID)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error saving user rating in database %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE question_rating SET rating = $1 WHERE question_id = $2 AND tenant_id = $3`,
+		answer.QuestionRating, answer.QuestionID, tenantID)
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error saving question rating in database %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entity.PracticeAnswer{}, fmt.Errorf("error creating practice answer in database %w", err)
+	}
+
+	return answer, nil
+}
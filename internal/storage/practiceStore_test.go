@@ -0,0 +1,127 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPracticeStore_CreatePracticeAnswer(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// Raises both ratings by one point, so every lost update shows up in the final ratings.
+	raise := func(userRating, questionRating float64) (float64, float64) {
+		return userRating + 1, questionRating + 1
+	}
+
+	t.Run("Should not lose rating updates of concurrent answers", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		practiceStore := storage.NewPracticeStore(connection)
+
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+
+		const answers = 10
+		sessionIDs := []int{}
+
+		for i := 0; i < answers; i++ {
+			sessionID, err := practiceStore.CreatePracticeSession(ctx, entity.PracticeSession{UserID: "user-1", CreatedAt: now})
+			require.NoError(t, err)
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+
+		wg := sync.WaitGroup{}
+		errs := make(chan error, answers)
+
+		for _, sessionID := range sessionIDs {
+			wg.Add(1)
+			go func(sessionID int) {
+				defer wg.Done()
+				_, err := practiceStore.CreatePracticeAnswer(ctx, "user-1", entity.PracticeAnswer{
+					SessionID: sessionID, QuestionID: questionID, Correct: true, AnsweredAt: now,
+				}, 1500, raise)
+				errs <- err
+			}(sessionID)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		userRating, err := practiceStore.GetUserRating(ctx, "user-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1500.0+answers, userRating)
+
+		questionRating, err := practiceStore.GetQuestionRating(ctx, questionID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1500.0+answers, questionRating)
+	})
+
+	t.Run("Should wait for a concurrent write instead of failing", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		practiceStore := storage.NewPracticeStore(connection)
+
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+		sessionID, err := practiceStore.CreatePracticeSession(ctx, entity.PracticeSession{UserID: "user-1", CreatedAt: now})
+		require.NoError(t, err)
+
+		// Hold the write lock with another transaction while the answer is recorded.
+		tx, err := connection.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, `UPDATE user_rating SET rating = rating WHERE user_id = 'user-1'`)
+		require.NoError(t, err)
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := practiceStore.CreatePracticeAnswer(ctx, "user-1", entity.PracticeAnswer{
+				SessionID: sessionID, QuestionID: questionID, Correct: true, AnsweredAt: now,
+			}, 1500, raise)
+			errs <- err
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, tx.Commit())
+		require.NoError(t, <-errs)
+
+		userRating, err := practiceStore.GetUserRating(ctx, "user-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1501.0, userRating)
+	})
+
+	t.Run("Should reject question answered twice in the session and keep ratings", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		practiceStore := storage.NewPracticeStore(connection)
+
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+		sessionID, err := practiceStore.CreatePracticeSession(ctx, entity.PracticeSession{UserID: "user-1", CreatedAt: now})
+		require.NoError(t, err)
+
+		answer := entity.PracticeAnswer{SessionID: sessionID, QuestionID: questionID, Correct: true, AnsweredAt: now}
+
+		created, err := practiceStore.CreatePracticeAnswer(ctx, "user-1", answer, 1500, raise)
+		require.NoError(t, err)
+		assert.Equal(t, 1501.0, created.UserRating)
+
+		_, err = practiceStore.CreatePracticeAnswer(ctx, "user-1", answer, 1500, raise)
+		assert.True(t, errors.Is(err, service.ErrInvalidInput))
+
+		userRating, err := practiceStore.GetUserRating(ctx, "user-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1501.0, userRating)
+	})
+}
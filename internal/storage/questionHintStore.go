@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// Represents sqlite implementation of question hint storage.
+// Queries are scoped to the tenant of the context.
+type QuestionHintStore struct {
+	db *sql.DB
+}
+
+// NewQuestionHintStore creates a new instance of the QuestionHintStore.
+func NewQuestionHintStore(connection *sql.DB) *QuestionHintStore {
+	return &QuestionHintStore{db: connection}
+}
+
+// Retrieves hints for a question ordered by position from the database.
+func (store *QuestionHintStore) GetQuestionHints(ctx context.Context, questionID int) ([]entity.QuestionHint, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	questionHints := []entity.QuestionHint{}
+
+	rows, err := conn(ctx, store.db).QueryContext(ctx,
+		`SELECT id, question_id, position, body FROM question_hint
+		WHERE question_id = $1 AND tenant_id = $2
+		ORDER BY position`, questionID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting question hints from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		questionHint := entity.QuestionHint{}
+
+		err := rows.Scan(
+			&questionHint.ID,
+			&questionHint.QuestionID,
+			&questionHint.Position,
+			&questionHint.Body,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting question hints from database %w", err)
+		}
+
+		questionHints = append(questionHints, questionHint)
+	}
+
+	return questionHints, nil
+}
+
+// Creates a new question hint of the tenant at the position in the database.
+func (store *QuestionHintStore) CreateQuestionHint(ctx context.Context, questionID, position int, body string) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
+		`INSERT INTO question_hint (question_id, position, body, tenant_id)
+		VALUES ($1, $2, $3, $4)`, questionID, position, body, tenantID)
+	if err != nil {
+		return fmt.Errorf("error creating question hint in database %w", err)
+	}
+
+	return nil
+}
+
+// Deletes all hints of a question in the database.
+func (store *QuestionHintStore) DeleteQuestionHints(ctx context.Context, questionID int) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
+		`DELETE FROM question_hint
+		WHERE question_id = $1 AND tenant_id = $2`, questionID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete question hints %w", err)
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 )
 
 // Represents sqlite implementation of QuestionOption storage.
+// Queries are scoped to the tenant of the context.
 type QuestionOptionStore struct {
 	db *sql.DB
 }
@@ -24,11 +25,16 @@ func NewQuestionOptionStore(connection *sql.DB) *QuestionOptionStore {
 
 // Retrieves a list of options for a questions from the database.
 func (store *QuestionOptionStore) GetQuestionOptions(ctx context.Context, questionID int) ([]entity.QuestionOption, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	questionOptions := []entity.QuestionOption{}
 
-	rows, err := store.db.QueryContext(ctx,
-		`SELECT * FROM question_option
-		WHERE question_id = $1`, questionID)
+	rows, err := conn(ctx, store.db).QueryContext(ctx,
+		`SELECT id, body, correct, question_id, COALESCE(explanation, '') FROM question_option
+		WHERE question_id = $1 AND tenant_id = $2`, questionID, tenantID)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("error getting question options from db %w", err)
 	}
@@ -42,6 +48,7 @@ func (store *QuestionOptionStore) GetQuestionOptions(ctx context.Context, questi
 			&questionOption.Body,
 			&questionOption.Correct,
 			&questionOption.QuestionID,
+			&questionOption.Explanation,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting question options from database %w", err)
@@ -53,24 +60,40 @@ func (store *QuestionOptionStore) GetQuestionOptions(ctx context.Context, questi
 	return questionOptions, nil
 }
 
-// Creates a new QuestionOption in the database.
+// Creates a new QuestionOption in the database, the question must belong to the tenant.
 func (store *QuestionOptionStore) CreateQuestionOption(ctx context.Context,
 	questionID int, option service.QuestionOptionCreationDTO) error {
-	_, err := store.db.ExecContext(ctx,
-		`INSERT INTO question_option (body, correct, question_id)
-		VALUES ($1, $2, $3)`, option.Body, option.Correct, questionID)
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := conn(ctx, store.db).ExecContext(ctx,
+		`INSERT INTO question_option (body, correct, question_id, explanation, tenant_id)
+		SELECT $1, $2, id, $4, tenant_id FROM question WHERE id = $3 AND tenant_id = $5`,
+		option.Body, option.Correct, questionID, option.Explanation, tenantID)
 	if err != nil {
 		return fmt.Errorf("error creating question options in database %w", err)
 	}
 
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("error creating question options in database %w", service.ErrNotFound)
+	}
+
 	return nil
 }
 
 // Deletes a QuestionOption in the database by the question id.
 func (store *QuestionOptionStore) DeleteQuestionOptions(ctx context.Context, questionID int) error {
-	_, err := store.db.ExecContext(ctx,
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
 		`DELETE FROM question_option
-		WHERE question_id = $1`, questionID)
+		WHERE question_id = $1 AND tenant_id = $2`, questionID, tenantID)
 	if err != nil {
 		return fmt.Errorf("failed to delete question option %w", err)
 	}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// Represents sqlite implementation of question response storage.
+// Responses are stored within the tenant of the context.
+type QuestionResponseStore struct {
+	db *sql.DB
+}
+
+// NewQuestionResponseStore creates a new instance of the QuestionResponseStore.
+func NewQuestionResponseStore(connection *sql.DB) *QuestionResponseStore {
+	return &QuestionResponseStore{db: connection}
+}
+
+// Creates a new question response together with its selected options in the database.
+func (store *QuestionResponseStore) CreateQuestionResponse(ctx context.Context,
+	response entity.QuestionResponse) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating question response in database %w", err)
+	}
+	defer tx.Rollback()
+
+	var responseID int
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO question_response (question_id, correct, answered_at, duration_ms, tenant_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		response.QuestionID, response.Correct, response.AnsweredAt.UTC(),
+		nullableInt(int(response.Duration.Milliseconds())), tenantID).Scan(&responseID)
+	if err != nil {
+		return 0, fmt.Errorf("error creating question response in database %w", err)
+	}
+
+	for _, optionID := range response.OptionIDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO question_response_option (response_id, option_id)
+			VALUES ($1, $2)`, responseID, optionID)
+		if err != nil {
+			return 0, fmt.Errorf("error creating question response option in database %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("error creating question response in database %w", err)
+	}
+
+	return responseID, nil
+}
@@ -3,15 +3,23 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "modernc.org/sqlite"
 )
 
+// Columns selected for question, in the order expected by scanQuestion.
+const questionColumns = `id, body, COALESCE(explanation, ''), COALESCE(difficulty, 0),
+	empirical_difficulty, response_count, COALESCE(owner, '')`
+
 // Represents sqlite implementation of question storage.
+// Queries are scoped to the tenant of the context, except for the recalculation job which covers every tenant.
 type QuestionStore struct {
 	db *sql.DB
 }
@@ -21,25 +29,29 @@ func NewQuestionStore(connection *sql.DB) *QuestionStore {
 	return &QuestionStore{db: connection}
 }
 
-// Retrieves a list of questions from the database.
-func (store *QuestionStore) GetQuestions(ctx context.Context, pageSize, offset int) ([]entity.Question, error) {
+// Retrieves a list of questions matching the filter from the database.
+func (store *QuestionStore) GetQuestions(ctx context.Context,
+	filter service.QuestionFilter, pageSize, offset int) ([]entity.Question, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	questions := []entity.Question{}
 
-	rows, err := store.db.QueryContext(ctx,
-		`SELECT * FROM question 
-		  LIMIT $2 OFFSET $1`, offset, pageSize)
+	where, args := questionFilterClause(tenantID, filter)
+	args = append(args, pageSize, offset)
+
+	rows, err := conn(ctx, store.db).QueryContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM question %s
+		  LIMIT $%d OFFSET $%d`, questionColumns, where, len(args)-1, len(args)), args...)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("error getting questions from db %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		question := entity.Question{}
-
-		err := rows.Scan(
-			&question.ID,
-			&question.Body,
-		)
+		question, err := scanQuestion(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error getting questions from database %w", err)
 		}
@@ -52,11 +64,17 @@ func (store *QuestionStore) GetQuestions(ctx context.Context, pageSize, offset i
 
 // Retrieves a  question from database the id.
 func (store *QuestionStore) GetQuestionByID(ctx context.Context, questionID int) (entity.Question, error) {
-	question := entity.Question{}
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.Question{}, err
+	}
 
-	err := store.db.QueryRowContext(ctx,
-		`SELECT * FROM question WHERE id = $1`, questionID).
-		Scan(&question.ID, &question.Body)
+	question, err := scanQuestion(conn(ctx, store.db).QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM question WHERE id = $1 AND tenant_id = $2`, questionColumns),
+		questionID, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Question{}, fmt.Errorf("error getting question from db %w", service.ErrNotFound)
+	}
 	if err != nil {
 		return entity.Question{}, fmt.Errorf("error getting question from db %w", err)
 	}
@@ -64,13 +82,20 @@ func (store *QuestionStore) GetQuestionByID(ctx context.Context, questionID int)
 	return question, nil
 }
 
-// Creates a new question in the database.
-func (store *QuestionStore) CreateQuestion(ctx context.Context, body string) (int, error) {
+// Creates a new question owned by the caller in the database.
+func (store *QuestionStore) CreateQuestion(ctx context.Context,
+	question service.QuestionCreationDTO, owner string) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	var questionID int
 
-	err := store.db.QueryRowContext(ctx,
-		`INSERT INTO question (body)
-		VALUES ($1) RETURNING id`, body).Scan(&questionID)
+	err = conn(ctx, store.db).QueryRowContext(ctx,
+		`INSERT INTO question (body, explanation, difficulty, owner, tenant_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		question.Body, question.Explanation, nullableInt(question.Difficulty), owner, tenantID).Scan(&questionID)
 	if err != nil {
 		return 0, fmt.Errorf("error creating questions in database %w", err)
 	}
@@ -79,26 +104,130 @@ func (store *QuestionStore) CreateQuestion(ctx context.Context, body string) (in
 }
 
 // Updates a question in the database by the id.
-func (store *QuestionStore) UpdateQuestion(ctx context.Context, questionID int, body string) (int, error) {
-	res, err := store.db.ExecContext(ctx,
+func (store *QuestionStore) UpdateQuestion(ctx context.Context,
+	questionID int, question service.QuestionCreationDTO) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := conn(ctx, store.db).ExecContext(ctx,
 		`UPDATE question
-		SET body = $1 WHERE id = $2`, body, questionID)
-	n, _ := res.RowsAffected()
+		SET body = $1, explanation = $2, difficulty = $3 WHERE id = $4 AND tenant_id = $5`,
+		question.Body, question.Explanation, nullableInt(question.Difficulty), questionID, tenantID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update question %w", err)
 	}
+	n, _ := res.RowsAffected()
 
 	return int(n), nil
 }
 
 // Deletes a question in the database by the id.
 func (store *QuestionStore) DeleteQuestion(ctx context.Context, questionID int) error {
-	_, err := store.db.ExecContext(ctx,
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
 		`DELETE FROM question
-		WHERE id = $1`, questionID)
+		WHERE id = $1 AND tenant_id = $2`, questionID, tenantID)
 	if err != nil {
 		return fmt.Errorf("failed to delete question %w", err)
 	}
 
 	return nil
 }
+
+// Recalculates empirical difficulty of every question of every tenant as the proportion of correct responses.
+func (store *QuestionStore) RecalculateEmpiricalDifficulty(ctx context.Context) error {
+	_, err := conn(ctx, store.db).ExecContext(ctx,
+		`UPDATE question SET
+		empirical_difficulty = (SELECT AVG(r.correct) FROM question_response r WHERE r.question_id = question.id),
+		response_count = (SELECT COUNT(*) FROM question_response r WHERE r.question_id = question.id)`)
+	if err != nil {
+		return fmt.Errorf("failed to recalculate empirical difficulty %w", err)
+	}
+
+	return nil
+}
+
+// Retrieves id of the question not yet answered within the practice session
+// whose rating is the closest to the provided rating, unrated questions have the default rating.
+func (store *QuestionStore) GetNextPracticeQuestionID(ctx context.Context,
+	sessionID int, rating, defaultRating float64) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var questionID int
+
+	err = conn(ctx, store.db).QueryRowContext(ctx,
+		`SELECT q.id FROM question q
+		LEFT JOIN question_rating r ON r.question_id = q.id
+		WHERE q.tenant_id = $4
+		AND q.id NOT IN (SELECT a.question_id FROM practice_answer a WHERE a.session_id = $1)
+		ORDER BY ABS(COALESCE(r.rating, $3) - $2), q.id
+		LIMIT 1`, sessionID, rating, defaultRating, tenantID).Scan(&questionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("error getting next practice question from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error getting next practice question from db %w", err)
+	}
+
+	return questionID, nil
+}
+
+// scanQuestion scans a single question row selected with questionColumns.
+func scanQuestion(row scanner) (entity.Question, error) {
+	question := entity.Question{}
+	var empiricalDifficulty sql.NullFloat64
+
+	err := row.Scan(
+		&question.ID,
+		&question.Body,
+		&question.Explanation,
+		&question.Difficulty,
+		&empiricalDifficulty,
+		&question.ResponseCount,
+		&question.Owner,
+	)
+	if err != nil {
+		return entity.Question{}, err
+	}
+
+	if empiricalDifficulty.Valid {
+		question.EmpiricalDifficulty = &empiricalDifficulty.Float64
+	}
+
+	return question, nil
+}
+
+// questionFilterClause builds the where clause and its arguments for the filter within the tenant.
+func questionFilterClause(tenantID string, filter service.QuestionFilter) (string, []interface{}) {
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.MinDifficulty != nil {
+		add("difficulty >= $%d", *filter.MinDifficulty)
+	}
+	if filter.MaxDifficulty != nil {
+		add("difficulty <= $%d", *filter.MaxDifficulty)
+	}
+	if filter.MinEmpiricalDifficulty != nil {
+		add("empirical_difficulty >= $%d", *filter.MinEmpiricalDifficulty)
+	}
+	if filter.MaxEmpiricalDifficulty != nil {
+		add("empirical_difficulty <= $%d", *filter.MaxEmpiricalDifficulty)
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,216 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/database"
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initConnection connects to a freshly migrated database.
+func initConnection(t *testing.T) *sql.DB {
+	connection, err := database.Connect(context.Background(),
+		database.Config{DSN: filepath.Join(t.TempDir(), "homework.sqlite"), BusyTimeout: 5 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { (*sql.DB)(connection).Close() })
+
+	err = database.Migrate(connection, "../../migrations")
+	require.NoError(t, err)
+
+	return connection
+}
+
+// initStores creates question and option stores on a freshly migrated database.
+func initStores(t *testing.T) (*storage.QuestionStore, *storage.QuestionOptionStore) {
+	connection := initConnection(t)
+
+	return storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+}
+
+// createQuestion creates a question with a single correct option within the tenant of the context.
+func createQuestion(ctx context.Context, t *testing.T,
+	questionStore *storage.QuestionStore, optionStore *storage.QuestionOptionStore) int {
+	questionID, err := questionStore.CreateQuestion(ctx, service.QuestionCreationDTO{Body: "first-question"}, "author-1")
+	require.NoError(t, err)
+
+	err = optionStore.CreateQuestionOption(ctx, questionID,
+		service.QuestionOptionCreationDTO{Body: "first-option", Correct: true})
+	require.NoError(t, err)
+
+	return questionID
+}
+
+func TestQuestionStore_TenantIsolation(t *testing.T) {
+	schoolA := tenant.WithTenant(context.Background(), "school-a")
+	schoolB := tenant.WithTenant(context.Background(), "school-b")
+
+	t.Run("Should only list questions of the tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		questions, err := questionStore.GetQuestions(schoolA, service.QuestionFilter{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, questions, 1)
+		assert.Equal(t, questionID, questions[0].ID)
+
+		questions, err = questionStore.GetQuestions(schoolB, service.QuestionFilter{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, questions)
+	})
+
+	t.Run("Should not find question of another tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		_, err := questionStore.GetQuestionByID(schoolB, questionID)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		options, err := optionStore.GetQuestionOptions(schoolB, questionID)
+		assert.NoError(t, err)
+		assert.Empty(t, options)
+	})
+
+	t.Run("Should not update or delete question of another tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		rowsAffected, err := questionStore.UpdateQuestion(schoolB, questionID,
+			service.QuestionCreationDTO{Body: "second-question"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rowsAffected)
+
+		err = optionStore.DeleteQuestionOptions(schoolB, questionID)
+		assert.NoError(t, err)
+
+		err = questionStore.DeleteQuestion(schoolB, questionID)
+		assert.NoError(t, err)
+
+		question, err := questionStore.GetQuestionByID(schoolA, questionID)
+		assert.NoError(t, err)
+		assert.Equal(t, "first-question", question.Body)
+
+		options, err := optionStore.GetQuestionOptions(schoolA, questionID)
+		assert.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("Should not add option to question of another tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		err := optionStore.CreateQuestionOption(schoolB, questionID,
+			service.QuestionOptionCreationDTO{Body: "second-option"})
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		options, err := optionStore.GetQuestionOptions(schoolA, questionID)
+		assert.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("Should not pick practice question of another tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		nextID, err := questionStore.GetNextPracticeQuestionID(schoolA, 1, 1500, 1500)
+		assert.NoError(t, err)
+		assert.Equal(t, questionID, nextID)
+
+		_, err = questionStore.GetNextPracticeQuestionID(schoolB, 1, 1500, 1500)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+
+	t.Run("Should not find or delete attachment of another tenant", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		attachmentStore := storage.NewAttachmentStore(connection)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		attachmentID, err := attachmentStore.CreateAttachment(schoolA, entity.Attachment{
+			QuestionID: questionID, Filename: "first.png", ContentType: "image/png", Size: 1, StorageKey: "first-key",
+		})
+		require.NoError(t, err)
+
+		_, err = attachmentStore.GetAttachmentByID(schoolB, attachmentID)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		attachments, err := attachmentStore.GetAttachmentsByQuestionID(schoolB, questionID)
+		assert.NoError(t, err)
+		assert.Empty(t, attachments)
+
+		err = attachmentStore.DeleteAttachment(schoolB, attachmentID)
+		assert.NoError(t, err)
+
+		_, err = attachmentStore.GetAttachmentByID(schoolA, attachmentID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should not list hints of another tenant", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		hintStore := storage.NewQuestionHintStore(connection)
+		questionID := createQuestion(schoolA, t, questionStore, optionStore)
+
+		err := hintStore.CreateQuestionHint(schoolA, questionID, 1, "first-hint")
+		require.NoError(t, err)
+
+		hints, err := hintStore.GetQuestionHints(schoolB, questionID)
+		assert.NoError(t, err)
+		assert.Empty(t, hints)
+
+		hints, err = hintStore.GetQuestionHints(schoolA, questionID)
+		assert.NoError(t, err)
+		assert.Len(t, hints, 1)
+	})
+
+	t.Run("Should fail without a tenant", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+
+		_, err := questionStore.GetQuestions(context.Background(), service.QuestionFilter{}, 10, 0)
+		assert.True(t, errors.Is(err, storage.ErrMissingTenant))
+
+		_, err = optionStore.GetQuestionOptions(context.Background(), 1)
+		assert.True(t, errors.Is(err, storage.ErrMissingTenant))
+	})
+}
+
+func TestQuestionStore_IDs(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+
+	t.Run("Should not reuse ids of deleted questions and options", func(t *testing.T) {
+		questionStore, optionStore := initStores(t)
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+
+		options, err := optionStore.GetQuestionOptions(ctx, questionID)
+		assert.NoError(t, err)
+		require.Len(t, options, 1)
+
+		err = optionStore.DeleteQuestionOptions(ctx, questionID)
+		assert.NoError(t, err)
+
+		err = optionStore.CreateQuestionOption(ctx, questionID,
+			service.QuestionOptionCreationDTO{Body: "first-option", Correct: true})
+		assert.NoError(t, err)
+
+		replacedOptions, err := optionStore.GetQuestionOptions(ctx, questionID)
+		assert.NoError(t, err)
+		require.Len(t, replacedOptions, 1)
+		assert.Greater(t, replacedOptions[0].ID, options[0].ID)
+
+		err = questionStore.DeleteQuestion(ctx, questionID)
+		assert.NoError(t, err)
+
+		nextQuestionID, err := questionStore.CreateQuestion(ctx, service.QuestionCreationDTO{Body: "second-question"}, "author-1")
+		assert.NoError(t, err)
+		assert.Greater(t, nextQuestionID, questionID)
+	})
+}
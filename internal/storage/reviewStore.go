@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents sqlite implementation of review schedule storage.
+// Queries are scoped to the tenant of the context.
+type ReviewStore struct {
+	db *sql.DB
+}
+
+// NewReviewStore creates a new instance of the ReviewStore.
+func NewReviewStore(connection *sql.DB) *ReviewStore {
+	return &ReviewStore{db: connection}
+}
+
+// Retrieves review schedule of a question for the user from the database.
+func (store *ReviewStore) GetReviewSchedule(ctx context.Context,
+	userID string, questionID int) (entity.ReviewSchedule, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.ReviewSchedule{}, err
+	}
+
+	schedule, err := scanReviewSchedule(conn(ctx, store.db).QueryRowContext(ctx,
+		`SELECT user_id, question_id, ease_factor, interval_days, repetitions, due_at, last_reviewed_at
+		FROM review_schedule
+		WHERE user_id = $1 AND question_id = $2 AND tenant_id = $3`, userID, questionID, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ReviewSchedule{}, fmt.Errorf("error getting review schedule from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.ReviewSchedule{}, fmt.Errorf("error getting review schedule from db %w", err)
+	}
+
+	return schedule, nil
+}
+
+// Retrieves review schedules of the user which are due at the provided time, the most overdue first.
+// Schedules of questions which no longer exist are not returned.
+func (store *ReviewStore) GetDueReviewSchedules(ctx context.Context,
+	userID string, now time.Time, pageSize, offset int) ([]entity.ReviewSchedule, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []entity.ReviewSchedule{}
+
+	rows, err := conn(ctx, store.db).QueryContext(ctx,
+		`SELECT s.user_id, s.question_id, s.ease_factor, s.interval_days, s.repetitions, s.due_at, s.last_reviewed_at
+		FROM review_schedule s
+		JOIN question q ON q.id = s.question_id
+		WHERE s.tenant_id = $5 AND s.user_id = $1 AND s.due_at <= $2
+		ORDER BY s.due_at, s.question_id
+		LIMIT $3 OFFSET $4`, userID, now.UTC(), pageSize, offset, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting due review schedules from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		schedule, err := scanReviewSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error getting due review schedules from database %w", err)
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// Creates or replaces review schedule of a question for the user of the tenant in the database.
+func (store *ReviewStore) SaveReviewSchedule(ctx context.Context, schedule entity.ReviewSchedule) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
+		`INSERT INTO review_schedule
+		(user_id, question_id, ease_factor, interval_days, repetitions, due_at, last_reviewed_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, question_id) DO UPDATE SET
+		ease_factor = excluded.ease_factor,
+		interval_days = excluded.interval_days,
+		repetitions = excluded.repetitions,
+		due_at = excluded.due_at,
+		last_reviewed_at = excluded.last_reviewed_at`,
+		schedule.UserID,
+		schedule.QuestionID,
+		schedule.EaseFactor,
+		schedule.IntervalDays,
+		schedule.Repetitions,
+		schedule.DueAt.UTC(),
+		schedule.LastReviewedAt.UTC(),
+		tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving review schedule in database %w", err)
+	}
+
+	return nil
+}
+
+// Deletes review schedules of a question for every user in the database.
+func (store *ReviewStore) DeleteQuestionReviewSchedules(ctx context.Context, questionID int) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, store.db).ExecContext(ctx,
+		`DELETE FROM review_schedule
+		WHERE question_id = $1 AND tenant_id = $2`, questionID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete review schedules %w", err)
+	}
+
+	return nil
+}
+
+// scanReviewSchedule scans a single review schedule row.
+func scanReviewSchedule(row scanner) (entity.ReviewSchedule, error) {
+	schedule := entity.ReviewSchedule{}
+
+	err := row.Scan(
+		&schedule.UserID,
+		&schedule.QuestionID,
+		&schedule.EaseFactor,
+		&schedule.IntervalDays,
+		&schedule.Repetitions,
+		&schedule.DueAt,
+		&schedule.LastReviewedAt,
+	)
+
+	return schedule, err
+}
@@ -0,0 +1,66 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewStore_GetDueReviewSchedules(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should leave out schedules of deleted questions", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		reviewStore := storage.NewReviewStore(connection)
+
+		deletedQuestionID := createQuestion(ctx, t, questionStore, optionStore)
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+
+		for _, id := range []int{deletedQuestionID, questionID} {
+			err := reviewStore.SaveReviewSchedule(ctx, entity.ReviewSchedule{
+				UserID: "user-1", QuestionID: id, EaseFactor: 2.5, DueAt: now, LastReviewedAt: now,
+			})
+			require.NoError(t, err)
+		}
+
+		err := questionStore.DeleteQuestion(ctx, deletedQuestionID)
+		require.NoError(t, err)
+
+		schedules, err := reviewStore.GetDueReviewSchedules(ctx, "user-1", now, 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, schedules, 1)
+		assert.Equal(t, questionID, schedules[0].QuestionID)
+	})
+
+	t.Run("Should delete schedules of the question for every user", func(t *testing.T) {
+		connection := initConnection(t)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		reviewStore := storage.NewReviewStore(connection)
+
+		questionID := createQuestion(ctx, t, questionStore, optionStore)
+
+		for _, userID := range []string{"user-1", "user-2"} {
+			err := reviewStore.SaveReviewSchedule(ctx, entity.ReviewSchedule{
+				UserID: userID, QuestionID: questionID, EaseFactor: 2.5, DueAt: now, LastReviewedAt: now,
+			})
+			require.NoError(t, err)
+		}
+
+		err := reviewStore.DeleteQuestionReviewSchedules(ctx, questionID)
+		assert.NoError(t, err)
+
+		for _, userID := range []string{"user-1", "user-2"} {
+			schedules, err := reviewStore.GetDueReviewSchedules(ctx, userID, now, 10, 0)
+			assert.NoError(t, err)
+			assert.Empty(t, schedules)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Columns selected for leaderboard entry, in the order expected by scanLeaderboardEntry.
+const leaderboardColumns = `time_window, rank, player_name, run_id, score, total, duration_ms, submitted_at`
+
+// Represents sqlite implementation of run and leaderboard storage.
+// Queries are scoped to the tenant of the context.
+type RunStore struct {
+	db *sql.DB
+}
+
+// NewRunStore creates a new instance of the RunStore.
+func NewRunStore(connection *sql.DB) *RunStore {
+	return &RunStore{db: connection}
+}
+
+// Creates a new run in the database and refreshes the leaderboards of its tenant within the same transaction.
+// The leaderboard of every window is rebuilt from runs submitted since the window cutoff, every player is ranked
+// by the best score and ties are broken by the shorter duration.
+func (store *RunStore) CreateRun(ctx context.Context, run entity.Run, cutoffs map[string]time.Time) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating run in database %w", err)
+	}
+	defer tx.Rollback()
+
+	var runID int
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO run (player_name, score, total, duration_ms, submitted_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		run.PlayerName, run.Score, run.Total, run.Duration.Milliseconds(), run.SubmittedAt.UTC(), tenantID).
+		Scan(&runID)
+	if err != nil {
+		return 0, fmt.Errorf("error creating run in database %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM leaderboard WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("error refreshing leaderboards in database %w", err)
+	}
+
+	for window, cutoff := range cutoffs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO leaderboard (tenant_id, `+leaderboardColumns+`)
+			SELECT $1, $2, RANK() OVER (ORDER BY score DESC, duration_ms), player_name, id, score, total,
+			duration_ms, submitted_at
+			FROM (
+				SELECT id, player_name, score, total, duration_ms, submitted_at,
+				ROW_NUMBER() OVER (PARTITION BY player_name ORDER BY score DESC, duration_ms, id) AS position
+				FROM run
+				WHERE tenant_id = $1 AND submitted_at >= $3
+			)
+			WHERE position = 1`, tenantID, window, cutoff.UTC())
+		if err != nil {
+			return 0, fmt.Errorf("error refreshing %s leaderboard in database %w", window, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, fmt.Errorf("error creating run in database %w", err)
+	}
+
+	return runID, nil
+}
+
+// Retrieves a page of the leaderboard of the window ordered by rank.
+func (store *RunStore) GetLeaderboard(ctx context.Context,
+	window string, pageSize, offset int) ([]entity.LeaderboardEntry, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []entity.LeaderboardEntry{}
+
+	rows, err := store.db.QueryContext(ctx,
+		`SELECT `+leaderboardColumns+` FROM leaderboard
+		WHERE tenant_id = $1 AND time_window = $2
+		ORDER BY rank, player_name
+		LIMIT $3 OFFSET $4`, tenantID, window, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting leaderboard from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanLeaderboardEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error getting leaderboard from database %w", err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Retrieves the leaderboard entry of the player in the window.
+func (store *RunStore) GetLeaderboardEntry(ctx context.Context,
+	window, playerName string) (entity.LeaderboardEntry, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.LeaderboardEntry{}, err
+	}
+
+	entry, err := scanLeaderboardEntry(store.db.QueryRowContext(ctx,
+		`SELECT `+leaderboardColumns+` FROM leaderboard
+		WHERE tenant_id = $1 AND time_window = $2 AND player_name = $3`, tenantID, window, playerName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.LeaderboardEntry{}, fmt.Errorf("error getting leaderboard entry from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.LeaderboardEntry{}, fmt.Errorf("error getting leaderboard entry from db %w", err)
+	}
+
+	return entry, nil
+}
+
+// scanLeaderboardEntry scans a single leaderboard row selected with leaderboardColumns.
+func scanLeaderboardEntry(row scanner) (entity.LeaderboardEntry, error) {
+	entry := entity.LeaderboardEntry{}
+	var durationMs int64
+
+	err := row.Scan(
+		&entry.Window,
+		&entry.Rank,
+		&entry.PlayerName,
+		&entry.RunID,
+		&entry.Score,
+		&entry.Total,
+		&durationMs,
+		&entry.SubmittedAt,
+	)
+	entry.Duration = time.Duration(durationMs) * time.Millisecond
+
+	return entry, err
+}
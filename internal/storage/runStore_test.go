@@ -0,0 +1,116 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStore_GetLeaderboard(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// cutoffs returns cutoffs of the all time and day leaderboards refreshed by the run.
+	cutoffs := func(run entity.Run) map[string]time.Time {
+		return map[string]time.Time{"all": {}, "day": run.SubmittedAt.Add(-24 * time.Hour)}
+	}
+
+	createRuns := func(t *testing.T, runStore *storage.RunStore, runs ...entity.Run) {
+		for _, run := range runs {
+			_, err := runStore.CreateRun(ctx, run, cutoffs(run))
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("Should rank best runs of every player of all time", func(t *testing.T) {
+		runStore := storage.NewRunStore(initConnection(t))
+
+		createRuns(t, runStore,
+			entity.Run{PlayerName: "first", Score: 3, Total: 5, Duration: time.Minute, SubmittedAt: now.Add(-48 * time.Hour)},
+			entity.Run{PlayerName: "first", Score: 2, Total: 5, Duration: time.Second, SubmittedAt: now},
+			entity.Run{PlayerName: "second", Score: 3, Total: 5, Duration: 2 * time.Minute, SubmittedAt: now},
+			entity.Run{PlayerName: "second", Score: 3, Total: 5, Duration: 30 * time.Second, SubmittedAt: now},
+		)
+
+		entries, err := runStore.GetLeaderboard(ctx, "all", 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "second", entries[0].PlayerName)
+		assert.Equal(t, 30*time.Second, entries[0].Duration)
+		assert.Equal(t, "first", entries[1].PlayerName)
+		assert.Equal(t, 3, entries[1].Score)
+		assert.Equal(t, 2, entries[1].Rank)
+		assert.Equal(t, "all", entries[1].Window)
+	})
+
+	t.Run("Should rank runs within the window on each submission", func(t *testing.T) {
+		runStore := storage.NewRunStore(initConnection(t))
+
+		createRuns(t, runStore,
+			entity.Run{PlayerName: "second", Score: 3, Total: 5, Duration: time.Minute, SubmittedAt: now.Add(-22 * time.Hour)},
+			entity.Run{PlayerName: "first", Score: 5, Total: 5, Duration: time.Minute, SubmittedAt: now.Add(-20 * time.Hour)},
+			entity.Run{PlayerName: "first", Score: 1, Total: 5, Duration: time.Minute, SubmittedAt: now.Add(-2 * time.Hour)},
+		)
+
+		entries, err := runStore.GetLeaderboard(ctx, "day", 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "first", entries[0].PlayerName)
+		assert.Equal(t, 5, entries[0].Score)
+		assert.Equal(t, "day", entries[0].Window)
+
+		// Sixteen hours later the best run of first and the run of second left the window.
+		createRuns(t, runStore,
+			entity.Run{PlayerName: "third", Score: 2, Total: 5, Duration: time.Minute, SubmittedAt: now.Add(16 * time.Hour)},
+		)
+
+		entries, err = runStore.GetLeaderboard(ctx, "day", 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "third", entries[0].PlayerName)
+
+		entry, err := runStore.GetLeaderboardEntry(ctx, "day", "first")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, entry.Rank)
+		assert.Equal(t, 1, entry.Score)
+		assert.Equal(t, now.Add(-2*time.Hour), entry.SubmittedAt.UTC())
+
+		_, err = runStore.GetLeaderboardEntry(ctx, "day", "second")
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		entries, err = runStore.GetLeaderboard(ctx, "all", 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 3)
+	})
+
+	t.Run("Should rank runs of the tenant only", func(t *testing.T) {
+		runStore := storage.NewRunStore(initConnection(t))
+		schoolB := tenant.WithTenant(context.Background(), "school-b")
+
+		createRuns(t, runStore,
+			entity.Run{PlayerName: "first", Score: 3, Total: 5, Duration: time.Minute, SubmittedAt: now},
+		)
+
+		run := entity.Run{PlayerName: "first", Score: 5, Total: 5, Duration: time.Second, SubmittedAt: now}
+		_, err := runStore.CreateRun(schoolB, run, cutoffs(run))
+		require.NoError(t, err)
+
+		entries, err := runStore.GetLeaderboard(ctx, "all", 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 3, entries[0].Score)
+
+		entries, err = runStore.GetLeaderboard(schoolB, "day", 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, 5, entries[0].Score)
+	})
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+)
+
+// Represents sqlite implementation of storage which aggregates recorded responses.
+// Queries are scoped to the tenant of the context.
+type StatisticsStore struct {
+	db *sql.DB
+}
+
+// NewStatisticsStore creates a new instance of the StatisticsStore.
+func NewStatisticsStore(connection *sql.DB) *StatisticsStore {
+	return &StatisticsStore{db: connection}
+}
+
+// Retrieves attempts, correct responses and average duration per question from the database.
+// Questions without responses are not returned.
+func (store *StatisticsStore) GetResponseSummaries(ctx context.Context,
+	questionIDs []int) ([]entity.QuestionResponseSummary, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []entity.QuestionResponseSummary{}
+
+	in, args := inClause(questionIDs)
+	args = append(args, tenantID)
+
+	rows, err := store.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT question_id, COUNT(*), COALESCE(SUM(correct), 0), AVG(duration_ms)
+		FROM question_response
+		WHERE question_id IN (%s) AND tenant_id = $%d
+		GROUP BY question_id`, in, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting response summaries from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		summary := entity.QuestionResponseSummary{}
+		var averageDuration sql.NullFloat64
+
+		err := rows.Scan(
+			&summary.QuestionID,
+			&summary.Attempts,
+			&summary.CorrectCount,
+			&averageDuration,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting response summaries from database %w", err)
+		}
+
+		if averageDuration.Valid {
+			summary.AverageDurationMs = &averageDuration.Float64
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// Retrieves the number of selections of every option of the questions from the database,
+// options which were never selected are included with zero selections.
+func (store *StatisticsStore) GetOptionSelections(ctx context.Context,
+	questionIDs []int) ([]entity.OptionSelection, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selections := []entity.OptionSelection{}
+
+	in, args := inClause(questionIDs)
+	args = append(args, tenantID)
+
+	rows, err := store.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT o.question_id, o.id, o.body, o.correct, COUNT(ro.response_id)
+		FROM question_option o
+		LEFT JOIN question_response_option ro ON ro.option_id = o.id
+		WHERE o.question_id IN (%s) AND o.tenant_id = $%d
+		GROUP BY o.id
+		ORDER BY o.question_id, o.id`, in, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting option selections from db %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		selection := entity.OptionSelection{}
+
+		err := rows.Scan(
+			&selection.QuestionID,
+			&selection.OptionID,
+			&selection.Body,
+			&selection.Correct,
+			&selection.Selections,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting option selections from database %w", err)
+		}
+
+		selections = append(selections, selection)
+	}
+
+	return selections, nil
+}
+
+// inClause builds numbered placeholders and arguments for an IN clause.
+func inClause(ids []int) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+)
+
+// ErrMissingTenant is returned by tenant scoped stores when the context carries no tenant.
+var ErrMissingTenant = errors.New("tenant is missing from context")
+
+// tenantID returns the tenant the context is scoped to.
+func tenantID(ctx context.Context) (string, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", ErrMissingTenant
+	}
+
+	return tenantID, nil
+}
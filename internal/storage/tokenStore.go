@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents sqlite implementation of refresh token and revocation list storage.
+// Refresh tokens are created in the tenant of the context and looked up by their hash alone,
+// a rotated refresh token stays in the tenant of the one it replaces.
+type TokenStore struct {
+	db *sql.DB
+}
+
+// NewTokenStore creates a new instance of the TokenStore.
+func NewTokenStore(connection *sql.DB) *TokenStore {
+	return &TokenStore{db: connection}
+}
+
+// Creates a new refresh token in the database.
+func (store *TokenStore) CreateRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.db.ExecContext(ctx,
+		`INSERT INTO refresh_token (token_hash, user_id, family_id, issued_at, expires_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		token.TokenHash, token.UserID, token.FamilyID, token.IssuedAt.UTC(), token.ExpiresAt.UTC(), tenantID)
+	if err != nil {
+		return fmt.Errorf("error creating refresh token in database %w", err)
+	}
+
+	return nil
+}
+
+// Retrieves a refresh token from database by the token hash, of any tenant.
+func (store *TokenStore) GetRefreshToken(ctx context.Context, tokenHash string) (entity.RefreshToken, error) {
+	token := entity.RefreshToken{}
+	var revokedAt sql.NullTime
+
+	err := store.db.QueryRowContext(ctx,
+		`SELECT token_hash, user_id, family_id, issued_at, expires_at, revoked_at, tenant_id
+		FROM refresh_token WHERE token_hash = $1`, tokenHash).
+		Scan(&token.TokenHash, &token.UserID, &token.FamilyID, &token.IssuedAt, &token.ExpiresAt, &revokedAt,
+			&token.TenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RefreshToken{}, fmt.Errorf("error getting refresh token from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.RefreshToken{}, fmt.Errorf("error getting refresh token from db %w", err)
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return token, nil
+}
+
+// Revokes the refresh token and creates the one replacing it in the same transaction.
+// Returns not found error if the refresh token was already revoked.
+func (store *TokenStore) RotateRefreshToken(ctx context.Context,
+	tokenHash string, replacement entity.RefreshToken) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error rotating refresh token in database %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE refresh_token SET revoked_at = $1
+		WHERE token_hash = $2 AND revoked_at IS NULL`, replacement.IssuedAt.UTC(), tokenHash)
+	if err != nil {
+		return fmt.Errorf("error rotating refresh token in database %w", err)
+	}
+
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("error rotating refresh token in database %w", service.ErrNotFound)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO refresh_token (token_hash, user_id, family_id, issued_at, expires_at, tenant_id)
+		SELECT $1, $2, $3, $4, $5, tenant_id FROM refresh_token WHERE token_hash = $6`,
+		replacement.TokenHash, replacement.UserID, replacement.FamilyID,
+		replacement.IssuedAt.UTC(), replacement.ExpiresAt.UTC(), tokenHash)
+	if err != nil {
+		return fmt.Errorf("error rotating refresh token in database %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error rotating refresh token in database %w", err)
+	}
+
+	return nil
+}
+
+// Revokes every refresh token of the family which isn't revoked yet.
+func (store *TokenStore) RevokeRefreshTokenFamily(ctx context.Context, familyID string, now time.Time) error {
+	_, err := store.db.ExecContext(ctx,
+		`UPDATE refresh_token SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL`, now.UTC(), familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens %w", err)
+	}
+
+	return nil
+}
+
+// Adds the access token to the revocation list.
+func (store *TokenStore) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := store.db.ExecContext(ctx,
+		`INSERT INTO revoked_access_token (jti, expires_at) VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING`, jti, expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token %w", err)
+	}
+
+	return nil
+}
+
+// Checks whether the access token is on the revocation list.
+func (store *TokenStore) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+
+	err := store.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM revoked_access_token WHERE jti = $1)`, jti).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("error getting revoked access token from db %w", err)
+	}
+
+	return revoked, nil
+}
+
+// Deletes expired refresh tokens and revocation list entries of expired access tokens.
+func (store *TokenStore) DeleteExpiredTokens(ctx context.Context, now time.Time) error {
+	_, err := store.db.ExecContext(ctx,
+		`DELETE FROM refresh_token WHERE expires_at < $1`, now.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired refresh tokens %w", err)
+	}
+
+	_, err = store.db.ExecContext(ctx,
+		`DELETE FROM revoked_access_token WHERE expires_at < $1`, now.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired revoked access tokens %w", err)
+	}
+
+	return nil
+}
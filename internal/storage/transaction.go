@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// querier is implemented by both sql.DB and the sql.Conn of a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txContextKey struct{}
+
+// Represents sqlite implementation of transactions spanning several stores.
+// The transaction is carried by the context, stores run their queries within it through conn.
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new instance of the Transactor.
+func NewTransactor(connection *sql.DB) *Transactor {
+	return &Transactor{db: connection}
+}
+
+// WithinTransaction runs fn within a transaction which is committed when fn succeeds and rolled back otherwise.
+// The transaction takes the write lock when it begins, as one which reads first can't take it
+// once another write committed in the meantime. Calls nested within fn join the transaction of the context.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Conn); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction %w", err)
+	}
+	defer tx.Close()
+
+	_, err = tx.ExecContext(ctx, "BEGIN IMMEDIATE")
+	if err != nil {
+		return fmt.Errorf("error starting transaction %w", err)
+	}
+
+	err = fn(context.WithValue(ctx, txContextKey{}, tx))
+	if err != nil {
+		rollback(tx)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "COMMIT")
+	if err != nil {
+		rollback(tx)
+		return fmt.Errorf("error committing transaction %w", err)
+	}
+
+	return nil
+}
+
+// rollback rolls back the transaction of the connection, the connection is discarded
+// instead of being reused when that fails.
+func rollback(tx *sql.Conn) {
+	_, err := tx.ExecContext(context.Background(), "ROLLBACK")
+	if err != nil {
+		_ = tx.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+}
+
+// conn returns the transaction carried by the context, or the database when there is none.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Conn); ok {
+		return tx
+	}
+
+	return db
+}
@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactor_WithinTransaction(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+
+	t.Run("Should commit writes of every store", func(t *testing.T) {
+		connection := initConnection(t)
+		transactor := storage.NewTransactor(connection)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+
+		var questionID int
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			questionID = createQuestion(ctx, t, questionStore, optionStore)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		options, err := optionStore.GetQuestionOptions(ctx, questionID)
+		assert.NoError(t, err)
+		assert.Len(t, options, 1)
+	})
+
+	t.Run("Should roll back writes of every store when the function fails", func(t *testing.T) {
+		connection := initConnection(t)
+		transactor := storage.NewTransactor(connection)
+		questionStore, optionStore := storage.NewQuestionStore(connection), storage.NewQuestionOptionStore(connection)
+		someErr := errors.New("some-error")
+
+		var questionID int
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			questionID = createQuestion(ctx, t, questionStore, optionStore)
+
+			// Nested calls join the transaction, so their writes are rolled back as well.
+			return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				createQuestion(ctx, t, questionStore, optionStore)
+				return someErr
+			})
+		})
+		assert.True(t, errors.Is(err, someErr))
+
+		_, err = questionStore.GetQuestionByID(ctx, questionID)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		questions, err := questionStore.GetQuestions(ctx, service.QuestionFilter{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, questions)
+
+		options, err := optionStore.GetQuestionOptions(ctx, questionID)
+		assert.NoError(t, err)
+		assert.Empty(t, options)
+	})
+
+	t.Run("Should run concurrent transactions which read before writing one after another", func(t *testing.T) {
+		connection := initConnection(t)
+		transactor := storage.NewTransactor(connection)
+		questionStore := storage.NewQuestionStore(connection)
+
+		const transactions = 2
+		wg := sync.WaitGroup{}
+		errs := make(chan error, transactions)
+
+		for i := 0; i < transactions; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+					_, err := questionStore.GetQuestions(ctx, service.QuestionFilter{}, 10, 0)
+					if err != nil {
+						return err
+					}
+
+					// Give the other transaction time to read and write in between.
+					time.Sleep(50 * time.Millisecond)
+
+					_, err = questionStore.CreateQuestion(ctx, service.QuestionCreationDTO{Body: "question"}, "author-1")
+					return err
+				})
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+
+		questions, err := questionStore.GetQuestions(ctx, service.QuestionFilter{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, questions, transactions)
+	})
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Represents sqlite implementation of user and session storage.
+// Users and sessions are created in the tenant of the context and users are looked up within it,
+// sessions are looked up by their token alone and carry the tenant of their user.
+type UserStore struct {
+	db *sql.DB
+}
+
+// NewUserStore creates a new instance of the UserStore.
+func NewUserStore(connection *sql.DB) *UserStore {
+	return &UserStore{db: connection}
+}
+
+// Creates a new user in the database.
+func (store *UserStore) CreateUser(ctx context.Context, user entity.User) (int, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var userID int
+
+	err = store.db.QueryRowContext(ctx,
+		`INSERT INTO user_account (username, password_hash, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4) RETURNING id`,
+		user.Username, user.PasswordHash, user.CreatedAt.UTC(), tenantID).Scan(&userID)
+	if isUniqueViolation(err) {
+		return 0, fmt.Errorf("user %s %w", user.Username, service.ErrConflict)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error creating user in database %w", err)
+	}
+
+	return userID, nil
+}
+
+// Retrieves a user from database by the id.
+func (store *UserStore) GetUserByID(ctx context.Context, userID int) (entity.User, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	user, err := scanUser(store.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, created_at, tenant_id FROM user_account
+		WHERE id = $1 AND tenant_id = $2`, userID, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.User{}, fmt.Errorf("error getting user from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.User{}, fmt.Errorf("error getting user from db %w", err)
+	}
+
+	return user, nil
+}
+
+// Retrieves a user from database by the username.
+func (store *UserStore) GetUserByUsername(ctx context.Context, username string) (entity.User, error) {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	user, err := scanUser(store.db.QueryRowContext(ctx,
+		`SELECT id, username, password_hash, created_at, tenant_id FROM user_account
+		WHERE username = $1 AND tenant_id = $2`, username, tenantID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.User{}, fmt.Errorf("error getting user from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.User{}, fmt.Errorf("error getting user from db %w", err)
+	}
+
+	return user, nil
+}
+
+// Creates a new session in the database.
+func (store *UserStore) CreateSession(ctx context.Context, session entity.UserSession) error {
+	tenantID, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.db.ExecContext(ctx,
+		`INSERT INTO user_session (token_hash, user_id, created_at, expires_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)`,
+		session.TokenHash, session.UserID, session.CreatedAt.UTC(), session.ExpiresAt.UTC(), tenantID)
+	if err != nil {
+		return fmt.Errorf("error creating session in database %w", err)
+	}
+
+	return nil
+}
+
+// Retrieves a session from database by the token hash, of any tenant.
+func (store *UserStore) GetSession(ctx context.Context, tokenHash string) (entity.UserSession, error) {
+	session := entity.UserSession{}
+
+	err := store.db.QueryRowContext(ctx,
+		`SELECT token_hash, user_id, created_at, expires_at, tenant_id FROM user_session
+		WHERE token_hash = $1`, tokenHash).
+		Scan(&session.TokenHash, &session.UserID, &session.CreatedAt, &session.ExpiresAt, &session.TenantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.UserSession{}, fmt.Errorf("error getting session from db %w", service.ErrNotFound)
+	}
+	if err != nil {
+		return entity.UserSession{}, fmt.Errorf("error getting session from db %w", err)
+	}
+
+	return session, nil
+}
+
+// Deletes a session in the database by the token hash.
+func (store *UserStore) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := store.db.ExecContext(ctx,
+		`DELETE FROM user_session WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete session %w", err)
+	}
+
+	return nil
+}
+
+// scanUser scans a single user row.
+func scanUser(row scanner) (entity.User, error) {
+	user := entity.User{}
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.TenantID,
+	)
+
+	return user, err
+}
+
+// Deletes sessions which expired before now.
+func (store *UserStore) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	_, err := store.db.ExecContext(ctx,
+		`DELETE FROM user_session WHERE expires_at < $1`, now.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired sessions %w", err)
+	}
+
+	return nil
+}
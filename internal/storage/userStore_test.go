@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/entity"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/djurica-surla/backend-homework/internal/storage"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserStore_CreateUser(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should return conflict for taken username", func(t *testing.T) {
+		userStore := storage.NewUserStore(initConnection(t))
+
+		_, err := userStore.CreateUser(ctx, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		require.NoError(t, err)
+
+		_, err = userStore.CreateUser(ctx, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		assert.True(t, errors.Is(err, service.ErrConflict))
+	})
+
+	t.Run("Should keep usernames of every tenant apart", func(t *testing.T) {
+		userStore := storage.NewUserStore(initConnection(t))
+		schoolB := tenant.WithTenant(context.Background(), "school-b")
+
+		_, err := userStore.CreateUser(ctx, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		require.NoError(t, err)
+
+		_, err = userStore.GetUserByUsername(schoolB, "student")
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		userID, err := userStore.CreateUser(schoolB, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		require.NoError(t, err)
+
+		user, err := userStore.GetUserByID(schoolB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "school-b", user.TenantID)
+
+		_, err = userStore.GetUserByID(ctx, userID)
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+	})
+}
+
+func TestUserStore_GetSession(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should find session of any tenant with its tenant", func(t *testing.T) {
+		userStore := storage.NewUserStore(initConnection(t))
+
+		userID, err := userStore.CreateUser(ctx, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		require.NoError(t, err)
+
+		err = userStore.CreateSession(ctx, entity.UserSession{
+			TokenHash: "token", UserID: userID, CreatedAt: now, ExpiresAt: now.Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		session, err := userStore.GetSession(context.Background(), "token")
+		assert.NoError(t, err)
+		assert.Equal(t, "school-a", session.TenantID)
+	})
+}
+
+func TestUserStore_DeleteExpiredSessions(t *testing.T) {
+	ctx := tenant.WithTenant(context.Background(), "school-a")
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Should only delete expired sessions", func(t *testing.T) {
+		userStore := storage.NewUserStore(initConnection(t))
+
+		userID, err := userStore.CreateUser(ctx, entity.User{Username: "student", PasswordHash: "hash", CreatedAt: now})
+		require.NoError(t, err)
+
+		for tokenHash, expiresAt := range map[string]time.Time{"expired": now.Add(-time.Minute), "valid": now.Add(time.Hour)} {
+			err := userStore.CreateSession(ctx, entity.UserSession{
+				TokenHash: tokenHash, UserID: userID, CreatedAt: now.Add(-time.Hour), ExpiresAt: expiresAt,
+			})
+			require.NoError(t, err)
+		}
+
+		err = userStore.DeleteExpiredSessions(ctx, now)
+		assert.NoError(t, err)
+
+		_, err = userStore.GetSession(ctx, "expired")
+		assert.True(t, errors.Is(err, service.ErrNotFound))
+
+		_, err = userStore.GetSession(ctx, "valid")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,19 @@
+package tenant
+
+import "context"
+
+// Default is the tenant of data created before tenants were introduced.
+const Default = "default"
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of the context which carries the tenant id.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// FromContext returns the tenant id carried by the context, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// Exporters spans can be sent to.
+const (
+	// Drops spans, trace context is still propagated.
+	ExporterNone = "none"
+	// Writes spans as JSON to stdout.
+	ExporterStdout = "stdout"
+	// Writes spans as JSON to a file.
+	ExporterFile = "file"
+	// Sends spans to an OTLP/HTTP collector.
+	ExporterOTLP = "otlp"
+)
+
+// How long flushing buffered spans may take on close.
+const closeTimeout = 5 * time.Second
+
+// ErrUnknownExporter is returned when the configured exporter isn't supported.
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
+// Config for exporting spans.
+type Config struct {
+	ServiceName  string
+	Exporter     string
+	File         string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	// Fraction of traces started by this service which are sampled, parents decide for propagated traces.
+	SampleRatio float64
+}
+
+// Tracing owns the tracer provider installed as the global one, close it to flush buffered spans.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	file     io.Closer
+}
+
+// Setup installs W3C trace context propagation and a global tracer provider exporting to the configured exporter.
+func Setup(ctx context.Context, cfg Config) (*Tracing, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Exporter == ExporterNone {
+		return &Tracing{}, nil
+	}
+
+	tracing := &Tracing{}
+
+	exporter, err := tracing.newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tracing.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(tracing.provider)
+
+	return tracing, nil
+}
+
+// newExporter creates the configured exporter, keeping the file it writes to for closing.
+func (t *Tracing) newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterFile:
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("error opening trace file: %w", err)
+		}
+		t.file = file
+
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+	case ExporterOTLP:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		return otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
+// Close flushes buffered spans and shuts the exporter down.
+func (t *Tracing) Close() error {
+	if t.provider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	err := t.provider.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("error shutting down tracer provider: %w", err)
+	}
+
+	if t.file != nil {
+		return t.file.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("Should export spans to the file and continue propagated traces", func(t *testing.T) {
+		ctx := context.Background()
+		file := filepath.Join(t.TempDir(), "traces.json")
+
+		tracer, err := tracing.Setup(ctx, tracing.Config{
+			ServiceName: "backend-homework",
+			Exporter:    tracing.ExporterFile,
+			File:        file,
+			SampleRatio: 1,
+		})
+		require.NoError(t, err)
+
+		header := http.Header{}
+		header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+
+		_, span := otel.Tracer("test").Start(ctx, "first-span")
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		span.End()
+
+		err = tracer.Close()
+		require.NoError(t, err)
+
+		spans, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Contains(t, string(spans), `"Name":"first-span"`)
+		assert.Contains(t, string(spans), "4bf92f3577b34da6a3ce929d0e0e4736")
+	})
+
+	t.Run("Should propagate trace context without exporting spans", func(t *testing.T) {
+		tracer, err := tracing.Setup(context.Background(), tracing.Config{Exporter: tracing.ExporterNone})
+		require.NoError(t, err)
+		assert.NoError(t, tracer.Close())
+
+		spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: trace.FlagsSampled,
+		})
+		header := http.Header{}
+		otel.GetTextMapPropagator().Inject(trace.ContextWithSpanContext(context.Background(), spanContext),
+			propagation.HeaderCarrier(header))
+		assert.Equal(t, "00-01000000000000000000000000000000-0100000000000000-01", header.Get("traceparent"))
+	})
+
+	t.Run("Should fail for unknown exporter", func(t *testing.T) {
+		tracer, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "jaeger"})
+		assert.Nil(t, tracer)
+		assert.True(t, errors.Is(err, tracing.ErrUnknownExporter))
+	})
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler, every route requires the admin token.
+func (h *APIKeyHandler) RegisterRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin").Subrouter()
+	admin.Use(newAdminMiddleware(h.adminToken))
+
+	admin.HandleFunc("/api-keys", h.GetAPIKeys()).Methods(http.MethodGet)
+	admin.HandleFunc("/api-keys", h.CreateAPIKey()).Methods(http.MethodPost)
+	admin.HandleFunc("/api-keys/{id}", h.DeleteAPIKey()).Methods(http.MethodDelete)
+}
+
+// APIKeyServicer represents necessary api key service implementation for api key handler.
+type APIKeyServicer interface {
+	CreateAPIKey(ctx context.Context, keyCreation service.APIKeyCreationDTO) (service.CreatedAPIKeyDTO, error)
+	GetAPIKeys(ctx context.Context) ([]service.APIKeyDTO, error)
+	DeleteAPIKey(ctx context.Context, keyID int) error
+}
+
+// APIKeyHandler handles admin http requests for managing api keys.
+type APIKeyHandler struct {
+	apiKeyService APIKeyServicer
+	adminToken    string
+	maxBodySize   int64
+}
+
+// NewAPIKeyHandler creates a new instance of api key handler.
+func NewAPIKeyHandler(apiKeyService APIKeyServicer, adminToken string, maxBodySize int64) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		adminToken:    adminToken,
+		maxBodySize:   maxBodySize,
+	}
+}
+
+// GetAPIKeys handles retrieving every api key.
+func (h *APIKeyHandler) GetAPIKeys() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := h.apiKeyService.GetAPIKeys(r.Context())
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// CreateAPIKey handles creation of a new api key.
+func (h *APIKeyHandler) CreateAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keyCreationDTO := service.APIKeyCreationDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &keyCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(keyCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.apiKeyService.CreateAPIKey(r.Context(), keyCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// DeleteAPIKey handles deletion of an api key.
+func (h *APIKeyHandler) DeleteAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keyID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		err = h.apiKeyService.DeleteAPIKey(r.Context(), keyID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
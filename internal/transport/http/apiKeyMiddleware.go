@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/gorilla/mux"
+)
+
+// APIKeyAuthenticator represents necessary api key service implementation for api key middleware.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (auth.APIKey, error)
+}
+
+// NewAPIKeyMiddleware creates a middleware which resolves the api key of the "ApiKey" authorization
+// scheme into the request context. Requests using another scheme are left to the other middlewares.
+func NewAPIKeyMiddleware(authenticator APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawKey, ok, err := authorizationToken(r, "ApiKey")
+			if err != nil {
+				encodeError(w, http.StatusUnauthorized, err)
+				return
+			}
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := authenticator.AuthenticateAPIKey(r.Context(), rawKey)
+			if err != nil {
+				encodeServiceError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithAPIKey(r.Context(), key)))
+		})
+	}
+}
+
+// newAdminMiddleware creates a middleware which only lets through requests carrying the admin token.
+// Admin routes are disabled when no admin token is configured.
+func newAdminMiddleware(adminToken string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !validAdminToken(r, adminToken) {
+				encodeError(w, http.StatusForbidden, errors.New("admin token required"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validAdminToken reports whether the request carries the admin token, which is never valid when empty.
+func validAdminToken(r *http.Request, adminToken string) bool {
+	token := r.Header.Get("X-Admin-Token")
+
+	return adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
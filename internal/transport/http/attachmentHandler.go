@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// Size of multipart form overhead allowed on top of the attachment size limit.
+const multipartOverhead = 1 << 20
+
+// RegisterRoutes links routes with the handler.
+func (h *AttachmentHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/questions/{id}/attachments", h.CreateAttachment()).Methods(http.MethodPost)
+	router.HandleFunc("/questions/{id}/options/{optionID}/attachments", h.CreateAttachment()).Methods(http.MethodPost)
+	router.HandleFunc("/attachments/{id}", h.GetAttachment()).Methods(http.MethodGet)
+	router.HandleFunc("/attachments/{id}", h.DeleteAttachment()).Methods(http.MethodDelete)
+}
+
+// AttachmentServicer represents necessary attachment service implementation for attachment handler.
+type AttachmentServicer interface {
+	CreateAttachment(ctx context.Context, questionID, optionID int, filename string, content io.Reader) (service.AttachmentDTO, error)
+	GetAttachmentContent(ctx context.Context, attachmentID int) (service.AttachmentDTO, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, attachmentID int) error
+}
+
+// AttachmentHandler handles http requests for question and option attachments.
+type AttachmentHandler struct {
+	attachmentService AttachmentServicer
+	maxSize           int64
+}
+
+// NewAttachmentHandler creates a new instance of attachment handler.
+func NewAttachmentHandler(attachmentService AttachmentServicer, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxSize:           maxSize,
+	}
+}
+
+// CreateAttachment handles multipart upload of an attachment sent in the "file" field.
+func (h *AttachmentHandler) CreateAttachment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		optionID := 0
+		if value, ok := mux.Vars(r)["optionID"]; ok {
+			optionID, err = parseID(value)
+			if err != nil {
+				encodeError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if r.ContentLength > h.maxSize+multipartOverhead {
+			encodeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("attachment exceeds %d bytes", h.maxSize))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+		defer file.Close()
+
+		res, err := h.attachmentService.CreateAttachment(r.Context(), questionID, optionID, header.Filename, file)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetAttachment handles serving attachment content.
+func (h *AttachmentHandler) GetAttachment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		attachmentID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		attachment, content, err := h.attachmentService.GetAttachmentContent(r.Context(), attachmentID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", attachment.Filename))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		io.Copy(w, content)
+	}
+}
+
+// DeleteAttachment handles deleting of attachments.
+func (h *AttachmentHandler) DeleteAttachment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		attachmentID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		err = h.attachmentService.DeleteAttachment(r.Context(), attachmentID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode("successfully deleted attachment")
+	}
+}
+
+// parseID converts a path variable into an id.
+func parseID(value string) (int, error) {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/gorilla/mux"
+)
+
+// Authenticator represents necessary user service implementation for auth middleware.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (auth.User, error)
+}
+
+// AccessTokenVerifier represents necessary token service implementation for jwt middleware.
+type AccessTokenVerifier interface {
+	VerifyAccessToken(ctx context.Context, token string) (auth.Claims, error)
+}
+
+// NewAuthMiddleware creates a middleware which resolves the user of the session bearer token into the request context.
+// Requests without a token or already authenticated by a jwt pass through, requests with an invalid token are rejected.
+func NewAuthMiddleware(authenticator Authenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.UserFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok, err := bearerToken(r)
+			if err != nil {
+				encodeError(w, http.StatusUnauthorized, err)
+				return
+			}
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := authenticator.Authenticate(r.Context(), token)
+			if err != nil {
+				encodeServiceError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithUser(r.Context(), user)))
+		})
+	}
+}
+
+// NewJWTMiddleware creates a middleware which validates jwt bearer tokens and puts their claims
+// and user into the request context. Requests without a jwt are left to the following middlewares.
+func NewJWTMiddleware(verifier AccessTokenVerifier) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok, err := bearerToken(r)
+			if err != nil || !ok || !isJWT(token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := verifier.VerifyAccessToken(r.Context(), token)
+			if err != nil {
+				encodeServiceError(w, err)
+				return
+			}
+
+			user, err := claims.User()
+			if err != nil {
+				encodeError(w, http.StatusUnauthorized, err)
+				return
+			}
+
+			ctx := auth.WithClaims(r.Context(), claims)
+			ctx = auth.WithUser(ctx, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// isJWT reports whether the token has the shape of a jwt, session tokens never contain dots.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// bearerToken extracts the bearer token from the authorization header, if present.
+func bearerToken(r *http.Request) (string, bool, error) {
+	return authorizationToken(r, "Bearer")
+}
+
+// authorizationToken extracts the credentials of the scheme from the authorization header.
+// Missing header or another scheme is reported as not present, empty credentials as an error.
+func authorizationToken(r *http.Request, scheme string) (string, bool, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", false, nil
+	}
+
+	headerScheme, token, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(headerScheme, scheme) {
+		return "", false, nil
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false, fmt.Errorf("authorization header is missing the %s credentials", scheme)
+	}
+
+	return token, true, nil
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings the compression middleware supports.
+const (
+	EncodingZstd    = "zstd"
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+// compressor is the writer of a content coding.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// newCompressorPool returns a pool of compressors of the content coding.
+func newCompressorPool(encoding string) (*sync.Pool, error) {
+	switch encoding {
+	case EncodingZstd:
+		return &sync.Pool{New: func() interface{} {
+			// Responses are small and written by a single goroutine, a lower memory encoder suits them.
+			encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+			return encoder
+		}}, nil
+	case EncodingGzip:
+		return &sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}, nil
+	case EncodingDeflate:
+		// The deflate content coding is the zlib format.
+		return &sync.Pool{New: func() interface{} { return zlib.NewWriter(nil) }}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content coding %s", encoding)
+	}
+}
+
+// compressibleTypes are the content types, besides text, which are worth compressing.
+var compressibleTypes = map[string]bool{
+	"application/json":      true,
+	"application/xml":       true,
+	"application/yaml":      true,
+	"application/x-yaml":    true,
+	"application/msgpack":   true,
+	"application/x-msgpack": true,
+	"image/svg+xml":         true,
+}
+
+// isCompressible reports whether responses of the content type are worth compressing,
+// images, video and archives are compressed already.
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(mediaType, "text/") || compressibleTypes[mediaType]
+}
+
+// NewCompressionMiddleware creates a middleware which compresses responses with the content coding
+// the Accept-Encoding header prefers, ties go to the order of encodings. Responses smaller than
+// minSize bytes or of content types which don't compress are sent as they are.
+func NewCompressionMiddleware(minSize int, encodings ...string) (mux.MiddlewareFunc, error) {
+	pools := map[string]*sync.Pool{}
+	for _, encoding := range encodings {
+		pool, err := newCompressorPool(encoding)
+		if err != nil {
+			return nil, err
+		}
+		pools[encoding] = pool
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			entries := parseAccept(r.Header.Get("Accept-Encoding"))
+
+			encoding, bestQ := "", 0.0
+			for _, candidate := range encodings {
+				if q := encodingQuality(entries, candidate); q > bestQ {
+					encoding, bestQ = candidate, q
+				}
+			}
+
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				pool:           pools[encoding],
+				minSize:        minSize,
+				status:         http.StatusOK,
+			}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}, nil
+}
+
+// compressWriter buffers the response until it's known to be large enough to compress,
+// then compresses the rest of it as it's written.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	pool     *sync.Pool
+	minSize  int
+
+	status      int
+	wroteHeader bool
+	buf         []byte
+	// Set once it's decided whether the response is compressed, the header is written then.
+	decided    bool
+	compressor compressor
+}
+
+// WriteHeader holds the status code back until it's decided whether the response is compressed.
+func (c *compressWriter) WriteHeader(status int) {
+	if c.wroteHeader {
+		return
+	}
+	c.status = status
+	c.wroteHeader = true
+}
+
+// Write buffers the data until minSize bytes are written, then compresses it.
+func (c *compressWriter) Write(p []byte) (int, error) {
+	c.wroteHeader = true
+
+	if !c.decided {
+		c.buf = append(c.buf, p...)
+		if len(c.buf) < c.minSize {
+			return len(p), nil
+		}
+
+		err := c.decide(true)
+		return len(p), err
+	}
+
+	if c.compressor != nil {
+		return c.compressor.Write(p)
+	}
+
+	return c.ResponseWriter.Write(p)
+}
+
+// Flush sends what's written so far, compressing it when the response is compressed.
+func (c *compressWriter) Flush() {
+	if !c.decided {
+		c.decide(true)
+	}
+	if c.compressor != nil {
+		c.compressor.Flush()
+	}
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close sends a response which stayed smaller than minSize and finishes the compressed stream.
+func (c *compressWriter) Close() error {
+	if !c.decided {
+		return c.decide(false)
+	}
+	if c.compressor == nil {
+		return nil
+	}
+
+	err := c.compressor.Close()
+	c.pool.Put(c.compressor)
+	c.compressor = nil
+
+	return err
+}
+
+// decide writes the header, compressing the response when it's large enough and its content type compresses,
+// and then writes the buffered data.
+func (c *compressWriter) decide(largeEnough bool) error {
+	c.decided = true
+
+	header := c.Header()
+	if header.Get("Content-Type") == "" && len(c.buf) > 0 {
+		// Sniff the content type here, net/http would sniff the compressed data instead.
+		header.Set("Content-Type", http.DetectContentType(c.buf))
+	}
+
+	compress := largeEnough &&
+		header.Get("Content-Encoding") == "" &&
+		c.status != http.StatusNoContent &&
+		c.status != http.StatusNotModified &&
+		isCompressible(header.Get("Content-Type"))
+
+	if !compress {
+		c.ResponseWriter.WriteHeader(c.status)
+		if len(c.buf) == 0 {
+			return nil
+		}
+		_, err := c.ResponseWriter.Write(c.buf)
+		return err
+	}
+
+	header.Set("Content-Encoding", c.encoding)
+	header.Del("Content-Length")
+	c.ResponseWriter.WriteHeader(c.status)
+
+	c.compressor = c.pool.Get().(compressor)
+	c.compressor.Reset(c.ResponseWriter)
+
+	_, err := c.compressor.Write(c.buf)
+	return err
+}
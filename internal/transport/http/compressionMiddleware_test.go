@@ -0,0 +1,110 @@
+package http_test
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compress serves the body with the content type through the compression middleware,
+// as a response to a request with the Accept-Encoding header.
+func compress(t *testing.T, acceptEncoding, contentType, body string) *httptest.ResponseRecorder {
+	compression, err := transporthttp.NewCompressionMiddleware(16,
+		transporthttp.EncodingZstd, transporthttp.EncodingGzip, transporthttp.EncodingDeflate)
+	require.NoError(t, err)
+
+	handler := compression(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, body)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+	r.Header.Set("Accept-Encoding", acceptEncoding)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+
+	return recorder
+}
+
+// decompress reads the body of the response in its content coding.
+func decompress(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	var reader io.Reader
+	var err error
+
+	switch recorder.Header().Get("Content-Encoding") {
+	case "zstd":
+		reader, err = zstd.NewReader(recorder.Body)
+	case "gzip":
+		reader, err = gzip.NewReader(recorder.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(recorder.Body)
+	default:
+		reader = recorder.Body
+	}
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	body := `{"questions": "` + strings.Repeat("a", 64) + `"}`
+
+	t.Run("Should compress with the preferred accepted encoding", func(t *testing.T) {
+		for acceptEncoding, encoding := range map[string]string{
+			"gzip, deflate, zstd":         "zstd",
+			"gzip":                        "gzip",
+			"deflate":                     "deflate",
+			"zstd;q=0.5, gzip":            "gzip",
+			"*, zstd;q=0":                 "gzip",
+			"identity, deflate;q=0.1, br": "deflate",
+		} {
+			recorder := compress(t, acceptEncoding, "application/json", body)
+			assert.Equal(t, http.StatusCreated, recorder.Code, acceptEncoding)
+			assert.Equal(t, encoding, recorder.Header().Get("Content-Encoding"), acceptEncoding)
+			assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"), acceptEncoding)
+			assert.Equal(t, body, decompress(t, recorder), acceptEncoding)
+		}
+	})
+
+	t.Run("Should sniff the content type before compressing", func(t *testing.T) {
+		recorder := compress(t, "gzip", "", body)
+		assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, body, decompress(t, recorder))
+	})
+
+	t.Run("Should send small, incompressible or unaccepted responses as they are", func(t *testing.T) {
+		for _, recorder := range []*httptest.ResponseRecorder{
+			compress(t, "gzip", "application/json", "{}"),
+			compress(t, "gzip", "image/png", body),
+			compress(t, "br", "application/json", body),
+			compress(t, "", "application/json", body),
+		} {
+			assert.Equal(t, http.StatusCreated, recorder.Code)
+			assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		}
+
+		assert.Equal(t, body, compress(t, "gzip", "image/png", body).Body.String())
+	})
+
+	t.Run("Should refuse unsupported encoding", func(t *testing.T) {
+		_, err := transporthttp.NewCompressionMiddleware(16, "br")
+		assert.EqualError(t, err, "unsupported content coding br")
+	})
+}
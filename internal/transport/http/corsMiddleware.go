@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// CORSPolicy represents which cross origin requests browsers are allowed to make.
+type CORSPolicy struct {
+	// Origins allowed to call the api, "*" allows every origin.
+	AllowedOrigins []string
+	// Methods and request headers allowed in preflighted requests, "*" allows every header.
+	AllowedMethods []string
+	AllowedHeaders []string
+	// Response headers which scripts of the allowed origins may read.
+	ExposedHeaders []string
+	// Whether requests may carry cookies and authorization headers, only with origins listed by name.
+	AllowCredentials bool
+	// How long browsers may cache the result of a preflight request.
+	MaxAge time.Duration
+}
+
+// NewCORSMiddleware creates a middleware which adds CORS headers to requests of allowed origins
+// and answers preflight requests itself. Preflight requests aren't routed, as routes only match
+// their own methods, so it must wrap the router rather than be used by it.
+// Allowing credentials to every origin would let any site make requests as the user,
+// so the "*" wildcard is rejected together with credentials.
+func NewCORSMiddleware(policy CORSPolicy) (mux.MiddlewareFunc, error) {
+	if policy.AllowCredentials && containsFold(policy.AllowedOrigins, "*") {
+		return nil, errors.New("cors credentials can't be allowed to the \"*\" wildcard origin")
+	}
+
+	allowedMethods := strings.Join(policy.AllowedMethods, ", ")
+	exposedHeaders := strings.Join(policy.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(policy.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			w.Header().Add("Vary", "Origin")
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if origin == "" || !policy.allowsOrigin(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if preflight {
+				requestHeaders := r.Header.Get("Access-Control-Request-Headers")
+				if !containsFold(policy.AllowedMethods, r.Header.Get("Access-Control-Request-Method")) ||
+					!policy.allowsHeaders(requestHeaders) {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				policy.setAllowOrigin(w, origin)
+				w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+				if requestHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", requestHeaders)
+				}
+				if policy.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			policy.setAllowOrigin(w, origin)
+			if exposedHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// allowsOrigin reports whether the origin may call the api.
+func (p CORSPolicy) allowsOrigin(origin string) bool {
+	return containsFold(p.AllowedOrigins, "*") || containsFold(p.AllowedOrigins, origin)
+}
+
+// allowsHeaders reports whether every header of the comma separated list may be sent.
+func (p CORSPolicy) allowsHeaders(headers string) bool {
+	if containsFold(p.AllowedHeaders, "*") {
+		return true
+	}
+
+	for _, header := range strings.Split(headers, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !containsFold(p.AllowedHeaders, header) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setAllowOrigin allows the origin, which is listed by name unless the "*" wildcard allows every origin.
+func (p CORSPolicy) setAllowOrigin(w http.ResponseWriter, origin string) {
+	if containsFold(p.AllowedOrigins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if p.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// containsFold reports whether the values contain the value, ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
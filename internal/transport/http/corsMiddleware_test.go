@@ -0,0 +1,137 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCORSHandler creates a router with a single GET /questions route wrapped by the cors middleware.
+func newCORSHandler(t *testing.T, policy transporthttp.CORSPolicy) http.Handler {
+	router := mux.NewRouter()
+	router.HandleFunc("/questions", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+
+	cors, err := transporthttp.NewCORSMiddleware(policy)
+	require.NoError(t, err)
+
+	return cors(router)
+}
+
+// corsRequest serves a request from the origin, a preflight request for the method when preflightMethod isn't empty.
+func corsRequest(handler http.Handler, origin, preflightMethod, preflightHeaders string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+	if preflightMethod != "" {
+		r = httptest.NewRequest(http.MethodOptions, "/questions", nil)
+		r.Header.Set("Access-Control-Request-Method", preflightMethod)
+		r.Header.Set("Access-Control-Request-Headers", preflightHeaders)
+	}
+	r.Header.Set("Origin", origin)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+
+	return recorder
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	policy := transporthttp.CORSPolicy{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	t.Run("Should answer preflight request of allowed origin", func(t *testing.T) {
+		recorder := corsRequest(newCORSHandler(t, policy), "https://app.example.com", http.MethodPost, "authorization, content-type")
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "authorization, content-type", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Should refuse preflight request of other origin, method or header", func(t *testing.T) {
+		handler := newCORSHandler(t, policy)
+
+		for _, recorder := range []*httptest.ResponseRecorder{
+			corsRequest(handler, "https://evil.example.com", http.MethodPost, ""),
+			corsRequest(handler, "https://app.example.com", http.MethodDelete, ""),
+			corsRequest(handler, "https://app.example.com", http.MethodPost, "X-Unknown"),
+		} {
+			assert.Equal(t, http.StatusNoContent, recorder.Code)
+			assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Methods"))
+		}
+	})
+
+	t.Run("Should allow request of allowed origin and expose headers", func(t *testing.T) {
+		recorder := corsRequest(newCORSHandler(t, policy), "https://app.example.com", "", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "X-Request-ID", recorder.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+	})
+
+	t.Run("Should serve request of other origin without cors headers", func(t *testing.T) {
+		recorder := corsRequest(newCORSHandler(t, policy), "https://evil.example.com", "", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Should allow any origin with wildcard", func(t *testing.T) {
+		wildcard := policy
+		wildcard.AllowedOrigins = []string{"*"}
+
+		recorder := corsRequest(newCORSHandler(t, wildcard), "https://other.example.com", "", "")
+		assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Should allow credentials to listed origins only", func(t *testing.T) {
+		credentials := policy
+		credentials.AllowCredentials = true
+
+		recorder := corsRequest(newCORSHandler(t, credentials), "https://app.example.com", "", "")
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+
+		recorder = corsRequest(newCORSHandler(t, credentials), "https://evil.example.com", "", "")
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Should reject wildcard origin with credentials", func(t *testing.T) {
+		wildcard := policy
+		wildcard.AllowedOrigins = []string{"https://app.example.com", "*"}
+		wildcard.AllowCredentials = true
+
+		_, err := transporthttp.NewCORSMiddleware(wildcard)
+		assert.Error(t, err)
+	})
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	t.Run("Should add configured security headers to unrouted responses", func(t *testing.T) {
+		handler := transporthttp.NewSecurityHeadersMiddleware(transporthttp.SecurityHeaders{
+			ContentSecurityPolicy: "default-src 'none'",
+			FrameOptions:          "DENY",
+		})(mux.NewRouter())
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "default-src 'none'", recorder.Header().Get("Content-Security-Policy"))
+		assert.Equal(t, "DENY", recorder.Header().Get("X-Frame-Options"))
+		assert.NotContains(t, recorder.Header(), "Referrer-Policy")
+		assert.NotContains(t, recorder.Header(), "Strict-Transport-Security")
+	})
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// bodyError represents a request body which can't be decoded, with the status code to respond with.
+type bodyError struct {
+	status int
+	err    error
+}
+
+// Error returns the message of the underlying error.
+func (e *bodyError) Error() string {
+	return e.err.Error()
+}
+
+// decodeJSONBody strictly decodes the json request body into dst. The body must be application/json,
+// no larger than maxSize bytes, hold a single json value and only fields dst has.
+// Errors describe the offending field or position and are written by encodeBodyError.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, maxSize int64, dst interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return &bodyError{http.StatusUnsupportedMediaType, errors.New("Content-Type header must be application/json")}
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSize))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(dst)
+	if err != nil {
+		return describeDecodeError(err)
+	}
+
+	err = decoder.Decode(&struct{}{})
+	if !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return describeDecodeError(err)
+		}
+		return &bodyError{http.StatusBadRequest, errors.New("request body must hold a single json value")}
+	}
+
+	return nil
+}
+
+// describeDecodeError turns the error of the json decoder into a body error naming what's wrong.
+func describeDecodeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return &bodyError{http.StatusRequestEntityTooLarge,
+			fmt.Errorf("request body can't be larger than %d bytes", maxBytesErr.Limit)}
+	case errors.As(err, &syntaxErr):
+		return &bodyError{http.StatusBadRequest,
+			fmt.Errorf("malformed json at position %d: %w", syntaxErr.Offset, err)}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &bodyError{http.StatusBadRequest, errors.New("malformed json, request body ended unexpectedly")}
+	case errors.Is(err, io.EOF):
+		return &bodyError{http.StatusBadRequest, errors.New("request body can't be empty")}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return &bodyError{http.StatusBadRequest,
+			fmt.Errorf("field %q must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)}
+	case errors.As(err, &typeErr):
+		return &bodyError{http.StatusBadRequest,
+			fmt.Errorf("request body must be %s, got %s", typeErr.Type, typeErr.Value)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// The decoder has no error type for unknown fields.
+		return &bodyError{http.StatusBadRequest,
+			fmt.Errorf("unknown field %s", strings.TrimPrefix(err.Error(), "json: unknown field "))}
+	default:
+		return &bodyError{http.StatusBadRequest, err}
+	}
+}
+
+// encodeBodyError writes the error returned by decodeJSONBody with its status code.
+func encodeBodyError(w http.ResponseWriter, err error) {
+	var decodeErr *bodyError
+	if errors.As(err, &decodeErr) {
+		encodeError(w, decodeErr.status, decodeErr)
+		return
+	}
+
+	encodeError(w, http.StatusBadRequest, err)
+}
@@ -0,0 +1,129 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registeringUserService records registrations, the other methods aren't used by the tests.
+type registeringUserService struct {
+	transporthttp.UserServicer
+	registrations []service.UserRegistrationDTO
+}
+
+func (s *registeringUserService) Register(ctx context.Context, registration service.UserRegistrationDTO) (service.UserDTO, error) {
+	s.registrations = append(s.registrations, registration)
+	return service.UserDTO{ID: 1, Username: registration.Username}, nil
+}
+
+// register serves a registration request with the body and content type, returning the response and error message.
+func register(t *testing.T, userService *registeringUserService, contentType, body string) (int, string) {
+	router := mux.NewRouter()
+	transporthttp.NewUserHandler(userService, 64).RegisterRoutes(router)
+
+	r := httptest.NewRequest(http.MethodPost, "/users/register", strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+
+	if recorder.Code < http.StatusBadRequest {
+		return recorder.Code, ""
+	}
+
+	response := errorBody{}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&response))
+
+	return recorder.Code, response.Message
+}
+
+func TestDecodeJSONBody(t *testing.T) {
+	t.Run("Should decode json body", func(t *testing.T) {
+		userService := &registeringUserService{}
+
+		status, _ := register(t, userService, "application/json; charset=utf-8",
+			`{"username": "player", "password": "password123"}`)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, []service.UserRegistrationDTO{{Username: "player", Password: "password123"}},
+			userService.registrations)
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		message     string
+	}{
+		{
+			name:        "Should reject other content type with 415",
+			contentType: "text/plain",
+			body:        `{"username": "player", "password": "password123"}`,
+			status:      http.StatusUnsupportedMediaType,
+			message:     "Content-Type header must be application/json",
+		},
+		{
+			name:        "Should reject body larger than the limit with 413",
+			contentType: "application/json",
+			body:        `{"username": "player", "password": "` + strings.Repeat("a", 64) + `"}`,
+			status:      http.StatusRequestEntityTooLarge,
+			message:     "request body can't be larger than 64 bytes",
+		},
+		{
+			name:        "Should reject unknown field naming it",
+			contentType: "application/json",
+			body:        `{"username": "player", "password": "password123", "admin": true}`,
+			status:      http.StatusBadRequest,
+			message:     `unknown field "admin"`,
+		},
+		{
+			name:        "Should reject field of the wrong type naming it",
+			contentType: "application/json",
+			body:        `{"username": 7, "password": "password123"}`,
+			status:      http.StatusBadRequest,
+			message:     `field "username" must be string, got number`,
+		},
+		{
+			name:        "Should reject trailing json",
+			contentType: "application/json",
+			body:        `{"username": "player", "password": "password123"} {}`,
+			status:      http.StatusBadRequest,
+			message:     "request body must hold a single json value",
+		},
+		{
+			name:        "Should reject malformed json with its position",
+			contentType: "application/json",
+			body:        `{"username": "player",}`,
+			status:      http.StatusBadRequest,
+			message:     "malformed json at position 23: invalid character '}' looking for beginning of object key string",
+		},
+		{
+			name:        "Should reject empty body",
+			contentType: "application/json",
+			body:        "",
+			status:      http.StatusBadRequest,
+			message:     "request body can't be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := &registeringUserService{}
+
+			status, message := register(t, userService, tt.contentType, tt.body)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.message, message)
+			assert.Empty(t, userService.registrations)
+		})
+	}
+}
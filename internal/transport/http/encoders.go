@@ -0,0 +1,217 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotTabular is returned by the csv encoder for values which can't be written as rows.
+var ErrNotTabular = errors.New("value can't be encoded as csv")
+
+// Encoder writes response bodies in a media type.
+type Encoder interface {
+	// MediaTypes returns the media types the encoder writes, the first one is sent as Content-Type.
+	MediaTypes() []string
+	Encode(w io.Writer, v interface{}) error
+}
+
+// EncoderRegistry picks the encoder of a response from the Accept header of the request.
+type EncoderRegistry struct {
+	encoders []Encoder
+}
+
+// NewEncoderRegistry creates a new instance of encoder registry, encoders are preferred in the order
+// they're registered and the first one is used when the request doesn't care.
+func NewEncoderRegistry(encoders ...Encoder) *EncoderRegistry {
+	return &EncoderRegistry{
+		encoders: encoders,
+	}
+}
+
+// Register adds the encoder, preferred less than the ones registered before it.
+func (e *EncoderRegistry) Register(encoder Encoder) {
+	e.encoders = append(e.encoders, encoder)
+}
+
+// Negotiate returns the encoder of the media type the Accept header prefers,
+// ties go to the encoder registered first.
+func (e *EncoderRegistry) Negotiate(accept string) (Encoder, error) {
+	if strings.TrimSpace(accept) == "" && len(e.encoders) > 0 {
+		return e.encoders[0], nil
+	}
+
+	entries := parseAccept(accept)
+
+	var best Encoder
+	bestQ := 0.0
+	for _, encoder := range e.encoders {
+		for _, mediaType := range encoder.MediaTypes() {
+			if q := mediaTypeQuality(entries, mediaType); q > bestQ {
+				best, bestQ = encoder, q
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("none of the accepted media types is available, available are %s",
+			strings.Join(e.mediaTypes(), ", "))
+	}
+
+	return best, nil
+}
+
+// mediaTypes returns the content types of every encoder.
+func (e *EncoderRegistry) mediaTypes() []string {
+	mediaTypes := []string{}
+	for _, encoder := range e.encoders {
+		mediaTypes = append(mediaTypes, encoder.MediaTypes()[0])
+	}
+
+	return mediaTypes
+}
+
+// writeEncoded writes the value with the negotiated encoder. Encoding is buffered so a value
+// the encoder can't write still gets an error response. Handlers negotiating the encoder
+// add Vary: Accept before negotiating, so every response they write carries it.
+func writeEncoded(w http.ResponseWriter, r *http.Request, encoder Encoder, v interface{}) {
+	body := &bytes.Buffer{}
+
+	err := encoder.Encode(body, v)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("error encoding response", "error", err)
+		encodeError(w, http.StatusInternalServerError, errors.New("error encoding response"))
+		return
+	}
+
+	w.Header().Set("Content-Type", encoder.MediaTypes()[0])
+	w.Write(body.Bytes())
+}
+
+// JSONEncoder writes json.
+type JSONEncoder struct{}
+
+// MediaTypes returns the json media type.
+func (JSONEncoder) MediaTypes() []string {
+	return []string{"application/json"}
+}
+
+// Encode writes the value as json.
+func (JSONEncoder) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// YAMLEncoder writes yaml with the field names and order of the json encoding.
+type YAMLEncoder struct{}
+
+// MediaTypes returns the yaml media types.
+func (YAMLEncoder) MediaTypes() []string {
+	return []string{"application/yaml", "application/x-yaml", "text/yaml"}
+}
+
+// Encode writes the value as yaml. The value is encoded as json first, which is valid yaml,
+// so the json tags of the value apply.
+func (YAMLEncoder) Encode(w io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	node := yaml.Node{}
+	err = yaml.Unmarshal(raw, &node)
+	if err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&node)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// blockStyle clears the json flow style of the node and its children, so they are written as block yaml.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// MessagePackEncoder writes MessagePack with the field names of the json encoding.
+type MessagePackEncoder struct{}
+
+// MediaTypes returns the MessagePack media types.
+func (MessagePackEncoder) MediaTypes() []string {
+	return []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}
+}
+
+// Encode writes the value as MessagePack, using the json tags of the value.
+func (MessagePackEncoder) Encode(w io.Writer, v interface{}) error {
+	encoder := msgpack.NewEncoder(w)
+	encoder.SetCustomStructTag("json")
+
+	return encoder.Encode(v)
+}
+
+// CSVMarshaler is implemented by values which can be written as csv.
+type CSVMarshaler interface {
+	MarshalCSV() (header []string, records [][]string)
+}
+
+// CSVEncoder writes csv of values implementing CSVMarshaler.
+type CSVEncoder struct{}
+
+// MediaTypes returns the csv media type.
+func (CSVEncoder) MediaTypes() []string {
+	return []string{"text/csv"}
+}
+
+// Encode writes the header and records of the value. Cells which spreadsheets would run as formulas are
+// prefixed with a quote, as they may hold text of any caller.
+func (CSVEncoder) Encode(w io.Writer, v interface{}) error {
+	marshaler, ok := v.(CSVMarshaler)
+	if !ok {
+		return ErrNotTabular
+	}
+
+	header, records := marshaler.MarshalCSV()
+
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	for _, record := range records {
+		for i, cell := range record {
+			record[i] = escapeFormula(cell)
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// escapeFormula prefixes cells starting like a formula with a quote, numbers are left as they are.
+func escapeFormula(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+
+	return "'" + cell
+}
@@ -0,0 +1,134 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// listingQuestionService lists the questions, the other methods aren't used by the tests.
+type listingQuestionService struct {
+	transporthttp.QuestionServicer
+	questions []service.QuestionDTO
+}
+
+func (s *listingQuestionService) GetQuestions(ctx context.Context, filter service.QuestionFilter, pageSize, offset int) ([]service.QuestionDTO, error) {
+	return s.questions, nil
+}
+
+// listQuestions serves GET /questions with the Accept header to a taker.
+func listQuestions(accept string) *httptest.ResponseRecorder {
+	correct := true
+	questionService := &listingQuestionService{questions: []service.QuestionDTO{
+		{ID: 1, Body: "=SUM(A1)", Difficulty: 2, Options: []service.QuestionOptionDTO{
+			{ID: 1, Body: "first", Correct: &correct},
+			{ID: 2, Body: "second, with comma"},
+		}},
+		{ID: 2, Body: "no options", Options: []service.QuestionOptionDTO{}},
+	}}
+
+	router := mux.NewRouter()
+	router.Use(transporthttp.NewIdentityMiddleware(transporthttp.NewTrustedHeaderResolver("X-User-Id", "X-User-Role")))
+	transporthttp.NewQuestionHandler(questionService, 1024, transporthttp.NewEncoderRegistry(
+		transporthttp.JSONEncoder{},
+		transporthttp.CSVEncoder{},
+		transporthttp.YAMLEncoder{},
+		transporthttp.MessagePackEncoder{},
+	)).RegisterRoutes(router)
+
+	r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+	r.Header.Set("X-User-Id", "taker")
+	r.Header.Set("X-User-Role", "taker")
+	r.Header.Set("Accept", accept)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+
+	return recorder
+}
+
+func TestEncoderRegistry_Negotiate(t *testing.T) {
+	registry := transporthttp.NewEncoderRegistry(transporthttp.JSONEncoder{}, transporthttp.CSVEncoder{})
+	registry.Register(transporthttp.YAMLEncoder{})
+
+	for accept, mediaType := range map[string]string{
+		"":                                      "application/json",
+		"*/*":                                   "application/json",
+		"text/csv":                              "text/csv",
+		"text/*":                                "text/csv",
+		"application/x-yaml":                    "application/yaml",
+		"application/json;q=0.5, text/csv":      "text/csv",
+		"text/csv;q=0, */*;q=0.1":               "application/json",
+		"text/html, application/*;q=0.9":        "application/json",
+		"application/json;q=0, text/yaml;q=0.2": "application/yaml",
+	} {
+		encoder, err := registry.Negotiate(accept)
+		require.NoError(t, err, accept)
+		assert.Equal(t, mediaType, encoder.MediaTypes()[0], accept)
+	}
+
+	_, err := registry.Negotiate("text/html, application/json;q=0")
+	assert.EqualError(t, err,
+		"none of the accepted media types is available, available are application/json, text/csv, application/yaml")
+}
+
+func TestQuestionHandler_GetQuestionsEncoding(t *testing.T) {
+	t.Run("Should write json by default", func(t *testing.T) {
+		recorder := listQuestions("")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+		assert.JSONEq(t, `[
+			{"id":1,"body":"=SUM(A1)","body_html":"","difficulty":2,"empirical_difficulty":null,"response_count":0,
+			 "options":[{"id":1,"body":"first","correct":true},{"id":2,"body":"second, with comma"}]},
+			{"id":2,"body":"no options","body_html":"","empirical_difficulty":null,"response_count":0,"options":[]}
+		]`, recorder.Body.String())
+	})
+
+	t.Run("Should write a csv row per option with formulas escaped", func(t *testing.T) {
+		recorder := listQuestions("text/csv")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "question_id,body,difficulty,empirical_difficulty,response_count,owner,option_id,option_body,option_correct\n"+
+			"1,'=SUM(A1),2,,0,,1,first,true\n"+
+			"1,'=SUM(A1),2,,0,,2,\"second, with comma\",\n"+
+			"2,no options,0,,0,,,,\n", recorder.Body.String())
+	})
+
+	t.Run("Should write yaml with the json field names", func(t *testing.T) {
+		recorder := listQuestions("application/yaml")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "- id: 1\n  body: =SUM(A1)\n  body_html: \"\"\n  difficulty: 2\n")
+		assert.Contains(t, recorder.Body.String(), "    - id: 2\n      body: second, with comma\n")
+	})
+
+	t.Run("Should write MessagePack with the json field names", func(t *testing.T) {
+		recorder := listQuestions("application/msgpack")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/msgpack", recorder.Header().Get("Content-Type"))
+
+		questions := []map[string]interface{}{}
+		err := msgpack.NewDecoder(bytes.NewReader(recorder.Body.Bytes())).Decode(&questions)
+		require.NoError(t, err)
+		assert.Len(t, questions, 2)
+		assert.Equal(t, "=SUM(A1)", questions[0]["body"])
+		assert.NotContains(t, questions[1], "difficulty")
+	})
+
+	t.Run("Should respond with 406 to unavailable media type", func(t *testing.T) {
+		recorder := listQuestions("text/html")
+		assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+	})
+}
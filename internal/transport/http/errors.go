@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// errorResponse is the body of every error response.
+type errorResponse struct {
+	Status    int    `json:"status"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// encodeError writes the error as json with the provided status code.
+// Request id is taken from the response header set by the request logging middleware.
+func encodeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(errorResponse{
+		Status:    status,
+		Error:     http.StatusText(status),
+		Message:   err.Error(),
+		RequestID: w.Header().Get(requestIDHeader),
+	})
+}
+
+// NewNotFoundHandler creates a handler which responds to requests for unknown routes with a json 404.
+func NewNotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodeError(w, http.StatusNotFound, fmt.Errorf("route %s not found", r.URL.Path))
+	})
+}
+
+// NewMethodNotAllowedHandler creates a handler which responds to requests with a method the route
+// doesn't serve with a json 405.
+func NewMethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodeError(w, http.StatusMethodNotAllowed,
+			fmt.Errorf("method %s not allowed for %s", r.Method, r.URL.Path))
+	})
+}
+
+// encodeServiceError writes the error returned by a service with a matching status code.
+func encodeServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		encodeError(w, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrInvalidInput):
+		encodeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrTooLarge):
+		encodeError(w, http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, service.ErrConflict):
+		encodeError(w, http.StatusConflict, err)
+	case errors.Is(err, service.ErrUnauthorized):
+		encodeError(w, http.StatusUnauthorized, err)
+	case errors.Is(err, service.ErrForbidden):
+		encodeError(w, http.StatusForbidden, err)
+	default:
+		encodeError(w, http.StatusInternalServerError, err)
+	}
+}
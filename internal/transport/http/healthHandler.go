@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/health"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+// Routes must be registered outside of the tenant and auth middlewares, probes send neither.
+func (h *HealthHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/healthz", h.report(h.liveness)).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", h.report(h.readiness)).Methods(http.MethodGet)
+}
+
+// HealthReporter represents necessary health registry implementation for health handler.
+type HealthReporter interface {
+	Run(ctx context.Context) health.Report
+}
+
+// HealthHandler handles liveness and readiness probes.
+type HealthHandler struct {
+	liveness  HealthReporter
+	readiness HealthReporter
+}
+
+// NewHealthHandler creates a new instance of health handler.
+func NewHealthHandler(liveness, readiness HealthReporter) *HealthHandler {
+	return &HealthHandler{
+		liveness:  liveness,
+		readiness: readiness,
+	}
+}
+
+// report handles running the checks of the reporter, responding with 503 when any check fails.
+func (h *HealthHandler) report(reporter HealthReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := reporter.Run(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if res.Status != health.StatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
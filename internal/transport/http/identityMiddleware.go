@@ -0,0 +1,111 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/gorilla/mux"
+)
+
+// IdentityResolver resolves the caller of a request.
+// It reports false when the request carries no identity the resolver understands.
+type IdentityResolver interface {
+	ResolveCaller(r *http.Request) (auth.Caller, bool, error)
+}
+
+// NewIdentityMiddleware creates a middleware which puts the caller resolved by the first
+// resolver that recognises the request into the request context.
+func NewIdentityMiddleware(resolvers ...IdentityResolver) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, resolver := range resolvers {
+				caller, ok, err := resolver.ResolveCaller(r)
+				if err != nil {
+					encodeError(w, http.StatusUnauthorized, err)
+					return
+				}
+				if ok {
+					next.ServeHTTP(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TrustedHeaderResolver resolves the caller from headers set by a trusted proxy in front of the api.
+type TrustedHeaderResolver struct {
+	idHeader   string
+	roleHeader string
+}
+
+// NewTrustedHeaderResolver creates a new instance of trusted header resolver.
+func NewTrustedHeaderResolver(idHeader, roleHeader string) *TrustedHeaderResolver {
+	return &TrustedHeaderResolver{
+		idHeader:   idHeader,
+		roleHeader: roleHeader,
+	}
+}
+
+// ResolveCaller reads the caller id and role from the trusted headers.
+func (t *TrustedHeaderResolver) ResolveCaller(r *http.Request) (auth.Caller, bool, error) {
+	id := r.Header.Get(t.idHeader)
+	roleName := r.Header.Get(t.roleHeader)
+
+	if id == "" && roleName == "" {
+		return auth.Caller{}, false, nil
+	}
+	if id == "" || roleName == "" {
+		return auth.Caller{}, false, fmt.Errorf("both %s and %s headers are required", t.idHeader, t.roleHeader)
+	}
+
+	role, ok := auth.ParseRole(roleName)
+	if !ok {
+		return auth.Caller{}, false, fmt.Errorf("unknown role %s", roleName)
+	}
+
+	return auth.Caller{ID: id, Role: role}, true, nil
+}
+
+// AuthenticatedResolver resolves the caller from the user or api key authenticated by earlier middlewares.
+// Users are takers, api keys with the write scope are authors and the other api keys are reviewers.
+type AuthenticatedResolver struct{}
+
+// NewAuthenticatedResolver creates a new instance of authenticated resolver.
+func NewAuthenticatedResolver() *AuthenticatedResolver {
+	return &AuthenticatedResolver{}
+}
+
+// ResolveCaller maps the authenticated user or api key to a caller.
+func (a *AuthenticatedResolver) ResolveCaller(r *http.Request) (auth.Caller, bool, error) {
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		return auth.Caller{ID: "user:" + strconv.Itoa(user.ID), Role: auth.RoleTaker}, true, nil
+	}
+
+	if key, ok := auth.APIKeyFromContext(r.Context()); ok {
+		role := auth.RoleReviewer
+		if key.HasScope(auth.ScopeWrite) {
+			role = auth.RoleAuthor
+		}
+		return auth.Caller{ID: "apikey:" + strconv.Itoa(key.ID), Role: role}, true, nil
+	}
+
+	return auth.Caller{}, false, nil
+}
+
+// requireCaller wraps the handler so it is only served to requests with a resolved caller.
+func requireCaller(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.CallerFromContext(r.Context()); !ok {
+			encodeError(w, http.StatusUnauthorized, errors.New("authentication required"))
+			return
+		}
+
+		next(w, r)
+	}
+}
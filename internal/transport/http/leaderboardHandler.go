@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+func (h *LeaderboardHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/runs", h.SubmitRun()).Methods(http.MethodPost)
+	router.HandleFunc("/leaderboards/{window}", h.GetLeaderboard()).Methods(http.MethodGet)
+	router.HandleFunc("/leaderboards/{window}/players/{name}", h.GetPlayerRank()).Methods(http.MethodGet)
+}
+
+// LeaderboardServicer represents necessary leaderboard service implementation for leaderboard handler.
+type LeaderboardServicer interface {
+	SubmitRun(ctx context.Context, runCreation service.RunCreationDTO) (service.RunDTO, error)
+	GetLeaderboard(ctx context.Context, window string, pageSize, offset int) (service.LeaderboardDTO, error)
+	GetPlayerRank(ctx context.Context, window, playerName string) (service.LeaderboardEntryDTO, error)
+}
+
+// LeaderboardHandler handles http requests for scored runs and leaderboards.
+type LeaderboardHandler struct {
+	leaderboardService LeaderboardServicer
+	maxBodySize        int64
+}
+
+// NewLeaderboardHandler creates a new instance of leaderboard handler.
+func NewLeaderboardHandler(leaderboardService LeaderboardServicer, maxBodySize int64) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardService: leaderboardService,
+		maxBodySize:        maxBodySize,
+	}
+}
+
+// SubmitRun handles grading and recording of a scored run.
+func (h *LeaderboardHandler) SubmitRun() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		runCreationDTO := service.RunCreationDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &runCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(runCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.leaderboardService.SubmitRun(r.Context(), runCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetLeaderboard handles retrieving a page of the leaderboard of a time window.
+func (h *LeaderboardHandler) GetLeaderboard() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pageSize, offset, err := helpers.Paginate(r.URL.Query())
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.leaderboardService.GetLeaderboard(r.Context(), mux.Vars(r)["window"], pageSize, offset)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetPlayerRank handles retrieving the rank of a player on the leaderboard of a time window.
+func (h *LeaderboardHandler) GetPlayerRank() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		res, err := h.leaderboardService.GetPlayerRank(r.Context(), vars["window"], vars["name"])
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
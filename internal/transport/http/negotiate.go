@@ -0,0 +1,78 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+)
+
+// acceptEntry represents a single value of an Accept or Accept-Encoding header with its quality.
+type acceptEntry struct {
+	value string
+	q     float64
+}
+
+// parseAccept parses the comma separated values of an Accept or Accept-Encoding header,
+// dropping their parameters except the quality, which defaults to 1.
+func parseAccept(header string) []acceptEntry {
+	entries := []acceptEntry{}
+
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+
+		value := strings.ToLower(strings.TrimSpace(params[0]))
+		if value == "" {
+			continue
+		}
+
+		entry := acceptEntry{value: value, q: 1}
+		for _, param := range params[1:] {
+			name, raw, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				q, err := strconv.ParseFloat(raw, 64)
+				if err != nil || q < 0 || q > 1 {
+					q = 0
+				}
+				entry.q = q
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// mediaTypeQuality returns the quality the entries give the media type, taken from the most specific
+// matching range, so "text/csv;q=0, */*" accepts everything except csv.
+func mediaTypeQuality(entries []acceptEntry, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	q, specificity := 0.0, 0
+	for _, entry := range entries {
+		switch {
+		case entry.value == mediaType && specificity < 3:
+			q, specificity = entry.q, 3
+		case entry.value == mainType+"/*" && specificity < 2:
+			q, specificity = entry.q, 2
+		case entry.value == "*/*" && specificity < 1:
+			q, specificity = entry.q, 1
+		}
+	}
+
+	return q
+}
+
+// encodingQuality returns the quality the entries give the content coding, named or through "*".
+func encodingQuality(entries []acceptEntry, encoding string) float64 {
+	q, named := 0.0, false
+	for _, entry := range entries {
+		switch {
+		case entry.value == encoding:
+			q, named = entry.q, true
+		case entry.value == "*" && !named:
+			q = entry.q
+		}
+	}
+
+	return q
+}
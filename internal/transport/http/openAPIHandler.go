@@ -0,0 +1,75 @@
+package http
+
+import (
+	"embed"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/djurica-surla/backend-homework/internal/openapi"
+	"github.com/gorilla/mux"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// Swagger UI page loading the bundled Swagger UI assets and the OpenAPI document.
+//
+//go:embed swagger
+var swaggerPage embed.FS
+
+// Content security policy of the docs, Swagger UI styles its elements inline and shows data uri images.
+const docsContentSecurityPolicy = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'"
+
+// RegisterRoutes links routes with the handler.
+// Routes are registered outside of the tenant and auth middlewares, the docs are public.
+func (h *OpenAPIHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/openapi.json", h.GetDocument()).Methods(http.MethodGet)
+	router.HandleFunc("/docs", h.GetDocs("swagger/index.html")).Methods(http.MethodGet)
+	router.HandleFunc("/docs/initializer.js", h.GetDocs("swagger/initializer.js")).Methods(http.MethodGet)
+	router.PathPrefix("/docs/").Handler(h.GetDocsAssets()).Methods(http.MethodGet)
+}
+
+// OpenAPIHandler handles http requests for the OpenAPI document of the api and its Swagger UI docs.
+type OpenAPIHandler struct {
+	document *openapi.Document
+}
+
+// NewOpenAPIHandler creates a new instance of OpenAPI handler.
+func NewOpenAPIHandler(document *openapi.Document) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		document: document,
+	}
+}
+
+// GetDocument handles serving the OpenAPI document.
+func (h *OpenAPIHandler) GetDocument() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(h.document)
+	}
+}
+
+// GetDocs handles serving a file of the embedded Swagger UI page.
+func (h *OpenAPIHandler) GetDocs(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		content, err := swaggerPage.ReadFile(name)
+		if err != nil {
+			encodeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+		w.Header().Set("Content-Security-Policy", docsContentSecurityPolicy)
+		w.Write(content)
+	}
+}
+
+// GetDocsAssets handles serving the Swagger UI assets.
+func (h *OpenAPIHandler) GetDocsAssets() http.Handler {
+	assets := http.StripPrefix("/docs/", http.FileServer(swaggerFiles.HTTP))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", docsContentSecurityPolicy)
+		assets.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,380 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/djurica-surla/backend-homework/internal/health"
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/openapi"
+	"github.com/djurica-surla/backend-homework/internal/service"
+)
+
+// Security requirements of the operations, public operations have none.
+// Operations with optional security are public but show more to callers.
+var (
+	callerSecurity         = []openapi.SecurityRequirement{{"bearerAuth": {}}, {"apiKeyAuth": {}}}
+	optionalCallerSecurity = []openapi.SecurityRequirement{{"bearerAuth": {}}, {"apiKeyAuth": {}}, {}}
+	userSecurity           = []openapi.SecurityRequirement{{"bearerAuth": {}}}
+	adminSecurity          = []openapi.SecurityRequirement{{"adminToken": {}}}
+)
+
+// Shared parameters of the operations, defined once in the components.
+var (
+	tenantParameter   = openapi.Parameter{Ref: "#/components/parameters/Tenant"}
+	pageParameter     = openapi.Parameter{Ref: "#/components/parameters/Page"}
+	pageSizeParameter = openapi.Parameter{Ref: "#/components/parameters/PageSize"}
+	idsParameter      = openapi.Parameter{
+		Name:        "ids",
+		In:          "query",
+		Description: fmt.Sprintf("Comma separated question ids, at most %d.", helpers.MaxIDs),
+		Required:    true,
+		Schema:      &openapi.Schema{Type: "string", Format: "ids"},
+	}
+)
+
+var pathParameterPattern = regexp.MustCompile(`{(\w+)}`)
+
+// apiOperation describes an operation of the api, the path is the mux path template of its route.
+type apiOperation struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+	// Parameters besides the path parameters, which are integers unless listed in stringPathParameters.
+	parameters           []openapi.Parameter
+	stringPathParameters []string
+	security             []openapi.SecurityRequirement
+	// Probes and metrics are served outside of the api middlewares, so they don't take the tenant header.
+	outsideAPI bool
+	// Json request body, or the content of other request bodies.
+	request        interface{}
+	requestContent map[string]openapi.MediaType
+	// Status of a successful response and its json body, or the content of other response bodies.
+	status          int
+	response        interface{}
+	responseContent map[string]openapi.MediaType
+	// Encoders negotiated for the response body, it's json when nil.
+	encoders *EncoderRegistry
+	// Statuses of error responses besides the ones every operation may respond with.
+	errors []int
+}
+
+// apiOperations returns the operations of every route the handlers register, in registration order.
+func apiOperations(questionEncoders *EncoderRegistry) []apiOperation {
+	binary := &openapi.Schema{Type: "string", Format: "binary"}
+
+	return []apiOperation{
+		// Metrics.
+		{method: http.MethodGet, path: "/metrics", tag: "metrics",
+			summary: "Prometheus metrics of served requests and database queries", outsideAPI: true,
+			status:          http.StatusOK,
+			responseContent: map[string]openapi.MediaType{"text/plain": {Schema: &openapi.Schema{Type: "string"}}}},
+
+		// Health.
+		{method: http.MethodGet, path: "/healthz", tag: "health", summary: "Liveness probe", outsideAPI: true,
+			status: http.StatusOK, response: health.Report{}, errors: []int{http.StatusServiceUnavailable}},
+		{method: http.MethodGet, path: "/readyz", tag: "health", summary: "Readiness probe checking the database and migrations",
+			outsideAPI: true, status: http.StatusOK, response: health.Report{},
+			errors: []int{http.StatusServiceUnavailable}},
+
+		// Questions.
+		{method: http.MethodGet, path: "/questions", tag: "questions",
+			summary: "List questions, optionally filtered by difficulty. Option correctness is omitted for takers",
+			parameters: []openapi.Parameter{
+				pageParameter,
+				pageSizeParameter,
+				queryParameter("min_difficulty", "Minimum author assigned difficulty.", "integer"),
+				queryParameter("max_difficulty", "Maximum author assigned difficulty.", "integer"),
+				queryParameter("min_empirical_difficulty", "Minimum proportion of correct responses.", "number"),
+				queryParameter("max_empirical_difficulty", "Maximum proportion of correct responses.", "number"),
+			},
+			security: optionalCallerSecurity, status: http.StatusOK, response: []service.QuestionDTO{},
+			encoders: questionEncoders, errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotAcceptable}},
+		{method: http.MethodPost, path: "/questions", tag: "questions", summary: "Create a question owned by the caller",
+			security: callerSecurity, request: service.QuestionCreationDTO{}, status: http.StatusOK, response: service.QuestionDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+		{method: http.MethodPut, path: "/questions/{id}", tag: "questions", summary: "Replace a question and its options",
+			security: callerSecurity, request: service.QuestionCreationDTO{}, status: http.StatusOK, response: service.QuestionDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}},
+		{method: http.MethodDelete, path: "/questions/{id}", tag: "questions", summary: "Delete a question",
+			security: callerSecurity, status: http.StatusOK, response: "",
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}},
+		{method: http.MethodGet, path: "/questions/{id}/explanation", tag: "questions",
+			summary: "Get the explanation of a question and its options", security: callerSecurity,
+			status: http.StatusOK, response: service.QuestionExplanationDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}},
+		{method: http.MethodGet, path: "/questions/{id}/hints/{n}", tag: "questions",
+			summary: "Get the n-th hint of a question, starting from 1", security: optionalCallerSecurity,
+			status: http.StatusOK, response: service.QuestionHintDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+
+		// QTI.
+		{method: http.MethodGet, path: "/questions/qti", tag: "qti", summary: "Export questions as a QTI 2.1 package",
+			parameters: []openapi.Parameter{idsParameter}, security: callerSecurity, status: http.StatusOK,
+			responseContent: map[string]openapi.MediaType{"application/zip": {Schema: binary}},
+			errors:          []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}},
+		{method: http.MethodPost, path: "/questions/qti", tag: "qti", summary: "Import questions from a QTI 2.1 package",
+			security: callerSecurity, requestContent: map[string]openapi.MediaType{"application/zip": {Schema: binary}},
+			status: http.StatusOK, response: service.QTIImportDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden}},
+
+		// Attachments.
+		{method: http.MethodPost, path: "/questions/{id}/attachments", tag: "attachments",
+			summary: "Attach a file to a question", requestContent: fileUpload(), status: http.StatusCreated,
+			response: service.AttachmentDTO{},
+			errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge}},
+		{method: http.MethodPost, path: "/questions/{id}/options/{optionID}/attachments", tag: "attachments",
+			summary: "Attach a file to an option of a question", requestContent: fileUpload(), status: http.StatusCreated,
+			response: service.AttachmentDTO{},
+			errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge}},
+		{method: http.MethodGet, path: "/attachments/{id}", tag: "attachments", summary: "Download the content of an attachment",
+			status: http.StatusOK, responseContent: map[string]openapi.MediaType{"*/*": {Schema: binary}},
+			errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		{method: http.MethodDelete, path: "/attachments/{id}", tag: "attachments", summary: "Delete an attachment",
+			status: http.StatusOK, response: "", errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		// Responses.
+		{method: http.MethodPost, path: "/questions/{id}/responses", tag: "responses",
+			summary: "Record a response to a question and grade it", security: callerSecurity,
+			request: service.QuestionResponseCreationDTO{}, status: http.StatusCreated, response: service.QuestionResponseDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+
+		// Statistics.
+		{method: http.MethodGet, path: "/questions/stats", tag: "statistics", summary: "Get statistics of several questions",
+			parameters: []openapi.Parameter{idsParameter}, security: callerSecurity, status: http.StatusOK,
+			response: []service.QuestionStatsDTO{},
+			errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+		{method: http.MethodGet, path: "/questions/{id}/stats", tag: "statistics", summary: "Get statistics of a question",
+			security: callerSecurity, status: http.StatusOK, response: service.QuestionStatsDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+
+		// Reviews.
+		{method: http.MethodGet, path: "/users/{id}/reviews/due", tag: "reviews",
+			summary: "List the questions due for review by a user", stringPathParameters: []string{"id"},
+			parameters: []openapi.Parameter{pageParameter, pageSizeParameter},
+			status:     http.StatusOK, response: []service.ReviewDTO{}, errors: []int{http.StatusBadRequest}},
+		{method: http.MethodPost, path: "/users/{id}/reviews", tag: "reviews",
+			summary: "Record a spaced repetition review of a question by a user", stringPathParameters: []string{"id"},
+			request: service.ReviewCreationDTO{}, status: http.StatusOK, response: service.ReviewDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		// Practice.
+		{method: http.MethodPost, path: "/practice", tag: "practice", summary: "Start an adaptive practice session",
+			security: callerSecurity, request: service.PracticeSessionCreationDTO{}, status: http.StatusCreated,
+			response: service.PracticeSessionDTO{}, errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+		{method: http.MethodGet, path: "/practice/{session}/next", tag: "practice",
+			summary: "Get the question best matching the rating of the user", security: callerSecurity,
+			status: http.StatusOK, response: service.PracticeQuestionDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+		{method: http.MethodPost, path: "/practice/{session}/answers", tag: "practice",
+			summary: "Answer a practice question, updating the ratings", security: callerSecurity,
+			request: service.PracticeAnswerCreationDTO{}, status: http.StatusCreated, response: service.PracticeAnswerDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound}},
+
+		// Leaderboards.
+		{method: http.MethodPost, path: "/runs", tag: "leaderboards", summary: "Submit a timed quiz run",
+			request: service.RunCreationDTO{}, status: http.StatusCreated, response: service.RunDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		{method: http.MethodGet, path: "/leaderboards/{window}", tag: "leaderboards",
+			summary: "Get the leaderboard of a window: all, day, week or month", stringPathParameters: []string{"window"},
+			parameters: []openapi.Parameter{pageParameter, pageSizeParameter},
+			status:     http.StatusOK, response: service.LeaderboardDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+		{method: http.MethodGet, path: "/leaderboards/{window}/players/{name}", tag: "leaderboards",
+			summary: "Get the rank of a player in the leaderboard of a window", stringPathParameters: []string{"window", "name"},
+			status: http.StatusOK, response: service.LeaderboardEntryDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusNotFound}},
+
+		// Users.
+		{method: http.MethodPost, path: "/users/register", tag: "users", summary: "Register a user",
+			request: service.UserRegistrationDTO{}, status: http.StatusCreated, response: service.UserDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusConflict}},
+		{method: http.MethodPost, path: "/users/login", tag: "users", summary: "Log in, starting a session",
+			request: service.UserLoginDTO{}, status: http.StatusOK, response: service.SessionDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+		{method: http.MethodPost, path: "/users/logout", tag: "users", summary: "Log out, ending the session",
+			security: userSecurity, status: http.StatusNoContent, errors: []int{http.StatusUnauthorized}},
+		{method: http.MethodGet, path: "/users/me", tag: "users", summary: "Get the logged in user",
+			security: userSecurity, status: http.StatusOK, response: service.UserDTO{},
+			errors: []int{http.StatusUnauthorized}},
+
+		// Tokens.
+		{method: http.MethodPost, path: "/auth/token", tag: "auth", summary: "Issue a jwt access token and a refresh token",
+			request: service.UserLoginDTO{}, status: http.StatusOK, response: service.TokenPairDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+		{method: http.MethodPost, path: "/auth/refresh", tag: "auth", summary: "Exchange a refresh token for a new token pair",
+			request: service.RefreshTokenDTO{}, status: http.StatusOK, response: service.TokenPairDTO{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized}},
+		{method: http.MethodPost, path: "/auth/revoke", tag: "auth",
+			summary: "Revoke a refresh token and the access token the request is sent with",
+			request: service.RefreshTokenDTO{}, status: http.StatusNoContent,
+			errors: []int{http.StatusBadRequest}},
+
+		// Api keys.
+		{method: http.MethodGet, path: "/admin/api-keys", tag: "admin", summary: "List api keys",
+			security: adminSecurity, status: http.StatusOK, response: []service.APIKeyDTO{},
+			errors: []int{http.StatusForbidden}},
+		{method: http.MethodPost, path: "/admin/api-keys", tag: "admin", summary: "Create an api key, returned only once",
+			security: adminSecurity, request: service.APIKeyCreationDTO{}, status: http.StatusCreated,
+			response: service.CreatedAPIKeyDTO{}, errors: []int{http.StatusBadRequest, http.StatusForbidden}},
+		{method: http.MethodDelete, path: "/admin/api-keys/{id}", tag: "admin", summary: "Delete an api key",
+			security: adminSecurity, status: http.StatusNoContent,
+			errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound}},
+	}
+}
+
+// NewOpenAPIDocument creates the OpenAPI document of every route the handlers register.
+// Question listings are documented in the media types of the question encoders.
+func NewOpenAPIDocument(version string, questionEncoders *EncoderRegistry) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Backend homework",
+		Description: "Question bank, practice and leaderboard api. Errors are returned as the Error schema.",
+		Version:     version,
+	})
+
+	doc.Components.SecuritySchemes["bearerAuth"] = openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "Session token from /users/login or jwt access token from /auth/token.",
+	}
+	doc.Components.SecuritySchemes["apiKeyAuth"] = openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "Authorization",
+		Description: `Api key sent as "ApiKey <key>".`,
+	}
+	doc.Components.SecuritySchemes["adminToken"] = openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "X-Admin-Token",
+		Description: "Admin token of the server config.",
+	}
+
+	doc.Components.Parameters["Tenant"] = openapi.Parameter{
+		Name: "X-Tenant-ID",
+		In:   "header",
+		Description: "Tenant whose question bank the request works with, the default tenant when omitted. " +
+			"Authenticated requests belong to the tenant of their credentials and can't name another one. " +
+			"It is only read from logins, admins and the trusted proxy, other requests belong to the default tenant.",
+		Schema: &openapi.Schema{Type: "string", MaxLength: intPtr(maxTenantIDLength)},
+	}
+	doc.Components.Parameters["Page"] = openapi.Parameter{
+		Name:        "page",
+		In:          "query",
+		Description: "Page number starting from 1, page_size must be sent with it.",
+		Schema:      &openapi.Schema{Type: "integer", Minimum: floatPtr(0)},
+	}
+	doc.Components.Parameters["PageSize"] = openapi.Parameter{
+		Name:        "page_size",
+		In:          "query",
+		Description: "Items per page, 10 when 0 and at most 50. Without page and page_size the first 50 items are listed.",
+		Schema:      &openapi.Schema{Type: "integer", Minimum: floatPtr(0), Maximum: floatPtr(50)},
+	}
+
+	errorSchema := doc.SchemaOf(errorResponse{}, openapi.ResponseUsage)
+
+	for _, operation := range apiOperations(questionEncoders) {
+		doc.AddOperation(operation.method, operation.path, operation.build(doc, errorSchema))
+	}
+
+	return doc
+}
+
+// build creates the OpenAPI operation, adding the schemas it uses to the document.
+func (o apiOperation) build(doc *openapi.Document, errorSchema *openapi.Schema) *openapi.Operation {
+	operation := &openapi.Operation{
+		OperationID: o.method + " " + o.path,
+		Summary:     o.summary,
+		Tags:        []string{o.tag},
+		Security:    o.security,
+		Responses:   map[string]openapi.Response{},
+	}
+
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(o.path, -1) {
+		schema := &openapi.Schema{Type: "integer", Minimum: floatPtr(0)}
+		for _, name := range o.stringPathParameters {
+			if name == match[1] {
+				schema = &openapi.Schema{Type: "string"}
+			}
+		}
+		operation.Parameters = append(operation.Parameters,
+			openapi.Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+	}
+	if !o.outsideAPI {
+		operation.Parameters = append(operation.Parameters, tenantParameter)
+	}
+	operation.Parameters = append(operation.Parameters, o.parameters...)
+
+	switch {
+	case o.request != nil:
+		operation.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				"application/json": {Schema: doc.SchemaOf(o.request, openapi.RequestUsage)},
+			},
+		}
+	case o.requestContent != nil:
+		operation.RequestBody = &openapi.RequestBody{Required: true, Content: o.requestContent}
+	}
+
+	success := openapi.Response{Description: http.StatusText(o.status), Content: o.responseContent}
+	if o.response != nil {
+		schema := doc.SchemaOf(o.response, openapi.ResponseUsage)
+		success.Content = map[string]openapi.MediaType{"application/json": {Schema: schema}}
+
+		if o.encoders != nil {
+			success.Content = map[string]openapi.MediaType{}
+			for _, mediaType := range o.encoders.mediaTypes() {
+				success.Content[mediaType] = openapi.MediaType{Schema: schema}
+			}
+			if _, ok := success.Content["text/csv"]; ok {
+				success.Content["text/csv"] = openapi.MediaType{Schema: &openapi.Schema{
+					Type:        "string",
+					Description: "A row per option of each question.",
+				}}
+			}
+		}
+	}
+	operation.Responses[strconv.Itoa(o.status)] = success
+
+	errorContent := map[string]openapi.MediaType{"application/json": {Schema: errorSchema}}
+	for _, status := range o.errors {
+		operation.Responses[strconv.Itoa(status)] = openapi.Response{
+			Description: http.StatusText(status),
+			Content:     errorContent,
+		}
+	}
+	operation.Responses["default"] = openapi.Response{
+		Description: "Error, e.g. 415 for a request body which isn't json, 413 for a body over the limit or 429 over the rate limit",
+		Content:     errorContent,
+	}
+
+	return operation
+}
+
+// queryParameter returns an optional query parameter of the type.
+func queryParameter(name, description, schemaType string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: schemaType}}
+}
+
+// fileUpload returns the content of a multipart upload of a single file.
+func fileUpload() map[string]openapi.MediaType {
+	return map[string]openapi.MediaType{
+		"multipart/form-data": {Schema: &openapi.Schema{
+			Type:       "object",
+			Required:   []string{"file"},
+			Properties: map[string]*openapi.Schema{"file": {Type: "string", Format: "binary"}},
+		}},
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
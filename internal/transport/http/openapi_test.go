@@ -0,0 +1,180 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// documentedRouter creates a router with the routes the server registers, without the routes of the OpenAPI
+// handler which serve the document itself.
+func documentedRouter() *mux.Router {
+	router := mux.NewRouter()
+
+	transporthttp.RegisterRoutes(router, transporthttp.Handlers{
+		Metrics: http.NotFoundHandler(),
+		Health:  transporthttp.NewHealthHandler(nil, nil),
+		OpenAPI: transporthttp.NewOpenAPIHandler(nil),
+
+		Question:    transporthttp.NewQuestionHandler(nil, 0, nil),
+		QTI:         transporthttp.NewQTIHandler(nil),
+		Attachment:  transporthttp.NewAttachmentHandler(nil, 0),
+		Response:    transporthttp.NewResponseHandler(nil, 0),
+		Statistics:  transporthttp.NewStatisticsHandler(nil),
+		Review:      transporthttp.NewReviewHandler(nil, 0),
+		Practice:    transporthttp.NewPracticeHandler(nil, 0),
+		Leaderboard: transporthttp.NewLeaderboardHandler(nil, 0),
+		User:        transporthttp.NewUserHandler(nil, 0),
+		Token:       transporthttp.NewTokenHandler(nil, 0),
+		APIKey:      transporthttp.NewAPIKeyHandler(nil, "", 0),
+	})
+
+	return router
+}
+
+// routes returns the method and path template of every route of the router.
+func routes(t *testing.T, router *mux.Router) []string {
+	routes := []string{}
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Subrouters have no methods of their own.
+			return nil
+		}
+
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		for _, method := range methods {
+			routes = append(routes, method+" "+template)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	return routes
+}
+
+// questionEncoders returns the encoders of question listings the server uses.
+func questionEncoders() *transporthttp.EncoderRegistry {
+	return transporthttp.NewEncoderRegistry(
+		transporthttp.JSONEncoder{},
+		transporthttp.CSVEncoder{},
+		transporthttp.YAMLEncoder{},
+		transporthttp.MessagePackEncoder{},
+	)
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	t.Run("Should describe every route and nothing else", func(t *testing.T) {
+		docsRouter := mux.NewRouter()
+		transporthttp.NewOpenAPIHandler(nil).RegisterRoutes(docsRouter)
+
+		docsRoutes := map[string]bool{}
+		for _, route := range routes(t, docsRouter) {
+			docsRoutes[route] = true
+		}
+
+		served := []string{}
+		for _, route := range routes(t, documentedRouter()) {
+			if !docsRoutes[route] {
+				served = append(served, route)
+			}
+		}
+		assert.Contains(t, served, "GET /metrics")
+
+		documented := []string{}
+		for path, item := range transporthttp.NewOpenAPIDocument("test", questionEncoders()).Paths {
+			for method := range item {
+				documented = append(documented, strings.ToUpper(method)+" "+path)
+			}
+		}
+
+		sort.Strings(served)
+		sort.Strings(documented)
+		assert.Equal(t, served, documented)
+	})
+
+	t.Run("Should only refer to defined components", func(t *testing.T) {
+		raw, err := json.Marshal(transporthttp.NewOpenAPIDocument("test", questionEncoders()))
+		require.NoError(t, err)
+
+		doc := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(raw, &doc))
+		components := doc["components"].(map[string]interface{})
+
+		var check func(value interface{})
+		check = func(value interface{}) {
+			switch value := value.(type) {
+			case map[string]interface{}:
+				if ref, ok := value["$ref"].(string); ok {
+					parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+					require.Len(t, parts, 2, ref)
+					assert.Contains(t, components[parts[0]], parts[1], ref)
+				}
+				for _, child := range value {
+					check(child)
+				}
+			case []interface{}:
+				for _, child := range value {
+					check(child)
+				}
+			}
+		}
+		check(doc)
+	})
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	router := mux.NewRouter()
+	transporthttp.NewOpenAPIHandler(transporthttp.NewOpenAPIDocument("test", questionEncoders())).RegisterRoutes(router)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	t.Run("Should serve the document", func(t *testing.T) {
+		recorder := serve("/openapi.json")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		doc := struct {
+			OpenAPI string `json:"openapi"`
+			Info    struct {
+				Version string `json:"version"`
+			} `json:"info"`
+		}{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+		assert.Equal(t, "test", doc.Info.Version)
+	})
+
+	t.Run("Should serve the Swagger UI page and its assets", func(t *testing.T) {
+		for path, contentType := range map[string]string{
+			"/docs":                      "text/html",
+			"/docs/initializer.js":       "javascript",
+			"/docs/swagger-ui-bundle.js": "javascript",
+			"/docs/swagger-ui.css":       "text/css",
+		} {
+			recorder := serve(path)
+			assert.Equal(t, http.StatusOK, recorder.Code, path)
+			assert.Contains(t, recorder.Header().Get("Content-Type"), contentType, path)
+			assert.Contains(t, recorder.Header().Get("Content-Security-Policy"), "default-src 'self'", path)
+			assert.NotZero(t, recorder.Body.Len(), path)
+		}
+
+		assert.Contains(t, serve("/docs/initializer.js").Body.String(), `url: "/openapi.json"`)
+	})
+}
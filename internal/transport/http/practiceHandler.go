@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+// Graded answers reveal the correct options, so practice is only served to requests with a caller.
+func (h *PracticeHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/practice", requireCaller(h.CreatePracticeSession())).Methods(http.MethodPost)
+	router.HandleFunc("/practice/{session}/next", requireCaller(h.GetNextQuestion())).Methods(http.MethodGet)
+	router.HandleFunc("/practice/{session}/answers", requireCaller(h.SubmitAnswer())).Methods(http.MethodPost)
+}
+
+// PracticeServicer represents necessary practice service implementation for practice handler.
+type PracticeServicer interface {
+	CreatePracticeSession(ctx context.Context,
+		sessionCreation service.PracticeSessionCreationDTO) (service.PracticeSessionDTO, error)
+	GetNextQuestion(ctx context.Context, sessionID int) (service.PracticeQuestionDTO, error)
+	SubmitAnswer(ctx context.Context,
+		sessionID int, answerCreation service.PracticeAnswerCreationDTO) (service.PracticeAnswerDTO, error)
+}
+
+// PracticeHandler handles http requests for adaptive practice sessions.
+type PracticeHandler struct {
+	practiceService PracticeServicer
+	maxBodySize     int64
+}
+
+// NewPracticeHandler creates a new instance of practice handler.
+func NewPracticeHandler(practiceService PracticeServicer, maxBodySize int64) *PracticeHandler {
+	return &PracticeHandler{
+		practiceService: practiceService,
+		maxBodySize:     maxBodySize,
+	}
+}
+
+// CreatePracticeSession handles starting a new practice session.
+func (h *PracticeHandler) CreatePracticeSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionCreationDTO := service.PracticeSessionCreationDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &sessionCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(sessionCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.practiceService.CreatePracticeSession(r.Context(), sessionCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetNextQuestion handles retrieving the next question of a practice session.
+func (h *PracticeHandler) GetNextQuestion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, err := parseID(mux.Vars(r)["session"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.practiceService.GetNextQuestion(r.Context(), sessionID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// SubmitAnswer handles grading of an answer submitted within a practice session.
+func (h *PracticeHandler) SubmitAnswer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, err := parseID(mux.Vars(r)["session"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		answerCreationDTO := service.PracticeAnswerCreationDTO{}
+
+		err = decodeJSONBody(w, r, h.maxBodySize, &answerCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(answerCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.practiceService.SubmitAnswer(r.Context(), sessionID, answerCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// Maximum size of an uploaded QTI package (32 MB).
+const maxQTIPackageSize = 32 << 20
+
+// RegisterRoutes links routes with the handler.
+// Routes are only served to requests with a caller, what the caller may do is decided by the service.
+func (h *QTIHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/questions/qti", requireCaller(h.ExportQuestions())).Methods(http.MethodGet)
+	router.HandleFunc("/questions/qti", requireCaller(h.ImportQuestions())).Methods(http.MethodPost)
+}
+
+// QTIServicer represents necessary qti service implementation for qti handler.
+type QTIServicer interface {
+	ExportQuestions(ctx context.Context, questionIDs []int) ([]byte, error)
+	ImportQuestions(ctx context.Context, data []byte) (service.QTIImportDTO, error)
+}
+
+// QTIHandler handles http requests for exchanging questions as QTI packages.
+type QTIHandler struct {
+	qtiService QTIServicer
+}
+
+// NewQTIHandler creates a new instance of qti handler.
+func NewQTIHandler(qtiService QTIServicer) *QTIHandler {
+	return &QTIHandler{
+		qtiService: qtiService,
+	}
+}
+
+// ExportQuestions handles packaging of questions selected with the ids query param.
+func (h *QTIHandler) ExportQuestions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionIDs, err := helpers.ParseIDs(r.URL.Query().Get("ids"))
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.qtiService.ExportQuestions(r.Context(), questionIDs)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="questions-qti.zip"`)
+		w.Header().Set("Content-Length", fmt.Sprint(len(res)))
+		w.Write(res)
+	}
+}
+
+// ImportQuestions handles creating questions from a QTI package sent as request body.
+func (h *QTIHandler) ImportQuestions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxQTIPackageSize))
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.qtiService.ImportQuestions(r.Context(), data)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/djurica-surla/backend-homework/internal/helpers"
@@ -13,49 +14,75 @@ import (
 )
 
 // RegisterRoutes links routes with the handler.
+// Questions and hints are public, other routes are only served to requests with a caller.
+// What the caller may do, and whether option correctness is shown, is decided by the service.
 func (h *QuestionHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/questions", h.GetQuestions()).Methods(http.MethodGet)
-	router.HandleFunc("/questions", h.CreateQuestion()).Methods(http.MethodPost)
-	router.HandleFunc("/questions/{id}", h.UpdateQuestion()).Methods(http.MethodPut)
-	router.HandleFunc("/questions/{id}", h.DeleteQuestion()).Methods(http.MethodDelete)
+	router.HandleFunc("/questions", requireCaller(h.CreateQuestion())).Methods(http.MethodPost)
+	router.HandleFunc("/questions/{id}", requireCaller(h.UpdateQuestion())).Methods(http.MethodPut)
+	router.HandleFunc("/questions/{id}", requireCaller(h.DeleteQuestion())).Methods(http.MethodDelete)
+	router.HandleFunc("/questions/{id}/explanation", requireCaller(h.GetQuestionExplanation())).Methods(http.MethodGet)
+	router.HandleFunc("/questions/{id}/hints/{n}", h.GetQuestionHint()).Methods(http.MethodGet)
 }
 
 // QuestionServicer represents necessary question service implementation for question handler.
 type QuestionServicer interface {
-	GetQuestions(ctx context.Context, pageSize, offset int) ([]service.QuestionDTO, error)
+	GetQuestions(ctx context.Context, filter service.QuestionFilter, pageSize, offset int) ([]service.QuestionDTO, error)
 	CreateQuestion(ctx context.Context, questionCreation service.QuestionCreationDTO) (service.QuestionDTO, error)
 	UpdateQuestion(ctx context.Context, questionID int, questionCreation service.QuestionCreationDTO) (service.QuestionDTO, error)
 	DeleteQuestion(ctx context.Context, questionID int) error
+	GetQuestionExplanation(ctx context.Context, questionID int) (service.QuestionExplanationDTO, error)
+	GetQuestionHint(ctx context.Context, questionID, number int) (service.QuestionHintDTO, error)
 }
 
 // QuestionHandler handles http requests for questions.
 type QuestionHandler struct {
 	questionService QuestionServicer
+	maxBodySize     int64
+	encoders        *EncoderRegistry
 }
 
 // NewQuestionHandler creates a new instance of question handler.
-func NewQuestionHandler(questionService QuestionServicer) *QuestionHandler {
+// Question listings are written by the encoder of the registry the Accept header prefers.
+func NewQuestionHandler(questionService QuestionServicer, maxBodySize int64, encoders *EncoderRegistry) *QuestionHandler {
 	return &QuestionHandler{
 		questionService: questionService,
+		maxBodySize:     maxBodySize,
+		encoders:        encoders,
 	}
 }
 
-// GetQuestions handles retrieveing questions.
+// GetQuestions handles retrieveing questions, optionally filtered by difficulty.
 func (h *QuestionHandler) GetQuestions() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// Every response depends on the Accept header, including 406 and error responses.
+		w.Header().Add("Vary", "Accept")
+
+		encoder, err := h.encoders.Negotiate(r.Header.Get("Accept"))
+		if err != nil {
+			encodeError(w, http.StatusNotAcceptable, err)
+			return
+		}
+
 		pageSize, offset, err := helpers.Paginate(r.URL.Query())
 		if err != nil {
 			h.encodeErrorWithStatus404(err, w)
 			return
 		}
 
-		res, err := h.questionService.GetQuestions(r.Context(), pageSize, offset)
+		filter, err := parseQuestionFilter(r.URL.Query())
 		if err != nil {
-			h.encodeErrorWithStatus500(err, w)
+			h.encodeErrorWithStatus404(err, w)
 			return
 		}
 
-		json.NewEncoder(w).Encode(res)
+		res, err := h.questionService.GetQuestions(r.Context(), filter, pageSize, offset)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		writeEncoded(w, r, encoder, questionTable(res))
 	}
 }
 
@@ -64,9 +91,9 @@ func (h *QuestionHandler) CreateQuestion() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		questionCreationDTO := service.QuestionCreationDTO{}
 
-		err := json.NewDecoder(r.Body).Decode(&questionCreationDTO)
+		err := decodeJSONBody(w, r, h.maxBodySize, &questionCreationDTO)
 		if err != nil {
-			h.encodeErrorWithStatus404(err, w)
+			encodeBodyError(w, err)
 			return
 		}
 
@@ -78,7 +105,7 @@ func (h *QuestionHandler) CreateQuestion() http.HandlerFunc {
 
 		res, err := h.questionService.CreateQuestion(r.Context(), questionCreationDTO)
 		if err != nil {
-			h.encodeErrorWithStatus500(err, w)
+			encodeServiceError(w, err)
 			return
 		}
 
@@ -98,9 +125,9 @@ func (h *QuestionHandler) UpdateQuestion() http.HandlerFunc {
 
 		questionCreationDTO := service.QuestionCreationDTO{}
 
-		err = json.NewDecoder(r.Body).Decode(&questionCreationDTO)
+		err = decodeJSONBody(w, r, h.maxBodySize, &questionCreationDTO)
 		if err != nil {
-			h.encodeErrorWithStatus404(err, w)
+			encodeBodyError(w, err)
 			return
 		}
 
@@ -112,7 +139,7 @@ func (h *QuestionHandler) UpdateQuestion() http.HandlerFunc {
 
 		res, err := h.questionService.UpdateQuestion(r.Context(), questionID, questionCreationDTO)
 		if err != nil {
-			h.encodeErrorWithStatus500(err, w)
+			encodeServiceError(w, err)
 			return
 		}
 
@@ -132,7 +159,7 @@ func (h *QuestionHandler) DeleteQuestion() http.HandlerFunc {
 
 		err = h.questionService.DeleteQuestion(r.Context(), questionID)
 		if err != nil {
-			h.encodeErrorWithStatus500(err, w)
+			encodeServiceError(w, err)
 			return
 		}
 
@@ -140,14 +167,129 @@ func (h *QuestionHandler) DeleteQuestion() http.HandlerFunc {
 	}
 }
 
-func (h *QuestionHandler) encodeErrorWithStatus500(err error, w http.ResponseWriter) {
-	errorResponse := fmt.Sprintf("error: %s", err.Error())
-	w.WriteHeader(http.StatusInternalServerError)
-	w.Write([]byte(errorResponse))
+// GetQuestionExplanation handles retrieving explanation of question and its options.
+func (h *QuestionHandler) GetQuestionExplanation() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			h.encodeErrorWithStatus404(err, w)
+			return
+		}
+
+		res, err := h.questionService.GetQuestionExplanation(r.Context(), questionID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetQuestionHint handles retrieving n-th hint of question.
+func (h *QuestionHandler) GetQuestionHint() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			h.encodeErrorWithStatus404(err, w)
+			return
+		}
+
+		number, err := parseID(mux.Vars(r)["n"])
+		if err != nil {
+			h.encodeErrorWithStatus404(err, w)
+			return
+		}
+
+		res, err := h.questionService.GetQuestionHint(r.Context(), questionID, number)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
 }
 
 func (h *QuestionHandler) encodeErrorWithStatus404(err error, w http.ResponseWriter) {
-	errorResponse := fmt.Sprintf("error: %s", err.Error())
-	w.WriteHeader(http.StatusBadRequest)
-	w.Write([]byte(errorResponse))
+	encodeError(w, http.StatusBadRequest, err)
+}
+
+// parseQuestionFilter extracts difficulty filters from query values, missing values are not applied.
+func parseQuestionFilter(query url.Values) (service.QuestionFilter, error) {
+	filter := service.QuestionFilter{}
+
+	for key, target := range map[string]**int{
+		"min_difficulty": &filter.MinDifficulty,
+		"max_difficulty": &filter.MaxDifficulty,
+	} {
+		if query.Get(key) == "" {
+			continue
+		}
+		value, err := strconv.Atoi(query.Get(key))
+		if err != nil {
+			return service.QuestionFilter{}, fmt.Errorf("error converting %s query param to number: %w", key, err)
+		}
+		*target = &value
+	}
+
+	for key, target := range map[string]**float64{
+		"min_empirical_difficulty": &filter.MinEmpiricalDifficulty,
+		"max_empirical_difficulty": &filter.MaxEmpiricalDifficulty,
+	} {
+		if query.Get(key) == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(query.Get(key), 64)
+		if err != nil {
+			return service.QuestionFilter{}, fmt.Errorf("error converting %s query param to number: %w", key, err)
+		}
+		*target = &value
+	}
+
+	return filter, nil
+}
+
+// questionTable is a question listing, written as csv with a row per option of each question.
+type questionTable []service.QuestionDTO
+
+// MarshalCSV returns a row per option, questions without options get a row with empty option cells.
+// Option correctness is empty when the caller may not see it.
+func (t questionTable) MarshalCSV() ([]string, [][]string) {
+	header := []string{
+		"question_id", "body", "difficulty", "empirical_difficulty", "response_count", "owner",
+		"option_id", "option_body", "option_correct",
+	}
+
+	records := [][]string{}
+	for _, question := range t {
+		questionCells := []string{
+			strconv.Itoa(question.ID),
+			question.Body,
+			strconv.Itoa(question.Difficulty),
+			"",
+			strconv.Itoa(question.ResponseCount),
+			question.Owner,
+		}
+		if question.EmpiricalDifficulty != nil {
+			questionCells[3] = strconv.FormatFloat(*question.EmpiricalDifficulty, 'f', -1, 64)
+		}
+
+		if len(question.Options) == 0 {
+			records = append(records, append(questionCells, "", "", ""))
+			continue
+		}
+
+		for _, option := range question.Options {
+			correct := ""
+			if option.Correct != nil {
+				correct = strconv.FormatBool(*option.Correct)
+			}
+
+			record := append(append([]string{}, questionCells...), strconv.Itoa(option.ID), option.Body, correct)
+			records = append(records, record)
+		}
+	}
+
+	return header, records
 }
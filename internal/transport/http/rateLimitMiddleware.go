@@ -0,0 +1,151 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/djurica-surla/backend-homework/internal/ratelimit"
+	"github.com/gorilla/mux"
+)
+
+// RouteLimit overrides the default limit for the route with the path template, for every method when method is empty.
+type RouteLimit struct {
+	Method string
+	Path   string
+	Limit  ratelimit.Limit
+}
+
+// RateLimitPolicy represents the limits of each client.
+type RateLimitPolicy struct {
+	// Limit of the routes without a route limit, which share a single bucket per client.
+	Default ratelimit.Limit
+	// Limits of individual routes, each with its own bucket per client.
+	Routes []RouteLimit
+	// Limit of each client ip over every route, taken before the request is authenticated.
+	ClientIP ratelimit.Limit
+	// Header a trusted proxy appends the client ip to, the remote address is used when empty.
+	ClientIPHeader string
+}
+
+// NewRateLimitMiddleware creates a middleware which limits the requests of each client,
+// keyed by the resolved caller or else by the client ip, so it must run after the identity middleware.
+// Requests over the limit are rejected with 429, limits are described by RateLimit headers.
+func NewRateLimitMiddleware(store ratelimit.Store, policy RateLimitPolicy) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name, limit := policy.limit(r)
+			if limit.Unlimited() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !takeRateLimitToken(w, r, store, name+"|"+policy.client(r), limit) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// NewClientIPRateLimitMiddleware creates a middleware which limits the requests of each client ip
+// by the client ip limit of the policy, whatever the route or caller. It must run before the authentication
+// middlewares, so requests with credentials which fail to authenticate are limited too.
+func NewClientIPRateLimitMiddleware(store ratelimit.Store, policy RateLimitPolicy) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy.ClientIP.Unlimited() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !takeRateLimitToken(w, r, store, "client|"+policy.clientIP(r), policy.ClientIP) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// takeRateLimitToken takes a token of the limit from the bucket and describes the limit in the response headers.
+// It responds with 429 and reports false when the bucket is empty.
+func takeRateLimitToken(w http.ResponseWriter, r *http.Request, store ratelimit.Store,
+	key string, limit ratelimit.Limit) bool {
+	result, err := store.Take(r.Context(), key, limit)
+	if err != nil {
+		// Serve the request rather than fail every request while the store is unavailable.
+		logging.FromContext(r.Context()).Warn("error taking rate limit token", "error", err)
+		return true
+	}
+
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+	w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Requests, ceilSeconds(limit.Period)))
+
+	if !result.Allowed {
+		w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+		encodeError(w, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+		return false
+	}
+
+	return true
+}
+
+// limit returns the bucket name and limit of the matched route.
+func (p RateLimitPolicy) limit(r *http.Request) (string, ratelimit.Limit) {
+	template := routeTemplate(r)
+
+	for _, route := range p.Routes {
+		if route.Path == template && (route.Method == "" || strings.EqualFold(route.Method, r.Method)) {
+			return "route:" + route.Method + " " + route.Path, route.Limit
+		}
+	}
+
+	return "default", p.Default
+}
+
+// client returns the key of the client, the resolved caller or else the client ip.
+func (p RateLimitPolicy) client(r *http.Request) string {
+	if caller, ok := auth.CallerFromContext(r.Context()); ok {
+		return "caller:" + caller.ID
+	}
+
+	return "ip:" + p.clientIP(r)
+}
+
+// clientIP returns the ip of the client, as appended to the client ip header by the trusted proxy
+// or else the remote address.
+func (p RateLimitPolicy) clientIP(r *http.Request) string {
+	if p.ClientIPHeader != "" {
+		// Clients can send the header with addresses of their choosing, only the last one was added by the proxy.
+		forwarded := r.Header.Values(p.ClientIPHeader)
+		if len(forwarded) > 0 {
+			addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(addresses[len(addresses)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return host
+}
+
+// ceilSeconds formats the duration as whole seconds, rounded up so clients never retry too early.
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,141 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/djurica-surla/backend-homework/internal/ratelimit"
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRateLimitedRouter creates a router limiting /questions to a single request and every other route to two.
+func newRateLimitedRouter() *mux.Router {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := ratelimit.NewMemoryStore(func() time.Time { return now })
+
+	router := mux.NewRouter()
+	router.Use(transporthttp.NewIdentityMiddleware(transporthttp.NewTrustedHeaderResolver("X-User-Id", "X-User-Role")))
+	router.Use(transporthttp.NewRateLimitMiddleware(store, transporthttp.RateLimitPolicy{
+		Default: ratelimit.Limit{Requests: 2, Period: time.Minute},
+		Routes: []transporthttp.RouteLimit{
+			{Method: http.MethodGet, Path: "/questions", Limit: ratelimit.Limit{Requests: 1, Period: time.Minute}},
+		},
+	}))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	router.HandleFunc("/questions", ok).Methods(http.MethodGet)
+	router.HandleFunc("/runs", ok).Methods(http.MethodPost)
+
+	return router
+}
+
+// request serves a request from the remote address, as the caller when callerID isn't empty.
+func request(router *mux.Router, method, path, remoteAddr, callerID string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	r.RemoteAddr = remoteAddr
+	if callerID != "" {
+		r.Header.Set("X-User-Id", callerID)
+		r.Header.Set("X-User-Role", "taker")
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+
+	return recorder
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Run("Should describe the limit and reject requests over the route limit with 429", func(t *testing.T) {
+		router := newRateLimitedRouter()
+
+		recorder := request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "60", recorder.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "1;w=60", recorder.Header().Get("RateLimit-Policy"))
+		assert.Empty(t, recorder.Header().Get("Retry-After"))
+
+		recorder = request(router, http.MethodGet, "/questions", "10.0.0.1:5678", "")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("Should limit routes without a route limit by the default limit", func(t *testing.T) {
+		router := newRateLimitedRouter()
+
+		assert.Equal(t, http.StatusOK, request(router, http.MethodPost, "/runs", "10.0.0.1:1234", "").Code)
+		assert.Equal(t, http.StatusOK, request(router, http.MethodPost, "/runs", "10.0.0.1:1234", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(router, http.MethodPost, "/runs", "10.0.0.1:1234", "").Code)
+
+		// The route limit has its own bucket.
+		assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "").Code)
+	})
+
+	t.Run("Should keep separate buckets per client ip and per caller", func(t *testing.T) {
+		router := newRateLimitedRouter()
+
+		assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "").Code)
+		assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/questions", "10.0.0.2:1234", "").Code)
+		assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "first").Code)
+		assert.Equal(t, http.StatusOK, request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "second").Code)
+		assert.Equal(t, http.StatusTooManyRequests,
+			request(router, http.MethodGet, "/questions", "10.0.0.2:1234", "first").Code)
+	})
+
+	t.Run("Should limit by the last address of the client ip header", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		router := mux.NewRouter()
+		router.Use(transporthttp.NewRateLimitMiddleware(ratelimit.NewMemoryStore(func() time.Time { return now }),
+			transporthttp.RateLimitPolicy{
+				Default:        ratelimit.Limit{Requests: 1, Period: time.Minute},
+				ClientIPHeader: "X-Forwarded-For",
+			}))
+		router.HandleFunc("/runs", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodPost)
+
+		forwarded := func(header string) int {
+			r := httptest.NewRequest(http.MethodPost, "/runs", nil)
+			r.Header.Set("X-Forwarded-For", header)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, r)
+
+			return recorder.Code
+		}
+
+		assert.Equal(t, http.StatusOK, forwarded("1.1.1.1, 10.0.0.1"))
+		// Spoofing the first address doesn't get the client a new bucket.
+		assert.Equal(t, http.StatusTooManyRequests, forwarded("2.2.2.2, 10.0.0.1"))
+		assert.Equal(t, http.StatusOK, forwarded("1.1.1.1, 10.0.0.2"))
+	})
+}
+
+func TestClientIPRateLimitMiddleware(t *testing.T) {
+	t.Run("Should limit requests of the client ip before they are authenticated", func(t *testing.T) {
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		authenticated := 0
+
+		router := mux.NewRouter()
+		router.Use(transporthttp.NewClientIPRateLimitMiddleware(ratelimit.NewMemoryStore(func() time.Time { return now }),
+			transporthttp.RateLimitPolicy{ClientIP: ratelimit.Limit{Requests: 2, Period: time.Minute}}))
+		router.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authenticated++
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		})
+		router.HandleFunc("/questions", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+
+		for _, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+			assert.Equal(t, want, request(router, http.MethodGet, "/questions", "10.0.0.1:1234", "").Code)
+		}
+		assert.Equal(t, 2, authenticated)
+
+		assert.Equal(t, http.StatusUnauthorized, request(router, http.MethodGet, "/questions", "10.0.0.2:1234", "").Code)
+	})
+}
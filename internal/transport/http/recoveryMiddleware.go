@@ -0,0 +1,38 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/djurica-surla/backend-homework/internal/logging"
+	"github.com/gorilla/mux"
+)
+
+// NewRecoveryMiddleware creates a middleware which recovers from panics of the handlers,
+// logging the stack with the request logger and responding with a json 500.
+func NewRecoveryMiddleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				// Aborting the handler is how net/http is asked to drop the connection.
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(recovered)
+				}
+
+				logging.FromContext(r.Context()).Error("recovered from panic",
+					"panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+
+				encodeError(w, http.StatusInternalServerError, errors.New("internal server error"))
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorBody is the json schema of error responses.
+type errorBody struct {
+	Status    int    `json:"status"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// newRouter creates a router with the error handlers and middlewares of the server, logging to out.
+func newRouter(out *bytes.Buffer) *mux.Router {
+	router := mux.NewRouter().StrictSlash(true)
+	router.NotFoundHandler = transporthttp.NewNotFoundHandler()
+	router.MethodNotAllowedHandler = transporthttp.NewMethodNotAllowedHandler()
+	router.Use(transporthttp.NewRequestLoggingMiddleware(slog.New(slog.NewJSONHandler(out, nil))))
+	router.Use(transporthttp.NewRecoveryMiddleware())
+
+	router.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("some-panic")
+	}).Methods(http.MethodGet)
+
+	return router
+}
+
+// serve serves the request and decodes the json error response.
+func serve(t *testing.T, router *mux.Router, r *http.Request) (*httptest.ResponseRecorder, errorBody) {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, r)
+
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	body := errorBody{}
+	err := json.NewDecoder(recorder.Body).Decode(&body)
+	require.NoError(t, err)
+
+	return recorder, body
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	t.Run("Should respond with json 500 and log the stack with the request id", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		r := httptest.NewRequest(http.MethodGet, "/panic", nil)
+		r.Header.Set("X-Request-ID", "request-1")
+
+		recorder, body := serve(t, newRouter(out), r)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Equal(t, errorBody{
+			Status:    http.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "internal server error",
+			RequestID: "request-1",
+		}, body)
+
+		assert.Contains(t, out.String(), `"msg":"recovered from panic","request_id":"request-1"`)
+		assert.Contains(t, out.String(), `"panic":"some-panic"`)
+		assert.Contains(t, out.String(), "runtime/debug.Stack")
+	})
+}
+
+func TestErrorHandlers(t *testing.T) {
+	t.Run("Should respond to unknown route with json 404", func(t *testing.T) {
+		recorder, body := serve(t, newRouter(&bytes.Buffer{}), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Equal(t, http.StatusNotFound, body.Status)
+		assert.Equal(t, "route /unknown not found", body.Message)
+	})
+
+	t.Run("Should respond to unknown method with json 405", func(t *testing.T) {
+		recorder, body := serve(t, newRouter(&bytes.Buffer{}), httptest.NewRequest(http.MethodPost, "/panic", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, body.Status)
+		assert.Equal(t, "method POST not allowed for /panic", body.Message)
+	})
+}
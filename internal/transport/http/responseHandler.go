@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+// Graded responses reveal the correct options, so they are only served to requests with a caller.
+func (h *ResponseHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/questions/{id}/responses", requireCaller(h.RecordResponse())).Methods(http.MethodPost)
+}
+
+// ResponseServicer represents necessary response service implementation for response handler.
+type ResponseServicer interface {
+	RecordResponse(ctx context.Context, questionID int,
+		responseCreation service.QuestionResponseCreationDTO) (service.QuestionResponseDTO, error)
+}
+
+// ResponseHandler handles http requests for responses to questions.
+type ResponseHandler struct {
+	responseService ResponseServicer
+	maxBodySize     int64
+}
+
+// NewResponseHandler creates a new instance of response handler.
+func NewResponseHandler(responseService ResponseServicer, maxBodySize int64) *ResponseHandler {
+	return &ResponseHandler{
+		responseService: responseService,
+		maxBodySize:     maxBodySize,
+	}
+}
+
+// RecordResponse handles grading and recording of a response to question.
+func (h *ResponseHandler) RecordResponse() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		responseCreationDTO := service.QuestionResponseCreationDTO{}
+
+		err = decodeJSONBody(w, r, h.maxBodySize, &responseCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(responseCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.responseService.RecordResponse(r.Context(), questionID, responseCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
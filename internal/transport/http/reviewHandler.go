@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// Maximum length of a caller supplied user identifier.
+const maxUserIDLength = 255
+
+// RegisterRoutes links routes with the handler.
+func (h *ReviewHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/users/{id}/reviews/due", h.GetDueReviews()).Methods(http.MethodGet)
+	router.HandleFunc("/users/{id}/reviews", h.RecordReview()).Methods(http.MethodPost)
+}
+
+// ReviewServicer represents necessary review service implementation for review handler.
+type ReviewServicer interface {
+	GetDueReviews(ctx context.Context, userID string, pageSize, offset int) ([]service.ReviewDTO, error)
+	RecordReview(ctx context.Context, userID string, reviewCreation service.ReviewCreationDTO) (service.ReviewDTO, error)
+}
+
+// ReviewHandler handles http requests for spaced repetition reviews.
+type ReviewHandler struct {
+	reviewService ReviewServicer
+	maxBodySize   int64
+}
+
+// NewReviewHandler creates a new instance of review handler.
+func NewReviewHandler(reviewService ReviewServicer, maxBodySize int64) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+		maxBodySize:   maxBodySize,
+	}
+}
+
+// GetDueReviews handles retrieving questions which are due for review by the user.
+func (h *ReviewHandler) GetDueReviews() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := parseUserID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		pageSize, offset, err := helpers.Paginate(r.URL.Query())
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.reviewService.GetDueReviews(r.Context(), userID, pageSize, offset)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// RecordReview handles recording of a review grade given by the user.
+func (h *ReviewHandler) RecordReview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := parseUserID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		reviewCreationDTO := service.ReviewCreationDTO{}
+
+		err = decodeJSONBody(w, r, h.maxBodySize, &reviewCreationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(reviewCreationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.reviewService.RecordReview(r.Context(), userID, reviewCreationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// parseUserID validates a caller supplied user identifier.
+func parseUserID(value string) (string, error) {
+	if value == "" || len(value) > maxUserIDLength {
+		return "", errors.New("user id must be between 1 and 255 characters")
+	}
+	return value, nil
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Handlers holds the handlers of every route the server serves.
+type Handlers struct {
+	// Serves metrics for prometheus to scrape.
+	Metrics http.Handler
+
+	// Handlers of routes served outside of the api middlewares.
+	Health  *HealthHandler
+	OpenAPI *OpenAPIHandler
+
+	// Handlers of the api routes.
+	Question    *QuestionHandler
+	QTI         *QTIHandler
+	Attachment  *AttachmentHandler
+	Response    *ResponseHandler
+	Statistics  *StatisticsHandler
+	Review      *ReviewHandler
+	Practice    *PracticeHandler
+	Leaderboard *LeaderboardHandler
+	User        *UserHandler
+	Token       *TokenHandler
+	APIKey      *APIKeyHandler
+}
+
+// RegisterRoutes links the routes of every handler with the router. Metrics, probes and docs are
+// registered ahead of the api middlewares, every other route is served by an api subrouter
+// which passes requests through the api middlewares in order.
+func RegisterRoutes(router *mux.Router, handlers Handlers, apiMiddlewares ...mux.MiddlewareFunc) {
+	router.Handle("/metrics", handlers.Metrics).Methods(http.MethodGet)
+	handlers.Health.RegisterRoutes(router)
+	handlers.OpenAPI.RegisterRoutes(router)
+
+	api := router.PathPrefix("/").Subrouter()
+	api.Use(apiMiddlewares...)
+
+	handlers.Question.RegisterRoutes(api)
+	handlers.QTI.RegisterRoutes(api)
+	handlers.Attachment.RegisterRoutes(api)
+	handlers.Response.RegisterRoutes(api)
+	handlers.Statistics.RegisterRoutes(api)
+	handlers.Review.RegisterRoutes(api)
+	handlers.Practice.RegisterRoutes(api)
+	handlers.Leaderboard.RegisterRoutes(api)
+	handlers.User.RegisterRoutes(api)
+	handlers.Token.RegisterRoutes(api)
+	handlers.APIKey.RegisterRoutes(api)
+}
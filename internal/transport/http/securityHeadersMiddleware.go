@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// SecurityHeaders represents the security headers added to every response, empty headers are left out.
+type SecurityHeaders struct {
+	ContentSecurityPolicy string
+	FrameOptions          string
+	ReferrerPolicy        string
+	// Set when the api is only served over https, e.g. "max-age=63072000; includeSubDomains".
+	StrictTransportSecurity string
+}
+
+// NewSecurityHeadersMiddleware creates a middleware which adds the security headers to every response,
+// including X-Content-Type-Options so browsers don't sniff content types. It wraps the router so
+// unrouted responses get them too.
+func NewSecurityHeadersMiddleware(headers SecurityHeaders) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			setIfNotEmpty(w, "Content-Security-Policy", headers.ContentSecurityPolicy)
+			setIfNotEmpty(w, "X-Frame-Options", headers.FrameOptions)
+			setIfNotEmpty(w, "Referrer-Policy", headers.ReferrerPolicy)
+			setIfNotEmpty(w, "Strict-Transport-Security", headers.StrictTransportSecurity)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setIfNotEmpty sets the response header unless the value is empty.
+func setIfNotEmpty(w http.ResponseWriter, header, value string) {
+	if value != "" {
+		w.Header().Set(header, value)
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+// Routes are only served to requests with a caller, which options are correct is decided by the service.
+func (h *StatisticsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/questions/stats", requireCaller(h.GetQuestionStatsReport())).Methods(http.MethodGet)
+	router.HandleFunc("/questions/{id}/stats", requireCaller(h.GetQuestionStats())).Methods(http.MethodGet)
+}
+
+// StatisticsServicer represents necessary statistics service implementation for statistics handler.
+type StatisticsServicer interface {
+	GetQuestionStats(ctx context.Context, questionID int) (service.QuestionStatsDTO, error)
+	GetQuestionStatsReport(ctx context.Context, questionIDs []int) ([]service.QuestionStatsDTO, error)
+}
+
+// StatisticsHandler handles http requests for item analysis statistics.
+type StatisticsHandler struct {
+	statisticsService StatisticsServicer
+}
+
+// NewStatisticsHandler creates a new instance of statistics handler.
+func NewStatisticsHandler(statisticsService StatisticsServicer) *StatisticsHandler {
+	return &StatisticsHandler{
+		statisticsService: statisticsService,
+	}
+}
+
+// GetQuestionStats handles retrieving statistics of a question.
+func (h *StatisticsHandler) GetQuestionStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionID, err := parseID(mux.Vars(r)["id"])
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.statisticsService.GetQuestionStats(r.Context(), questionID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// GetQuestionStatsReport handles retrieving statistics of questions selected with the ids query param.
+func (h *StatisticsHandler) GetQuestionStatsReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		questionIDs, err := helpers.ParseIDs(r.URL.Query().Get("ids"))
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.statisticsService.GetQuestionStatsReport(r.Context(), questionIDs)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	"github.com/gorilla/mux"
+)
+
+// Maximum length of a tenant id, matching the tenant_id columns.
+const maxTenantIDLength = 255
+
+// Login routes check the credentials of the request body against users of the tenant named by the header,
+// so naming a tenant there grants nothing without credentials of the tenant.
+var tenantLoginRoutes = map[string]bool{
+	http.MethodPost + " /users/login": true,
+	http.MethodPost + " /auth/token":  true,
+}
+
+// TenantPolicy represents how the tenant of a request is resolved.
+type TenantPolicy struct {
+	// Header naming the tenant, and the tenant of requests which may not name one.
+	Header        string
+	DefaultTenant string
+	// Admin token and header of the caller id set by a trusted proxy, requests carrying either may name any tenant.
+	// They are ignored when empty.
+	AdminToken            string
+	TrustedIdentityHeader string
+}
+
+// NewTenantMiddleware creates a middleware which puts the tenant of the request into the request context.
+// It must run after the authentication middlewares: requests authenticated by a user or api key belong to
+// the tenant of their credentials, and naming another tenant in the header is rejected.
+// Requests carrying the admin token or a trusted proxy identity, and logins, belong to the tenant named by the header.
+// Other requests can't choose their tenant, the header is ignored and they belong to the default tenant,
+// they are rejected when the default tenant is empty.
+func NewTenantMiddleware(policy TenantPolicy) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID := r.Header.Get(policy.Header)
+
+			if credentialTenantID, ok := credentialTenant(r); ok {
+				if tenantID != "" && tenantID != credentialTenantID {
+					encodeError(w, http.StatusForbidden, errors.New("credentials belong to another tenant"))
+					return
+				}
+				tenantID = credentialTenantID
+			} else if !policy.maySelectTenant(r) {
+				if policy.DefaultTenant == "" {
+					encodeError(w, http.StatusUnauthorized, errors.New("authentication required"))
+					return
+				}
+				tenantID = policy.DefaultTenant
+			}
+
+			if tenantID == "" {
+				tenantID = policy.DefaultTenant
+			}
+
+			if tenantID == "" {
+				encodeError(w, http.StatusBadRequest, fmt.Errorf("%s header is required", policy.Header))
+				return
+			}
+			if len(tenantID) > maxTenantIDLength {
+				encodeError(w, http.StatusBadRequest,
+					fmt.Errorf("%s header can't be longer than %d characters", policy.Header, maxTenantIDLength))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.WithTenant(r.Context(), tenantID)))
+		})
+	}
+}
+
+// maySelectTenant reports whether the request logs in, or carries the admin token or an identity set by
+// the trusted proxy.
+func (p TenantPolicy) maySelectTenant(r *http.Request) bool {
+	if tenantLoginRoutes[r.Method+" "+routeTemplate(r)] || validAdminToken(r, p.AdminToken) {
+		return true
+	}
+
+	return p.TrustedIdentityHeader != "" && r.Header.Get(p.TrustedIdentityHeader) != ""
+}
+
+// credentialTenant returns the tenant of the user or api key authenticated by earlier middlewares, if any.
+func credentialTenant(r *http.Request) (string, bool) {
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		return user.TenantID, user.TenantID != ""
+	}
+
+	if key, ok := auth.APIKeyFromContext(r.Context()); ok {
+		return key.TenantID, key.TenantID != ""
+	}
+
+	return "", false
+}
@@ -0,0 +1,144 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/tenant"
+	transporthttp "github.com/djurica-surla/backend-homework/internal/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tenant policy of the tests, admins and the trusted proxy may name the tenant.
+var tenantPolicy = transporthttp.TenantPolicy{
+	Header:                "X-Tenant-ID",
+	DefaultTenant:         tenant.Default,
+	AdminToken:            "admin-token",
+	TrustedIdentityHeader: "X-Caller-ID",
+}
+
+// serveTenant serves the request through the tenant middleware and returns the response and the resolved tenant.
+func serveTenant(r *http.Request) (*httptest.ResponseRecorder, string) {
+	return serveTenantWithPolicy(tenantPolicy, r)
+}
+
+// serveTenantWithPolicy serves the request through the tenant middleware of the policy.
+func serveTenantWithPolicy(policy transporthttp.TenantPolicy, r *http.Request) (*httptest.ResponseRecorder, string) {
+	var tenantID string
+
+	handler := transporthttp.NewTenantMiddleware(policy)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID, _ = tenant.FromContext(r.Context())
+		}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, r)
+
+	return recorder, tenantID
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	t.Run("Should ignore the header tenant of unauthenticated request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+
+		recorder, tenantID := serveTenant(r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, tenant.Default, tenantID)
+	})
+
+	t.Run("Should reject unauthenticated request without a default tenant", func(t *testing.T) {
+		policy := tenantPolicy
+		policy.DefaultTenant = ""
+
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+
+		recorder, _ := serveTenantWithPolicy(policy, r)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("Should use the header tenant of request with the admin token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+		r.Header.Set("X-Admin-Token", "admin-token")
+
+		recorder, tenantID := serveTenant(r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "school-a", tenantID)
+	})
+
+	t.Run("Should ignore the header tenant of request with a wrong admin token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+		r.Header.Set("X-Admin-Token", "guessed-token")
+
+		recorder, tenantID := serveTenant(r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, tenant.Default, tenantID)
+	})
+
+	t.Run("Should use the header tenant of request with a trusted proxy identity", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+		r.Header.Set("X-Caller-ID", "proxy-user")
+
+		recorder, tenantID := serveTenant(r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "school-a", tenantID)
+	})
+
+	t.Run("Should use the default tenant without the header", func(t *testing.T) {
+		recorder, tenantID := serveTenant(httptest.NewRequest(http.MethodGet, "/questions", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, tenant.Default, tenantID)
+	})
+
+	t.Run("Should use the header tenant of login request", func(t *testing.T) {
+		var tenantID string
+
+		router := mux.NewRouter()
+		router.Use(transporthttp.NewTenantMiddleware(tenantPolicy))
+		router.HandleFunc("/users/login", func(w http.ResponseWriter, r *http.Request) {
+			tenantID, _ = tenant.FromContext(r.Context())
+		}).Methods(http.MethodPost)
+
+		r := httptest.NewRequest(http.MethodPost, "/users/login", nil)
+		r.Header.Set("X-Tenant-ID", "school-a")
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "school-a", tenantID)
+	})
+
+	t.Run("Should use the tenant of the authenticated user", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r = r.WithContext(auth.WithUser(r.Context(), auth.User{ID: 1, TenantID: "school-a"}))
+
+		recorder, tenantID := serveTenant(r)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "school-a", tenantID)
+	})
+
+	t.Run("Should reject user naming another tenant", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r.Header.Set("X-Tenant-ID", "school-b")
+		r = r.WithContext(auth.WithUser(r.Context(), auth.User{ID: 1, TenantID: "school-a"}))
+
+		recorder, _ := serveTenant(r)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("Should reject api key naming another tenant", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/questions", nil)
+		r.Header.Set("X-Tenant-ID", "school-b")
+		r = r.WithContext(auth.WithAPIKey(r.Context(), auth.APIKey{ID: 3, TenantID: "school-a"}))
+
+		recorder, _ := serveTenant(r)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+func (h *TokenHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/auth/token", h.IssueTokens()).Methods(http.MethodPost)
+	router.HandleFunc("/auth/refresh", h.RefreshTokens()).Methods(http.MethodPost)
+	router.HandleFunc("/auth/revoke", h.RevokeTokens()).Methods(http.MethodPost)
+}
+
+// TokenServicer represents necessary token service implementation for token handler.
+type TokenServicer interface {
+	IssueTokens(ctx context.Context, login service.UserLoginDTO) (service.TokenPairDTO, error)
+	RefreshTokens(ctx context.Context, refresh service.RefreshTokenDTO) (service.TokenPairDTO, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	RevokeAccessToken(ctx context.Context, claims auth.Claims) error
+}
+
+// TokenHandler handles http requests for jwt access and refresh tokens.
+type TokenHandler struct {
+	tokenService TokenServicer
+	maxBodySize  int64
+}
+
+// NewTokenHandler creates a new instance of token handler.
+func NewTokenHandler(tokenService TokenServicer, maxBodySize int64) *TokenHandler {
+	return &TokenHandler{
+		tokenService: tokenService,
+		maxBodySize:  maxBodySize,
+	}
+}
+
+// IssueTokens handles checking of user credentials and returns a new token pair.
+func (h *TokenHandler) IssueTokens() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loginDTO := service.UserLoginDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &loginDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(loginDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.tokenService.IssueTokens(r.Context(), loginDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// RefreshTokens handles exchanging of a refresh token for a new token pair.
+func (h *TokenHandler) RefreshTokens() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		refreshDTO := service.RefreshTokenDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &refreshDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(refreshDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.tokenService.RefreshTokens(r.Context(), refreshDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// RevokeTokens handles revoking of the refresh token and of the access token used for the request.
+func (h *TokenHandler) RevokeTokens() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		refreshDTO := service.RefreshTokenDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &refreshDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(refreshDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		err = h.tokenService.RevokeRefreshToken(r.Context(), refreshDTO.RefreshToken)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		if claims, ok := auth.ClaimsFromContext(r.Context()); ok {
+			err = h.tokenService.RevokeAccessToken(r.Context(), claims)
+			if err != nil {
+				encodeServiceError(w, err)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
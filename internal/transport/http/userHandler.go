@@ -0,0 +1,136 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/djurica-surla/backend-homework/internal/auth"
+	"github.com/djurica-surla/backend-homework/internal/helpers"
+	"github.com/djurica-surla/backend-homework/internal/service"
+	"github.com/gorilla/mux"
+)
+
+// RegisterRoutes links routes with the handler.
+func (h *UserHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/users/register", h.Register()).Methods(http.MethodPost)
+	router.HandleFunc("/users/login", h.Login()).Methods(http.MethodPost)
+	router.HandleFunc("/users/logout", h.Logout()).Methods(http.MethodPost)
+	router.HandleFunc("/users/me", h.GetCurrentUser()).Methods(http.MethodGet)
+}
+
+// UserServicer represents necessary user service implementation for user handler.
+type UserServicer interface {
+	Register(ctx context.Context, registration service.UserRegistrationDTO) (service.UserDTO, error)
+	Login(ctx context.Context, login service.UserLoginDTO) (service.SessionDTO, error)
+	Logout(ctx context.Context, token string) error
+	GetUserByID(ctx context.Context, userID int) (service.UserDTO, error)
+}
+
+// UserHandler handles http requests for user accounts and sessions.
+type UserHandler struct {
+	userService UserServicer
+	maxBodySize int64
+}
+
+// NewUserHandler creates a new instance of user handler.
+func NewUserHandler(userService UserServicer, maxBodySize int64) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		maxBodySize: maxBodySize,
+	}
+}
+
+// Register handles registration of a new user.
+func (h *UserHandler) Register() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		registrationDTO := service.UserRegistrationDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &registrationDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(registrationDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.userService.Register(r.Context(), registrationDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// Login handles checking of user credentials and returns a new session token.
+func (h *UserHandler) Login() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loginDTO := service.UserLoginDTO{}
+
+		err := decodeJSONBody(w, r, h.maxBodySize, &loginDTO)
+		if err != nil {
+			encodeBodyError(w, err)
+			return
+		}
+
+		err = helpers.ValidateStruct(loginDTO)
+		if err != nil {
+			encodeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := h.userService.Login(r.Context(), loginDTO)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// Logout handles ending of the session of the bearer token.
+func (h *UserHandler) Logout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok, err := bearerToken(r)
+		if err != nil || !ok {
+			encodeError(w, http.StatusUnauthorized, errors.New("authentication required"))
+			return
+		}
+
+		err = h.userService.Logout(r.Context(), token)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetCurrentUser handles retrieving the user who made the request.
+func (h *UserHandler) GetCurrentUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := auth.UserFromContext(r.Context())
+		if !ok {
+			encodeError(w, http.StatusUnauthorized, errors.New("authentication required"))
+			return
+		}
+
+		res, err := h.userService.GetUserByID(r.Context(), user.ID)
+		if err != nil {
+			encodeServiceError(w, err)
+			return
+		}
+
+		json.NewEncoder(w).Encode(res)
+	}
+}
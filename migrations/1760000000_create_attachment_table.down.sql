@@ -0,0 +1,2 @@
+-- Drop table attachment
+DROP TABLE IF EXISTS attachment
@@ -0,0 +1,21 @@
+-- Create attachment table
+-- Attachment belongs either to a question or to a question option.
+CREATE TABLE IF NOT EXISTS attachment (
+    id INTEGER PRIMARY KEY,
+    question_id INTEGER,
+    question_option_id INTEGER,
+    filename VARCHAR(255),
+    content_type VARCHAR(255),
+    size INTEGER,
+    storage_key VARCHAR(255) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_question_option
+    FOREIGN KEY (question_option_id)
+    REFERENCES question_option(id)
+    ON DELETE CASCADE,
+    CHECK ((question_id IS NULL) <> (question_option_id IS NULL))
+);
@@ -0,0 +1,12 @@
+-- Restore question body to VARCHAR(255)
+CREATE TABLE IF NOT EXISTS question_old (
+    id INTEGER PRIMARY KEY,
+    body VARCHAR(255)
+);
+
+INSERT INTO question_old (id, body)
+SELECT id, body FROM question;
+
+DROP TABLE question;
+
+ALTER TABLE question_old RENAME TO question;
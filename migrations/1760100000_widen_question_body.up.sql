@@ -0,0 +1,13 @@
+-- Widen question body so it can hold markdown
+-- SQLite can't alter column type, so the table is rebuilt.
+CREATE TABLE IF NOT EXISTS question_new (
+    id INTEGER PRIMARY KEY,
+    body TEXT
+);
+
+INSERT INTO question_new (id, body)
+SELECT id, body FROM question;
+
+DROP TABLE question;
+
+ALTER TABLE question_new RENAME TO question;
@@ -0,0 +1,7 @@
+-- Drop table question_hint
+DROP TABLE IF EXISTS question_hint;
+
+-- Drop explanation columns
+ALTER TABLE question_option DROP COLUMN explanation;
+
+ALTER TABLE question DROP COLUMN explanation;
@@ -0,0 +1,18 @@
+-- Add explanation to question and question_option
+ALTER TABLE question ADD COLUMN explanation TEXT;
+
+ALTER TABLE question_option ADD COLUMN explanation TEXT;
+
+-- Create question_hint table
+-- Position orders hints of a question starting from 1.
+CREATE TABLE IF NOT EXISTS question_hint (
+    id INTEGER PRIMARY KEY,
+    question_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    body TEXT,
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE,
+    UNIQUE (question_id, position)
+);
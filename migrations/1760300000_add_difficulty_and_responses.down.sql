@@ -0,0 +1,12 @@
+-- Drop table question_response_option
+DROP TABLE IF EXISTS question_response_option;
+
+-- Drop table question_response
+DROP TABLE IF EXISTS question_response;
+
+-- Drop difficulty columns
+ALTER TABLE question DROP COLUMN response_count;
+
+ALTER TABLE question DROP COLUMN empirical_difficulty;
+
+ALTER TABLE question DROP COLUMN difficulty;
@@ -0,0 +1,34 @@
+-- Add author assigned and empirical difficulty to question
+-- Difficulty is a level from 1 (easiest) to 5 (hardest).
+-- Empirical difficulty is the proportion of correct responses, recalculated periodically.
+ALTER TABLE question ADD COLUMN difficulty INTEGER;
+
+ALTER TABLE question ADD COLUMN empirical_difficulty REAL;
+
+ALTER TABLE question ADD COLUMN response_count INTEGER NOT NULL DEFAULT 0;
+
+-- Create question_response table
+-- For correct, 1 = true & 0 = false
+CREATE TABLE IF NOT EXISTS question_response (
+    id INTEGER PRIMARY KEY,
+    question_id INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    answered_at DATETIME NOT NULL,
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_response_question_id ON question_response (question_id);
+
+-- Create question_response_option table which holds options selected in a response
+CREATE TABLE IF NOT EXISTS question_response_option (
+    response_id INTEGER NOT NULL,
+    option_id INTEGER NOT NULL,
+    PRIMARY KEY (response_id, option_id),
+    CONSTRAINT fk_question_response
+    FOREIGN KEY (response_id)
+    REFERENCES question_response(id)
+    ON DELETE CASCADE
+);
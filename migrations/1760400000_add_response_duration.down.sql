@@ -0,0 +1,4 @@
+-- Drop time spent answering
+DROP INDEX IF EXISTS idx_question_response_option_option_id;
+
+ALTER TABLE question_response DROP COLUMN duration_ms;
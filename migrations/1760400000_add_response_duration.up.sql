@@ -0,0 +1,4 @@
+-- Add time spent answering to question_response, in milliseconds
+ALTER TABLE question_response ADD COLUMN duration_ms INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_question_response_option_option_id ON question_response_option (option_id);
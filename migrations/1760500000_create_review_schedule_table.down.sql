@@ -0,0 +1,2 @@
+-- Drop table review_schedule
+DROP TABLE IF EXISTS review_schedule
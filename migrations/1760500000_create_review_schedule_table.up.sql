@@ -0,0 +1,18 @@
+-- Create review_schedule table
+-- Holds SM-2 scheduling state of a question for a caller supplied user identifier.
+CREATE TABLE IF NOT EXISTS review_schedule (
+    user_id VARCHAR(255) NOT NULL,
+    question_id INTEGER NOT NULL,
+    ease_factor REAL NOT NULL,
+    interval_days INTEGER NOT NULL,
+    repetitions INTEGER NOT NULL,
+    due_at DATETIME NOT NULL,
+    last_reviewed_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, question_id),
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_schedule_user_id_due_at ON review_schedule (user_id, due_at);
@@ -0,0 +1,8 @@
+-- Drop practice tables
+DROP TABLE IF EXISTS question_rating;
+
+DROP TABLE IF EXISTS user_rating;
+
+DROP TABLE IF EXISTS practice_answer;
+
+DROP TABLE IF EXISTS practice_session;
@@ -0,0 +1,45 @@
+-- Create practice_session table
+-- Holds adaptive practice sessions started by a caller supplied user identifier.
+CREATE TABLE IF NOT EXISTS practice_session (
+    id INTEGER PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+-- Create practice_answer table
+-- For correct, 1 = true & 0 = false
+-- Ratings hold the Elo ratings of the user and the question after the answer.
+CREATE TABLE IF NOT EXISTS practice_answer (
+    id INTEGER PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    question_id INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    user_rating REAL NOT NULL,
+    question_rating REAL NOT NULL,
+    answered_at DATETIME NOT NULL,
+    UNIQUE (session_id, question_id),
+    CONSTRAINT fk_practice_session
+    FOREIGN KEY (session_id)
+    REFERENCES practice_session(id)
+    ON DELETE CASCADE,
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE
+);
+
+-- Create user_rating table which holds current Elo rating of a user
+CREATE TABLE IF NOT EXISTS user_rating (
+    user_id VARCHAR(255) PRIMARY KEY,
+    rating REAL NOT NULL
+);
+
+-- Create question_rating table which holds current Elo rating of a question
+CREATE TABLE IF NOT EXISTS question_rating (
+    question_id INTEGER PRIMARY KEY,
+    rating REAL NOT NULL,
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE
+);
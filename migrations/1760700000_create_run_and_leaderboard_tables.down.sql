@@ -0,0 +1,4 @@
+-- Drop leaderboard and run tables
+DROP TABLE IF EXISTS leaderboard;
+
+DROP TABLE IF EXISTS run;
@@ -0,0 +1,32 @@
+-- Create run table
+-- Holds scored runs over the question bank submitted by players.
+CREATE TABLE IF NOT EXISTS run (
+    id INTEGER PRIMARY KEY,
+    player_name VARCHAR(255) NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_run_submitted_at ON run (submitted_at);
+
+-- Create leaderboard table
+-- Materialised best run of every player per time window, refreshed on each submission.
+CREATE TABLE IF NOT EXISTS leaderboard (
+    time_window VARCHAR(16) NOT NULL,
+    player_name VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (time_window, player_name),
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_time_window_rank ON leaderboard (time_window, rank);
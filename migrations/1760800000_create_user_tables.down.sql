@@ -0,0 +1,4 @@
+-- Drop user tables
+DROP TABLE IF EXISTS user_session;
+
+DROP TABLE IF EXISTS user_account;
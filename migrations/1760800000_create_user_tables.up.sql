@@ -0,0 +1,23 @@
+-- Create user_account table
+-- Password is stored as a bcrypt hash.
+CREATE TABLE IF NOT EXISTS user_account (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR(64) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+-- Create user_session table
+-- Only the sha256 hash of the session token is stored.
+CREATE TABLE IF NOT EXISTS user_session (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    CONSTRAINT fk_user_account
+    FOREIGN KEY (user_id)
+    REFERENCES user_account(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_session_user_id ON user_session (user_id);
@@ -0,0 +1,4 @@
+-- Drop token tables
+DROP TABLE IF EXISTS revoked_access_token;
+
+DROP TABLE IF EXISTS refresh_token;
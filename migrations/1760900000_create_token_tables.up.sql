@@ -0,0 +1,24 @@
+-- Create refresh_token table
+-- Only the sha256 hash of the refresh token is stored.
+-- Tokens rotated from the same login share the family id, so reuse of a rotated token revokes the whole family.
+CREATE TABLE IF NOT EXISTS refresh_token (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    issued_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME,
+    CONSTRAINT fk_user_account
+    FOREIGN KEY (user_id)
+    REFERENCES user_account(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_token_family_id ON refresh_token (family_id);
+
+-- Create revoked_access_token table
+-- Revocation list of access tokens by their jti, kept until the token would expire anyway.
+CREATE TABLE IF NOT EXISTS revoked_access_token (
+    jti VARCHAR(64) PRIMARY KEY,
+    expires_at DATETIME NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Drop table api_key
+DROP TABLE IF EXISTS api_key
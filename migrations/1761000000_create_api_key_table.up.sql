@@ -0,0 +1,13 @@
+-- Create api_key table
+-- Prefix identifies the key and is stored as is, the rest of the key only as a sha256 hash.
+-- Scopes are stored as a comma separated list, e.g. read,write.
+CREATE TABLE IF NOT EXISTS api_key (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    last_used_at DATETIME
+);
@@ -0,0 +1,2 @@
+-- Drop owner from question
+ALTER TABLE question DROP COLUMN owner;
@@ -0,0 +1,3 @@
+-- Add owner to question
+-- Owner is the caller id of the author who created the question, questions created before have no owner.
+ALTER TABLE question ADD COLUMN owner VARCHAR(255);
@@ -0,0 +1,6 @@
+-- Drop tenant from question and question_option
+DROP INDEX IF EXISTS idx_question_option_tenant;
+DROP INDEX IF EXISTS idx_question_tenant;
+
+ALTER TABLE question_option DROP COLUMN tenant_id;
+ALTER TABLE question DROP COLUMN tenant_id;
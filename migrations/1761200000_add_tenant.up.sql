@@ -0,0 +1,7 @@
+-- Add tenant to question and question_option
+-- Every question bank belongs to a tenant, existing questions and options belong to the default tenant.
+ALTER TABLE question ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE question_option ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS idx_question_tenant ON question (tenant_id, id);
+CREATE INDEX IF NOT EXISTS idx_question_option_tenant ON question_option (tenant_id, question_id);
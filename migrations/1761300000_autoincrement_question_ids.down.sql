@@ -0,0 +1,42 @@
+-- Let question and question_option reuse ids of deleted rows again
+CREATE TABLE IF NOT EXISTS question_old (
+    id INTEGER PRIMARY KEY,
+    body TEXT,
+    explanation TEXT,
+    difficulty INTEGER,
+    empirical_difficulty REAL,
+    response_count INTEGER NOT NULL DEFAULT 0,
+    owner VARCHAR(255),
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default'
+);
+
+INSERT INTO question_old (id, body, explanation, difficulty, empirical_difficulty, response_count, owner, tenant_id)
+SELECT id, body, explanation, difficulty, empirical_difficulty, response_count, owner, tenant_id FROM question;
+
+DROP TABLE question;
+
+ALTER TABLE question_old RENAME TO question;
+
+CREATE INDEX IF NOT EXISTS idx_question_tenant ON question (tenant_id, id);
+
+CREATE TABLE IF NOT EXISTS question_option_old (
+    id INTEGER PRIMARY KEY,
+    body VARCHAR(255),
+    correct BOOLEAN,
+    question_id INTEGER NOT NULL,
+    explanation TEXT,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    CONSTRAINT fk_question
+    FOREIGN KEY (question_id)
+    REFERENCES question(id)
+    ON DELETE CASCADE
+);
+
+INSERT INTO question_option_old (id, body, correct, question_id, explanation, tenant_id)
+SELECT id, body, correct, question_id, explanation, tenant_id FROM question_option;
+
+DROP TABLE question_option;
+
+ALTER TABLE question_option_old RENAME TO question_option;
+
+CREATE INDEX IF NOT EXISTS idx_question_option_tenant ON question_option (tenant_id, question_id);
@@ -0,0 +1,20 @@
+-- Restore leaderboard table, it is refreshed by the next submitted run
+DROP TABLE IF EXISTS player_best_run;
+
+CREATE TABLE IF NOT EXISTS leaderboard (
+    time_window VARCHAR(16) NOT NULL,
+    player_name VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (time_window, player_name),
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_time_window_rank ON leaderboard (time_window, rank);
@@ -0,0 +1,28 @@
+-- Replace leaderboard table with the best run of every player
+-- Rolling windows are ranked from runs within the window when read, so they can't go stale,
+-- while the all time ranking reads best runs kept up to date on every submission.
+DROP TABLE IF EXISTS leaderboard;
+
+CREATE TABLE IF NOT EXISTS player_best_run (
+    player_name VARCHAR(255) PRIMARY KEY,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_best_run_score ON player_best_run (score DESC, duration_ms);
+
+INSERT INTO player_best_run (player_name, run_id, score, total, duration_ms, submitted_at)
+SELECT player_name, id, score, total, duration_ms, submitted_at
+FROM (
+    SELECT id, player_name, score, total, duration_ms, submitted_at,
+    ROW_NUMBER() OVER (PARTITION BY player_name ORDER BY score DESC, duration_ms, id) AS position
+    FROM run
+)
+WHERE position = 1;
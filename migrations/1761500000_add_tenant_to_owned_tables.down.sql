@@ -0,0 +1,68 @@
+-- Drop tenant from tables holding data of a tenant
+CREATE TABLE IF NOT EXISTS player_best_run_old (
+    player_name VARCHAR(255) PRIMARY KEY,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+INSERT OR IGNORE INTO player_best_run_old (player_name, run_id, score, total, duration_ms, submitted_at)
+SELECT player_name, run_id, score, total, duration_ms, submitted_at FROM player_best_run
+ORDER BY score DESC, duration_ms;
+
+DROP TABLE player_best_run;
+
+ALTER TABLE player_best_run_old RENAME TO player_best_run;
+
+CREATE INDEX IF NOT EXISTS idx_player_best_run_score ON player_best_run (score DESC, duration_ms);
+
+CREATE TABLE IF NOT EXISTS user_account_old (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR(64) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+INSERT OR IGNORE INTO user_account_old (id, username, password_hash, created_at)
+SELECT id, username, password_hash, created_at FROM user_account ORDER BY id;
+
+DROP TABLE user_account;
+
+ALTER TABLE user_account_old RENAME TO user_account;
+
+CREATE TABLE IF NOT EXISTS user_rating_old (
+    user_id VARCHAR(255) PRIMARY KEY,
+    rating REAL NOT NULL
+);
+
+INSERT OR IGNORE INTO user_rating_old (user_id, rating) SELECT user_id, rating FROM user_rating;
+
+DROP TABLE user_rating;
+
+ALTER TABLE user_rating_old RENAME TO user_rating;
+
+DROP INDEX IF EXISTS idx_api_key_tenant;
+DROP INDEX IF EXISTS idx_run_tenant_submitted_at;
+DROP INDEX IF EXISTS idx_practice_session_tenant;
+DROP INDEX IF EXISTS idx_review_schedule_tenant_user_id_due_at;
+DROP INDEX IF EXISTS idx_question_response_tenant;
+DROP INDEX IF EXISTS idx_question_hint_tenant;
+DROP INDEX IF EXISTS idx_attachment_tenant;
+
+ALTER TABLE api_key DROP COLUMN tenant_id;
+ALTER TABLE refresh_token DROP COLUMN tenant_id;
+ALTER TABLE user_session DROP COLUMN tenant_id;
+ALTER TABLE run DROP COLUMN tenant_id;
+ALTER TABLE question_rating DROP COLUMN tenant_id;
+ALTER TABLE practice_answer DROP COLUMN tenant_id;
+ALTER TABLE practice_session DROP COLUMN tenant_id;
+ALTER TABLE review_schedule DROP COLUMN tenant_id;
+ALTER TABLE question_response DROP COLUMN tenant_id;
+ALTER TABLE question_hint DROP COLUMN tenant_id;
+ALTER TABLE attachment DROP COLUMN tenant_id;
@@ -0,0 +1,93 @@
+-- Add tenant to every table holding data of a tenant
+-- Rows depending on a question take the tenant of the question, the other existing rows belong to the default tenant.
+ALTER TABLE attachment ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE question_hint ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE question_response ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE review_schedule ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE practice_session ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE practice_answer ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE question_rating ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE run ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE user_session ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE refresh_token ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE api_key ADD COLUMN tenant_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+UPDATE attachment SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM question q WHERE q.id = attachment.question_id),
+    (SELECT o.tenant_id FROM question_option o WHERE o.id = attachment.question_option_id),
+    tenant_id);
+UPDATE question_hint SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM question q WHERE q.id = question_hint.question_id), tenant_id);
+UPDATE question_response SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM question q WHERE q.id = question_response.question_id), tenant_id);
+UPDATE review_schedule SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM question q WHERE q.id = review_schedule.question_id), tenant_id);
+UPDATE question_rating SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM question q WHERE q.id = question_rating.question_id), tenant_id);
+UPDATE practice_session SET tenant_id = COALESCE(
+    (SELECT q.tenant_id FROM practice_answer a JOIN question q ON q.id = a.question_id
+    WHERE a.session_id = practice_session.id ORDER BY a.id LIMIT 1), tenant_id);
+UPDATE practice_answer SET tenant_id = COALESCE(
+    (SELECT s.tenant_id FROM practice_session s WHERE s.id = practice_answer.session_id), tenant_id);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_tenant ON attachment (tenant_id, id);
+CREATE INDEX IF NOT EXISTS idx_question_hint_tenant ON question_hint (tenant_id, question_id);
+CREATE INDEX IF NOT EXISTS idx_question_response_tenant ON question_response (tenant_id, question_id);
+CREATE INDEX IF NOT EXISTS idx_review_schedule_tenant_user_id_due_at ON review_schedule (tenant_id, user_id, due_at);
+CREATE INDEX IF NOT EXISTS idx_practice_session_tenant ON practice_session (tenant_id, id);
+CREATE INDEX IF NOT EXISTS idx_run_tenant_submitted_at ON run (tenant_id, submitted_at);
+CREATE INDEX IF NOT EXISTS idx_api_key_tenant ON api_key (tenant_id, id);
+
+-- Ratings of users, usernames and best runs of players are unique within their tenant.
+CREATE TABLE IF NOT EXISTS user_rating_new (
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    user_id VARCHAR(255) NOT NULL,
+    rating REAL NOT NULL,
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+INSERT INTO user_rating_new (user_id, rating) SELECT user_id, rating FROM user_rating;
+
+DROP TABLE user_rating;
+
+ALTER TABLE user_rating_new RENAME TO user_rating;
+
+CREATE TABLE IF NOT EXISTS user_account_new (
+    id INTEGER PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    username VARCHAR(64) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (tenant_id, username)
+);
+
+INSERT INTO user_account_new (id, username, password_hash, created_at)
+SELECT id, username, password_hash, created_at FROM user_account;
+
+DROP TABLE user_account;
+
+ALTER TABLE user_account_new RENAME TO user_account;
+
+CREATE TABLE IF NOT EXISTS player_best_run_new (
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    player_name VARCHAR(255) NOT NULL,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (tenant_id, player_name),
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+INSERT INTO player_best_run_new (player_name, run_id, score, total, duration_ms, submitted_at)
+SELECT player_name, run_id, score, total, duration_ms, submitted_at FROM player_best_run;
+
+DROP TABLE player_best_run;
+
+ALTER TABLE player_best_run_new RENAME TO player_best_run;
+
+CREATE INDEX IF NOT EXISTS idx_player_best_run_score ON player_best_run (tenant_id, score DESC, duration_ms);
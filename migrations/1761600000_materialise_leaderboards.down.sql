@@ -0,0 +1,28 @@
+-- Restore best runs of players from the runs
+DROP TABLE IF EXISTS leaderboard;
+
+CREATE TABLE IF NOT EXISTS player_best_run (
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    player_name VARCHAR(255) NOT NULL,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (tenant_id, player_name),
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_best_run_score ON player_best_run (tenant_id, score DESC, duration_ms);
+
+INSERT INTO player_best_run (tenant_id, player_name, run_id, score, total, duration_ms, submitted_at)
+SELECT tenant_id, player_name, id, score, total, duration_ms, submitted_at
+FROM (
+    SELECT id, tenant_id, player_name, score, total, duration_ms, submitted_at,
+    ROW_NUMBER() OVER (PARTITION BY tenant_id, player_name ORDER BY score DESC, duration_ms, id) AS position
+    FROM run
+)
+WHERE position = 1;
@@ -0,0 +1,33 @@
+-- Replace best runs of players with the materialised leaderboard of every window
+-- Leaderboards of a tenant are refreshed on each of its submissions. The all time leaderboard is rebuilt
+-- from existing runs, rolling windows are filled by the next submission.
+DROP TABLE IF EXISTS player_best_run;
+
+CREATE TABLE IF NOT EXISTS leaderboard (
+    tenant_id VARCHAR(255) NOT NULL DEFAULT 'default',
+    time_window VARCHAR(16) NOT NULL,
+    player_name VARCHAR(255) NOT NULL,
+    rank INTEGER NOT NULL,
+    run_id INTEGER NOT NULL,
+    score INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    submitted_at DATETIME NOT NULL,
+    PRIMARY KEY (tenant_id, time_window, player_name),
+    CONSTRAINT fk_run
+    FOREIGN KEY (run_id)
+    REFERENCES run(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_tenant_time_window_rank ON leaderboard (tenant_id, time_window, rank);
+
+INSERT INTO leaderboard (tenant_id, time_window, rank, player_name, run_id, score, total, duration_ms, submitted_at)
+SELECT tenant_id, 'all', RANK() OVER (PARTITION BY tenant_id ORDER BY score DESC, duration_ms), player_name, id,
+score, total, duration_ms, submitted_at
+FROM (
+    SELECT id, tenant_id, player_name, score, total, duration_ms, submitted_at,
+    ROW_NUMBER() OVER (PARTITION BY tenant_id, player_name ORDER BY score DESC, duration_ms, id) AS position
+    FROM run
+)
+WHERE position = 1;